@@ -51,6 +51,11 @@ func TestDatabase(t *testing.T) {
 		"commits",
 		"branch_commits",
 		"repositories",
+		"version_tickets",
+		"version_releases",
+		"repository_syncs",
+		"tickets",
+		"ticket_actions",
 	}
 
 	// 1. setup
@@ -0,0 +1,355 @@
+package branchmapper
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/ticket/ticketmodel"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+var (
+	// ErrLoadFromDB occurs if something went wrong on loading
+	ErrLoadFromDB = errors.New("failed to load branch from database")
+
+	// ErrNoData occurs if given model is nil
+	ErrNoData = errors.New("branch is nil")
+
+	// ErrSaveToDB occurs if something went wrong on saving
+	ErrSaveToDB = errors.New("failed to save branch to database")
+
+	// ErrDeleteFromDB occurs if something went wrong on deleting
+	ErrDeleteFromDB = errors.New("failed to delete branch from database")
+
+	// ErrNotFound occurs if record doesn't exist in database
+	ErrNotFound = errors.New("branch was not found")
+
+	// ErrPreconditionFailed occurs if the entity tag expected by the client doesn't match the one of the branch
+	ErrPreconditionFailed = errors.New("branch was modified or doesn't exist")
+
+	// ErrInvalid occurs if the model is invalid, the error returned is a *validation.Error listing the invalid fields
+	ErrInvalid = validation.ErrInvalid
+
+	// ErrDuplicate occurs if a branch with the same name already exists in the repository
+	ErrDuplicate = branchstore.ErrDuplicate
+)
+
+// Mapper provides methods to load and persist branch models
+type Mapper struct {
+	db *sqlx.DB
+}
+
+// New returns a new mapper
+func New(db *sqlx.DB) *Mapper {
+	return &Mapper{db: db}
+}
+
+// Load returns a branch model including its ticket loaded from database by ID
+func (m *Mapper) Load(ctx context.Context, id int) (*branchmodel.Branch, error) {
+	s := &branchstore.Branch{ID: id}
+
+	err := s.Read(ctx, m.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	}
+
+	model := storeToModel(s)
+
+	if s.TicketID == "" {
+		return model, nil
+	}
+
+	t := &ticketstore.Ticket{Key: s.TicketID}
+
+	err = t.ReadByKey(ctx, m.db)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	} else if err == nil {
+		model.Ticket = ticketToModel(t)
+	}
+
+	return model, nil
+}
+
+// List returns the branch models matching the filter including their tickets
+func (m *Mapper) List(ctx context.Context, filter *branchstore.Filter) (branchmodel.Branches, error) {
+	var branches branchstore.Branches
+	if err := branches.ReadByFilter(ctx, m.db, filter); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	}
+
+	var keys []string
+
+	for _, b := range branches {
+		if b.TicketID != "" {
+			keys = append(keys, b.TicketID)
+		}
+	}
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadByKeys(ctx, m.db, keys); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	}
+
+	byKey := tickets.ByKey()
+	models := make(branchmodel.Branches, 0, len(branches))
+
+	for _, b := range branches {
+		model := storeToModel(b)
+		if t, ok := byKey[b.TicketID]; ok {
+			model.Ticket = ticketToModel(t)
+		}
+
+		models = append(models, model)
+	}
+
+	return models, nil
+}
+
+// Save persists (create or update) the branch and returns the changed data (id, createdAt or modifiedAt). The
+// embedded ticket is ignored, but the ticket referenced by ID is created if it doesn't exist yet. Invalid branches are
+// not saved, a *validation.Error is returned instead.
+func (m *Mapper) Save(ctx context.Context, model *branchmodel.Branch) (*branchmodel.Branch, error) {
+	return m.SaveIfMatch(ctx, model, "")
+}
+
+// SaveIfMatch persists the branch like Save, if the value of an If-Match header is given the branch is only updated if
+// its entity tag matches, otherwise ErrPreconditionFailed is returned. The entity tag is checked by the update itself,
+// so a change in between fails the precondition as well. Missing branches never match, an empty value always does.
+func (m *Mapper) SaveIfMatch(
+	ctx context.Context,
+	model *branchmodel.Branch,
+	ifMatch string,
+) (*branchmodel.Branch, error) {
+	if model == nil {
+		return nil, ErrNoData
+	}
+
+	modifiedAt, err := m.precondition(ctx, model.ID, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.save(ctx, model, modifiedAt)
+}
+
+// save persists the branch, an existing branch is only updated if it wasn't modified since the time given
+func (m *Mapper) save(
+	ctx context.Context,
+	model *branchmodel.Branch,
+	modifiedAt *time.Time,
+) (*branchmodel.Branch, error) {
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := modelToStore(model)
+
+	var err error
+
+	switch {
+	case model.ID == 0:
+		err = s.Create(ctx, m.db)
+	case modifiedAt != nil:
+		err = s.UpdateUnmodified(ctx, m.db, *modifiedAt)
+	default:
+		err = s.Update(ctx, m.db)
+	}
+
+	switch {
+	case errors.Is(err, branchstore.ErrModified):
+		return nil, ErrPreconditionFailed
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrNotFound
+	case errors.Is(err, ErrDuplicate):
+		return nil, err
+	case errors.Is(err, branchstore.ErrRepositoryMissing):
+		invalid := &validation.Error{}
+		invalid.Add("repository_id", validation.CodeNotFound, "doesn't exist")
+
+		return nil, invalid
+	case err != nil:
+		return nil, fmt.Errorf("%w: %v", ErrSaveToDB, err)
+	}
+
+	if s.TicketID != "" {
+		if err := (&ticketstore.Ticket{Key: s.TicketID}).ReadOrCreate(ctx, m.db); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSaveToDB, err)
+		}
+	}
+
+	return m.Load(ctx, s.ID)
+}
+
+// ETag returns the entity tag of the model derived from the time it was modified
+func ETag(model *branchmodel.Branch) string {
+	if model == nil {
+		return ""
+	}
+
+	return etag.New(model.ModifiedAt)
+}
+
+// precondition returns the time the branch loaded by ID was modified at, if the value of an If-Match header matches
+// its entity tag. It is nil if no value is given.
+func (m *Mapper) precondition(ctx context.Context, id int, ifMatch string) (*time.Time, error) {
+	if ifMatch == "" {
+		return nil, nil
+	}
+
+	var current *branchmodel.Branch
+
+	if id != 0 {
+		var err error
+
+		current, err = m.Load(ctx, id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return match(ifMatch, current)
+}
+
+// match returns the time the branch was modified at, if the value of an If-Match header matches its entity tag. It is
+// nil if no value is given, missing branches never match.
+func match(ifMatch string, current *branchmodel.Branch) (*time.Time, error) {
+	if ifMatch == "" {
+		return nil, nil
+	}
+
+	if !etag.Match(ifMatch, ETag(current)) {
+		return nil, ErrPreconditionFailed
+	}
+
+	modifiedAt := current.ModifiedAt
+
+	return &modifiedAt, nil
+}
+
+// Patch applies the JSON merge patch (RFC 7396) to the branch loaded by ID, the result is validated before it is saved
+func (m *Mapper) Patch(ctx context.Context, id int, patch io.Reader) (*branchmodel.Branch, error) {
+	return m.PatchIfMatch(ctx, id, patch, "")
+}
+
+// PatchIfMatch applies the JSON merge patch like Patch, if the value of an If-Match header is given the branch is only
+// updated if its entity tag matches, otherwise ErrPreconditionFailed is returned. The entity tag is checked by the
+// update itself, so a change in between fails the precondition as well.
+func (m *Mapper) PatchIfMatch(
+	ctx context.Context,
+	id int,
+	patch io.Reader,
+	ifMatch string,
+) (*branchmodel.Branch, error) {
+	model, err := m.Load(ctx, id)
+	if errors.Is(err, ErrNotFound) && ifMatch != "" {
+		return nil, ErrPreconditionFailed
+	} else if err != nil {
+		return nil, err
+	}
+
+	modifiedAt, err := match(ifMatch, model)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = model.Patch(patch); err != nil {
+		return nil, err
+	}
+
+	return m.save(ctx, model, modifiedAt)
+}
+
+// Delete removes a model from database by ID including its commits and assignments to versions
+func (m *Mapper) Delete(ctx context.Context, id int) error {
+	return m.DeleteIfMatch(ctx, id, "")
+}
+
+// DeleteIfMatch removes a model from database by ID like Delete, if the value of an If-Match header is given the branch
+// is only removed if its entity tag matches, otherwise ErrPreconditionFailed is returned. The entity tag is checked by
+// the delete itself, so a change in between fails the precondition as well.
+func (m *Mapper) DeleteIfMatch(ctx context.Context, id int, ifMatch string) error {
+	modifiedAt, err := m.precondition(ctx, id, ifMatch)
+	if err != nil {
+		return err
+	}
+
+	s := &branchstore.Branch{ID: id}
+	if modifiedAt != nil {
+		err = s.DeleteUnmodified(ctx, m.db, *modifiedAt)
+	} else {
+		err = s.Delete(ctx, m.db)
+	}
+
+	switch {
+	case errors.Is(err, branchstore.ErrModified):
+		return ErrPreconditionFailed
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrNotFound
+	case err != nil:
+		return fmt.Errorf("%w: %v", ErrDeleteFromDB, err)
+	}
+
+	return nil
+}
+
+func storeToModel(s *branchstore.Branch) *branchmodel.Branch {
+	if s == nil {
+		return &branchmodel.Branch{}
+	}
+
+	return &branchmodel.Branch{
+		ID:           s.ID,
+		Name:         s.Name,
+		RepositoryID: s.RepositoryID,
+		TicketID:     s.TicketID,
+		Closed:       s.Closed,
+		BaseBranch:   s.BaseBranch,
+		CreatedAt:    s.CreatedAt,
+		ModifiedAt:   s.ModifiedAt,
+	}
+}
+
+func modelToStore(m *branchmodel.Branch) *branchstore.Branch {
+	if m == nil {
+		return &branchstore.Branch{}
+	}
+
+	return &branchstore.Branch{
+		ID:           m.ID,
+		Name:         m.Name,
+		RepositoryID: m.RepositoryID,
+		TicketID:     m.TicketID,
+		Closed:       m.Closed,
+		BaseBranch:   m.BaseBranch,
+		CreatedAt:    m.CreatedAt,
+		ModifiedAt:   m.ModifiedAt,
+	}
+}
+
+func ticketToModel(s *ticketstore.Ticket) *ticketmodel.Ticket {
+	return &ticketmodel.Ticket{
+		Key:        s.Key,
+		Summary:    s.Summary,
+		Status:     s.Status,
+		Category:   s.Category,
+		Type:       s.Type,
+		Parent:     s.Parent,
+		Assignee:   s.Assignee,
+		Priority:   s.Priority,
+		FetchedAt:  s.FetchedAt,
+		FetchError: s.FetchError,
+	}
+}
@@ -0,0 +1,397 @@
+package branchmapper_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+const (
+	testCluster = "test_branchmapper"
+)
+
+func setup(t *testing.T, name string) *sqlx.DB {
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	ticket := &ticketstore.Ticket{Key: "JIRA-1", Summary: "first", Status: "Open", Category: "todo", Type: "Story"}
+	if err := ticket.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	return db
+}
+
+func TestMapper_Load(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperLoad")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	if _, err := mapper.Save(context.Background(), &branchmodel.Branch{
+		Name:         "feature/JIRA-1",
+		RepositoryID: 1,
+		TicketID:     "JIRA-1",
+	}); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	actual, err := mapper.Load(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testBranch(t, &branchmodel.Branch{ID: 1, Name: "feature/JIRA-1", RepositoryID: 1, TicketID: "JIRA-1"}, actual)
+
+	if actual.Ticket == nil || actual.Ticket.Key != "JIRA-1" || actual.Ticket.Summary != "first" ||
+		actual.Ticket.Status != "Open" || actual.Ticket.Type != "Story" {
+		t.Errorf("expected ticket JIRA-1 to be embedded but got %#v", actual.Ticket)
+	}
+
+	_, err = mapper.Load(context.Background(), 2)
+	if !errors.Is(err, branchmapper.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrNotFound, err)
+	}
+}
+
+func TestMapper_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperList")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	for _, b := range []*branchmodel.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-1", RepositoryID: 1, TicketID: "JIRA-1"},
+	} {
+		if _, err := mapper.Save(context.Background(), b); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	actual, err := mapper.List(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(actual) != 2 || actual[0].Name != "feature/JIRA-1" || actual[1].Name != "master" {
+		t.Fatalf("expected all branches but got %#v", actual)
+	}
+
+	if actual[0].Ticket == nil || actual[0].Ticket.Category != "todo" || actual[1].Ticket != nil {
+		t.Errorf("expected only ticket of feature/JIRA-1 to be embedded but got %#v", actual)
+	}
+
+	actual, err = mapper.List(context.Background(), &branchstore.Filter{Category: "done"})
+	if err != nil || len(actual) != 0 {
+		t.Errorf("expected no branches but got %d: %v", len(actual), err)
+	}
+}
+
+func TestMapper_Save(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperSave")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	// 2. test
+	testCases := []struct {
+		name           string
+		actual         *branchmodel.Branch
+		expected       *branchmodel.Branch
+		expectedTicket string
+		expectedErr    error
+	}{
+		{
+			name:        "model is nil",
+			expectedErr: branchmapper.ErrNoData,
+		},
+		{
+			name:        "model is invalid",
+			actual:      &branchmodel.Branch{Name: "master"},
+			expectedErr: branchmapper.ErrInvalid,
+		},
+		{
+			name:     "model has no ID",
+			actual:   &branchmodel.Branch{Name: "master", RepositoryID: 1},
+			expected: &branchmodel.Branch{ID: 1, Name: "master", RepositoryID: 1},
+		},
+		{
+			name:           "model has ID",
+			actual:         &branchmodel.Branch{ID: 1, Name: "feature/JIRA-2", RepositoryID: 1, TicketID: "JIRA-2"},
+			expected:       &branchmodel.Branch{ID: 1, Name: "feature/JIRA-2", RepositoryID: 1, TicketID: "JIRA-2"},
+			expectedTicket: "JIRA-2",
+		},
+		{
+			name:        "model is duplicate",
+			actual:      &branchmodel.Branch{Name: "feature/JIRA-2", RepositoryID: 1},
+			expectedErr: branchmapper.ErrDuplicate,
+		},
+		{
+			name:        "update not existing model",
+			actual:      &branchmodel.Branch{ID: 3, Name: "develop", RepositoryID: 1},
+			expectedErr: branchmapper.ErrNotFound,
+		},
+		{
+			name:        "repository not existing",
+			actual:      &branchmodel.Branch{Name: "develop", RepositoryID: 42},
+			expectedErr: branchmapper.ErrInvalid,
+		},
+		{
+			name:        "update with repository not existing",
+			actual:      &branchmodel.Branch{ID: 1, Name: "develop", RepositoryID: 42},
+			expectedErr: branchmapper.ErrInvalid,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := mapper.Save(context.Background(), testCase.actual)
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+			}
+
+			testBranch(t, testCase.expected, res)
+
+			if testCase.expectedTicket != "" && (res.Ticket == nil || res.Ticket.Key != testCase.expectedTicket) {
+				t.Errorf("expected ticket '%s' to be created but got %#v", testCase.expectedTicket, res.Ticket)
+			}
+		})
+	}
+}
+
+func TestMapper_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperDelete")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	res, err := mapper.Save(context.Background(), &branchmodel.Branch{Name: "master", RepositoryID: 1})
+	if err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	if err = mapper.Delete(context.Background(), 0); !errors.Is(err, branchmapper.ErrDeleteFromDB) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrDeleteFromDB, err)
+	}
+
+	if err = mapper.Delete(context.Background(), res.ID); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if err = mapper.Delete(context.Background(), res.ID); !errors.Is(err, branchmapper.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrNotFound, err)
+	}
+
+	if _, err = mapper.Load(context.Background(), res.ID); !errors.Is(err, branchmapper.ErrNotFound) {
+		t.Errorf("expected that branch was deleted but got error '%v'", err)
+	}
+}
+
+func TestMapper_Patch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperPatch")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	model := &branchmodel.Branch{Name: "feature/x", RepositoryID: 1, BaseBranch: "main"}
+
+	res, err := mapper.Save(context.Background(), model)
+	if err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	actual, err := mapper.Patch(context.Background(), res.ID, strings.NewReader(`{"ticket_id": "JIRA-1"}`))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	model.ID = res.ID
+	model.TicketID = "JIRA-1"
+	testBranch(t, model, actual)
+
+	if actual.Ticket == nil || actual.Ticket.Summary != "first" {
+		t.Errorf("expected ticket JIRA-1 to be embedded but got %#v", actual.Ticket)
+	}
+
+	_, err = mapper.Patch(context.Background(), res.ID, strings.NewReader(`{"name": null}`))
+	if !errors.Is(err, branchmapper.ErrInvalid) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrInvalid, err)
+	}
+
+	_, err = mapper.Patch(context.Background(), 99, strings.NewReader(`{}`))
+	if !errors.Is(err, branchmapper.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrNotFound, err)
+	}
+}
+
+func TestMapper_IfMatch(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperIfMatch")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	res, err := mapper.Save(context.Background(), &branchmodel.Branch{Name: "master", RepositoryID: 1})
+	if err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	tag := branchmapper.ETag(res)
+
+	// 2. test failing preconditions
+	_, err = mapper.SaveIfMatch(context.Background(), &branchmodel.Branch{ID: res.ID, Name: "main", RepositoryID: 1},
+		`"outdated"`)
+	if !errors.Is(err, branchmapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrPreconditionFailed, err)
+	}
+
+	_, err = mapper.SaveIfMatch(context.Background(), &branchmodel.Branch{Name: "main", RepositoryID: 1}, "*")
+	if !errors.Is(err, branchmapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrPreconditionFailed, err)
+	}
+
+	_, err = mapper.PatchIfMatch(context.Background(), 99, strings.NewReader(`{}`), "*")
+	if !errors.Is(err, branchmapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrPreconditionFailed, err)
+	}
+
+	err = mapper.DeleteIfMatch(context.Background(), 99, "*")
+	if !errors.Is(err, branchmapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrPreconditionFailed, err)
+	}
+
+	// 3. test matching preconditions, the entity tag changes with every modification
+	time.Sleep(2 * time.Millisecond)
+
+	patched, err := mapper.PatchIfMatch(context.Background(), res.ID, strings.NewReader(`{"name": "main"}`), tag)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if patched.Name != "main" || branchmapper.ETag(patched) == tag {
+		t.Errorf("expected branch to be patched with new entity tag but got %#v", patched)
+	}
+
+	_, err = mapper.SaveIfMatch(context.Background(), res, tag)
+	if !errors.Is(err, branchmapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrPreconditionFailed, err)
+	}
+
+	err = mapper.DeleteIfMatch(context.Background(), res.ID, tag)
+	if !errors.Is(err, branchmapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", branchmapper.ErrPreconditionFailed, err)
+	}
+
+	if err = mapper.DeleteIfMatch(context.Background(), res.ID, branchmapper.ETag(patched)); err != nil {
+		t.Errorf("expected no error but got: %v", err)
+	}
+}
+
+func testBranch(t *testing.T, expected, actual *branchmodel.Branch) {
+	t.Helper()
+
+	if expected == nil && actual == nil {
+		return
+	}
+
+	if expected != nil && actual == nil || expected == nil && actual != nil {
+		t.Errorf("expected branch '%v' but got '%v'", expected, actual)
+		return
+	}
+
+	if expected.ID != actual.ID || expected.Name != actual.Name || expected.RepositoryID != actual.RepositoryID ||
+		expected.TicketID != actual.TicketID || expected.Closed != actual.Closed ||
+		expected.BaseBranch != actual.BaseBranch {
+		t.Errorf("expected branch '%#v' but got '%#v'", expected, actual)
+	}
+
+	if actual.CreatedAt.IsZero() {
+		t.Error("created at should be greater than the zero date")
+	}
+
+	if actual.ModifiedAt.IsZero() {
+		t.Error("modified at should be greater than the zero date")
+	}
+}
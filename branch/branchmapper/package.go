@@ -0,0 +1,2 @@
+// Package branchmapper provides functionality to read and persist branches
+package branchmapper
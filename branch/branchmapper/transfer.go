@@ -0,0 +1,173 @@
+package branchmapper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+)
+
+// ErrRepositoryNotFound occurs if the repository of a branch to import doesn't exist
+var ErrRepositoryNotFound = errors.New("repository of branch was not found")
+
+// repositoryKey identifies a repository independent of its ID
+type repositoryKey struct {
+	url  string
+	name string
+}
+
+// branchKey identifies a branch independent of its ID
+type branchKey struct {
+	repositoryID int
+	name         string
+}
+
+// repositories provides the repositories by ID and by url and name
+type repositories struct {
+	byID  map[int]*repositorystore.Repository
+	byKey map[repositoryKey]int
+}
+
+// loadRepositories returns all repositories
+func (m *Mapper) loadRepositories(ctx context.Context) (*repositories, error) {
+	var list repositorystore.Repositories
+	if err := list.ReadAll(ctx, m.db); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	}
+
+	r := &repositories{
+		byID:  make(map[int]*repositorystore.Repository, len(list)),
+		byKey: make(map[repositoryKey]int, len(list)),
+	}
+
+	for _, repository := range list {
+		r.byID[repository.ID] = repository
+		r.byKey[repositoryKey{url: repository.URL, name: repository.Name}] = repository.ID
+	}
+
+	return r, nil
+}
+
+// resolve sets the repository ID of the transfer, the name and url take precedence over the ID
+func (r *repositories) resolve(t *branchmodel.Transfer) error {
+	if t.RepositoryURL != "" || t.RepositoryName != "" {
+		id, ok := r.byKey[repositoryKey{url: t.RepositoryURL, name: t.RepositoryName}]
+		if !ok {
+			return fmt.Errorf("%w: name '%s' and url '%s'", ErrRepositoryNotFound, t.RepositoryName, t.RepositoryURL)
+		}
+
+		t.RepositoryID = id
+
+		return nil
+	}
+
+	if _, ok := r.byID[t.RepositoryID]; !ok && t.RepositoryID != 0 {
+		return fmt.Errorf("%w: id %d", ErrRepositoryNotFound, t.RepositoryID)
+	}
+
+	return nil
+}
+
+// Export returns the branch models matching the filter including the name and url of their repositories
+func (m *Mapper) Export(ctx context.Context, filter *branchstore.Filter) ([]*branchmodel.Transfer, error) {
+	repos, err := m.loadRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models, err := m.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*branchmodel.Transfer, 0, len(models))
+
+	for _, model := range models {
+		t := &branchmodel.Transfer{Branch: *model}
+		if r, ok := repos.byID[model.RepositoryID]; ok {
+			t.RepositoryName = r.Name
+			t.RepositoryURL = r.URL
+		}
+
+		transfers = append(transfers, t)
+	}
+
+	return transfers, nil
+}
+
+// Import creates or updates the branches of the rows. Branches are matched by repository and name, the IDs of the
+// rows are ignored. The repository is referenced by its name and url, or by ID if both are missing, so lists exported
+// by other instances can be imported. Like on saving, fields missing in a row reset the values of an existing branch.
+// In a dry run nothing is saved. The rows are saved one by one without a transaction: rows which are invalid or can't
+// be saved fail without affecting the others, so the branches of the rows saved before a failure are kept and the
+// result reports each row.
+func (m *Mapper) Import(ctx context.Context, rows []*bulk.Row, dryRun bool) (*bulk.Result, error) {
+	repos, err := m.loadRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var branches branchstore.Branches
+	if err = branches.ReadByFilter(ctx, m.db, nil); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	}
+
+	existing := make(map[branchKey]*branchstore.Branch, len(branches))
+	for _, b := range branches {
+		existing[branchKey{repositoryID: b.RepositoryID, name: b.Name}] = b
+	}
+
+	result := bulk.NewResult(dryRun)
+
+	for _, row := range rows {
+		t := &branchmodel.Transfer{}
+		if err = row.Decode(t); err != nil {
+			result.Fail(row, err)
+			continue
+		}
+
+		if err = repos.resolve(t); err != nil {
+			result.Fail(row, err)
+			continue
+		}
+
+		model := &t.Branch
+		model.ID = 0
+		model.Ticket = nil
+
+		if err = model.Validate(); err != nil {
+			result.Fail(row, err)
+			continue
+		}
+
+		k := branchKey{repositoryID: model.RepositoryID, name: model.Name}
+		action := bulk.ActionCreated
+
+		if current, ok := existing[k]; ok {
+			if current.TicketID == model.TicketID && current.Closed == model.Closed &&
+				current.BaseBranch == model.BaseBranch {
+				result.Add(&bulk.RowResult{Row: row.Number, Action: bulk.ActionUnchanged, ID: current.ID})
+				continue
+			}
+
+			model.ID = current.ID
+			action = bulk.ActionUpdated
+		}
+
+		if !dryRun {
+			if model, err = m.Save(ctx, model); err != nil {
+				result.Fail(row, err)
+				continue
+			}
+		}
+
+		existing[k] = modelToStore(model)
+		result.Add(&bulk.RowResult{Row: row.Number, Action: action, ID: model.ID})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,221 @@
+package branchmapper_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func setupTransfer(t *testing.T, name string) (*branchmapper.Mapper, *sqlx.DB) {
+	t.Helper()
+
+	db := setup(t, name)
+
+	repo := &repositorystore.Repository{Name: "other", URL: "https://host/other.git"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	mapper := branchmapper.New(db)
+	for _, b := range []*branchmodel.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-1", RepositoryID: 1, TicketID: "JIRA-1", BaseBranch: "master"},
+	} {
+		if _, err := mapper.Save(context.Background(), b); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	return mapper, db
+}
+
+func TestMapper_Export(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	mapper, db := setupTransfer(t, "mapperExport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	// 2. test
+	actual, err := mapper.Export(context.Background(), &branchstore.Filter{TicketID: "JIRA-1"})
+	if err != nil {
+		t.Fatalf("expected no error but got '%v'", err)
+	}
+
+	if len(actual) != 1 {
+		t.Fatalf("expected 1 branch but got %d", len(actual))
+	}
+
+	a := actual[0]
+	if a.ID != 2 || a.Name != "feature/JIRA-1" || a.RepositoryName != "repo" || a.RepositoryURL != "repo.url" {
+		t.Errorf("expected branch 2 of repository 'repo' but got %#v", a)
+	}
+
+	if a.Ticket == nil || a.Ticket.Summary != "first" {
+		t.Errorf("expected ticket to be embedded but got %#v", a.Ticket)
+	}
+}
+
+func TestMapper_Import(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	mapper, db := setupTransfer(t, "mapperImport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	// saving the branch named broken fails like the database would do unexpectedly
+	if _, err := db.Exec(`CREATE TRIGGER broken BEFORE INSERT ON branches WHEN NEW.branch_name = 'broken'
+		BEGIN SELECT RAISE(ABORT, 'broken'); END`); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name     string
+		format   bulk.Format
+		input    string
+		dryRun   bool
+		expected *bulk.Result
+		closed   bool
+	}{
+		{
+			name:   "dry run",
+			format: bulk.FormatYAML,
+			input: `
+- name: master
+  repository_id: 1
+- name: feature/JIRA-1
+  repository_id: 1
+  ticket_id: JIRA-1
+  base_branch: master
+  closed: true
+- name: develop
+  repository_name: other
+  repository_url: https://host/other.git
+- name: develop
+  repository_name: missing
+  repository_url: https://host/other.git
+- name: develop
+  repository_id: 9
+- name: ""
+  repository_id: 1
+`,
+			dryRun: true,
+			expected: &bulk.Result{
+				DryRun:    true,
+				Created:   1,
+				Updated:   1,
+				Unchanged: 1,
+				Failed:    3,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionUnchanged, ID: 1},
+					{Row: 2, Action: bulk.ActionUpdated, ID: 2},
+					{Row: 3, Action: bulk.ActionCreated},
+					{
+						Row:    4,
+						Action: bulk.ActionFailed,
+						Detail: "repository of branch was not found: name 'missing' and url 'https://host/other.git'",
+					},
+					{Row: 5, Action: bulk.ActionFailed, Detail: "repository of branch was not found: id 9"},
+					{
+						Row:    6,
+						Action: bulk.ActionFailed,
+						Errors: []*validation.FieldError{{Field: "name", Code: validation.CodeRequired}},
+					},
+				},
+			},
+		},
+		{
+			name:   "import",
+			format: bulk.FormatCSV,
+			input: "name,repository_id,ticket_id,closed,base_branch,repository_name,repository_url\n" +
+				"feature/JIRA-1,1,JIRA-1,true,master,,\n" +
+				"develop,,,,,other,https://host/other.git\n" +
+				"develop,2,,false,,,\n" +
+				"hotfix,1,,maybe,,,\n",
+			expected: &bulk.Result{
+				Created:   1,
+				Updated:   1,
+				Unchanged: 1,
+				Failed:    1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionUpdated, ID: 2},
+					{Row: 2, Action: bulk.ActionCreated, ID: 3},
+					{Row: 3, Action: bulk.ActionUnchanged, ID: 3},
+					{Row: 4, Action: bulk.ActionFailed, Detail: "failed to decode rows: closed must be a boolean"},
+				},
+			},
+			closed: true,
+		},
+		{
+			name:   "database failure",
+			format: bulk.FormatJSON,
+			input: `[
+				{"name": "broken", "repository_id": 1},
+				{"name": "feature/JIRA-1", "repository_id": 1, "ticket_id": "JIRA-1", "base_branch": "master"}
+			]`,
+			expected: &bulk.Result{
+				Updated: 1,
+				Failed:  1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionFailed},
+					{Row: 2, Action: bulk.ActionUpdated, ID: 2},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows, err := bulk.Decode(testCase.format, strings.NewReader(testCase.input))
+			if err != nil {
+				t.Fatalf("failed to decode rows: %v", err)
+			}
+
+			actual, err := mapper.Import(context.Background(), rows, testCase.dryRun)
+			if err != nil {
+				t.Fatalf("expected no error but got '%v'", err)
+			}
+
+			test.CheckResult(t, testCase.expected, actual)
+
+			updated, err := mapper.Load(context.Background(), 2)
+			if err != nil {
+				t.Fatalf("failed to load branch: %v", err)
+			}
+
+			if testCase.closed != updated.Closed {
+				t.Errorf("expected closed of updated branch to be %t but got %t", testCase.closed, updated.Closed)
+			}
+		})
+	}
+
+	created, err := mapper.Load(context.Background(), 3)
+	if err != nil || created.Name != "develop" || created.RepositoryID != 2 {
+		t.Errorf("expected branch develop of repository 2 but got %#v and error '%v'", created, err)
+	}
+}
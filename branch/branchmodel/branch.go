@@ -0,0 +1,173 @@
+package branchmodel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/mergepatch"
+	"github.com/rebel-l/branma_be/ticket/ticketmodel"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+const (
+	// MaxLengthName is the maximum length of the name, it matches the size of the column in the database
+	MaxLengthName = 250
+
+	// MaxLengthTicketID is the maximum length of the ticket ID, it matches the size of the column in the database
+	MaxLengthTicketID = 50
+
+	// MaxLengthBaseBranch is the maximum length of the base branch, it matches the size of the column in the database
+	MaxLengthBaseBranch = 250
+)
+
+var (
+	// ErrDecodeJSON occurs if the a string is not in JSON format
+	ErrDecodeJSON = errors.New("failed to decode JSON")
+)
+
+// Columns returns the columns of a branch in CSV, the embedded ticket is not part of it
+func Columns() []string {
+	return []string{"id", "name", "repository_id", "ticket_id", "closed", "base_branch", "created_at", "modified_at"}
+}
+
+// Branch represents a model of branch including business logic, the ticket is embedded read only
+type Branch struct {
+	ID           int                 `json:"id" yaml:"id"`
+	Name         string              `json:"name" yaml:"name"`
+	RepositoryID int                 `json:"repository_id" yaml:"repository_id"`
+	TicketID     string              `json:"ticket_id" yaml:"ticket_id"`
+	Ticket       *ticketmodel.Ticket `json:"ticket,omitempty" yaml:"ticket,omitempty"`
+	Closed       bool                `json:"closed" yaml:"closed"`
+	BaseBranch   string              `json:"base_branch" yaml:"base_branch"`
+	CreatedAt    time.Time           `json:"created_at" yaml:"created_at"`
+	ModifiedAt   time.Time           `json:"modified_at" yaml:"modified_at"`
+}
+
+// DecodeJSON converts JSON data to struct
+func (b *Branch) DecodeJSON(reader io.Reader) error {
+	if b == nil {
+		return nil
+	}
+
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(b); err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	return nil
+}
+
+// Patch applies the JSON merge patch (RFC 7396) to the branch, fields set to null are reset. The ID, created at and
+// modified at are kept, the embedded ticket is removed as it might not match the ticket ID anymore.
+func (b *Branch) Patch(reader io.Reader) error {
+	if b == nil {
+		return nil
+	}
+
+	patch, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	doc, err := json.Marshal(b)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	doc, err = mergepatch.Apply(doc, patch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	patched := &Branch{}
+	if err = json.Unmarshal(doc, patched); err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	patched.ID = b.ID
+	patched.CreatedAt = b.CreatedAt
+	patched.ModifiedAt = b.ModifiedAt
+	patched.Ticket = nil
+	*b = *patched
+
+	return nil
+}
+
+// Validate returns a *validation.Error listing all invalid fields, if the branch is valid nil is returned
+func (b *Branch) Validate() error {
+	if b == nil {
+		return nil
+	}
+
+	e := &validation.Error{}
+	e.Required("name", b.Name)
+	e.MaxLength("name", b.Name, MaxLengthName)
+
+	if b.RepositoryID <= 0 {
+		e.Add("repository_id", validation.CodeRequired, "is required")
+	}
+
+	e.MaxLength("ticket_id", b.TicketID, MaxLengthTicketID)
+	e.MaxLength("base_branch", b.BaseBranch, MaxLengthBaseBranch)
+
+	return e.Err()
+}
+
+// MarshalRecord returns the branch as row of CSV
+func (b *Branch) MarshalRecord() bulk.Record {
+	if b == nil {
+		return bulk.Record{}
+	}
+
+	return bulk.Record{
+		"id":            strconv.Itoa(b.ID),
+		"name":          b.Name,
+		"repository_id": strconv.Itoa(b.RepositoryID),
+		"ticket_id":     b.TicketID,
+		"closed":        strconv.FormatBool(b.Closed),
+		"base_branch":   b.BaseBranch,
+		"created_at":    bulk.FormatTime(b.CreatedAt),
+		"modified_at":   bulk.FormatTime(b.ModifiedAt),
+	}
+}
+
+// UnmarshalRecord sets the branch from a row of CSV, the created at and modified at are ignored as they are maintained
+// by the database
+func (b *Branch) UnmarshalRecord(record bulk.Record) error {
+	if b == nil {
+		return nil
+	}
+
+	id, err := record.Int("id")
+	if err != nil {
+		return err
+	}
+
+	repositoryID, err := record.Int("repository_id")
+	if err != nil {
+		return err
+	}
+
+	closed, err := record.Bool("closed")
+	if err != nil {
+		return err
+	}
+
+	*b = Branch{
+		ID:           id,
+		Name:         strings.TrimSpace(record["name"]),
+		RepositoryID: repositoryID,
+		TicketID:     strings.TrimSpace(record["ticket_id"]),
+		Closed:       closed,
+		BaseBranch:   strings.TrimSpace(record["base_branch"]),
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+package branchmodel_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/ticket/ticketmodel"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestBranch_DecodeJSON(t *testing.T) { // nolint:funlen
+	createdAt, _ := time.Parse(time.RFC3339Nano, "2020-02-09T03:36:57.9167778+01:00")
+	modifiedAt, _ := time.Parse(time.RFC3339Nano, "2020-02-10T15:44:57.9168378+01:00")
+
+	testCases := []struct {
+		name        string
+		actual      *branchmodel.Branch
+		json        io.Reader
+		expected    *branchmodel.Branch
+		expectedErr error
+	}{
+		{
+			name: "model is nil",
+		},
+		{
+			name:        "no JSON format",
+			actual:      &branchmodel.Branch{},
+			json:        bytes.NewReader([]byte("no JSON")),
+			expected:    &branchmodel.Branch{},
+			expectedErr: branchmodel.ErrDecodeJSON,
+		},
+		{
+			name:   "success",
+			actual: &branchmodel.Branch{},
+			json: bytes.NewReader([]byte(`{
+				"id": 1,
+				"name": "feature/JIRA-1",
+				"repository_id": 2,
+				"ticket_id": "JIRA-1",
+				"closed": true,
+				"base_branch": "master",
+				"created_at": "2020-02-09T03:36:57.9167778+01:00",
+				"modified_at": "2020-02-10T15:44:57.9168378+01:00"
+			}`)),
+			expected: &branchmodel.Branch{
+				ID:           1,
+				Name:         "feature/JIRA-1",
+				RepositoryID: 2,
+				TicketID:     "JIRA-1",
+				Closed:       true,
+				BaseBranch:   "master",
+				CreatedAt:    createdAt,
+				ModifiedAt:   modifiedAt,
+			},
+		},
+		{
+			name:     "empty json",
+			actual:   &branchmodel.Branch{},
+			json:     bytes.NewReader([]byte("{}")),
+			expected: &branchmodel.Branch{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.DecodeJSON(testCase.json)
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+				return
+			}
+
+			testBranch(t, testCase.expected, testCase.actual)
+		})
+	}
+}
+
+func TestBranch_Patch(t *testing.T) {
+	createdAt, _ := time.Parse(time.RFC3339Nano, "2020-02-09T03:36:57.9167778+01:00")
+	modifiedAt, _ := time.Parse(time.RFC3339Nano, "2020-02-10T15:44:57.9168378+01:00")
+
+	actual := &branchmodel.Branch{
+		ID:           1,
+		Name:         "feature/JIRA-1",
+		RepositoryID: 2,
+		TicketID:     "JIRA-1",
+		Ticket:       &ticketmodel.Ticket{Key: "JIRA-1"},
+		BaseBranch:   "main",
+		CreatedAt:    createdAt,
+		ModifiedAt:   modifiedAt,
+	}
+
+	err := actual.Patch(bytes.NewReader([]byte(`{"id": 3, "ticket_id": "JIRA-2", "closed": true, "base_branch": null}`)))
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testBranch(t, &branchmodel.Branch{
+		ID:           1,
+		Name:         "feature/JIRA-1",
+		RepositoryID: 2,
+		TicketID:     "JIRA-2",
+		Closed:       true,
+		CreatedAt:    createdAt,
+		ModifiedAt:   modifiedAt,
+	}, actual)
+
+	if actual.Ticket != nil {
+		t.Errorf("expected embedded ticket to be removed but got %#v", actual.Ticket)
+	}
+
+	if err = actual.Patch(bytes.NewReader([]byte(`[]`))); !errors.Is(err, branchmodel.ErrDecodeJSON) {
+		t.Errorf("expected error '%v' but got '%v'", branchmodel.ErrDecodeJSON, err)
+	}
+}
+
+func testBranch(t *testing.T, expected, actual *branchmodel.Branch) {
+	t.Helper()
+
+	if expected == nil && actual == nil {
+		return
+	}
+
+	if expected != nil && actual == nil || expected == nil && actual != nil {
+		t.Errorf("expected branch to be '%v' but got '%v'", expected, actual)
+		return
+	}
+
+	if expected.ID != actual.ID || expected.Name != actual.Name || expected.RepositoryID != actual.RepositoryID ||
+		expected.TicketID != actual.TicketID || expected.Closed != actual.Closed ||
+		expected.BaseBranch != actual.BaseBranch {
+		t.Errorf("expected branch '%#v' but got '%#v'", expected, actual)
+	}
+
+	if !expected.CreatedAt.Equal(actual.CreatedAt) {
+		t.Errorf("expected created at '%s' but got '%s'", expected.CreatedAt, actual.CreatedAt)
+	}
+
+	if !expected.ModifiedAt.Equal(actual.ModifiedAt) {
+		t.Errorf("expected modified at '%s' but got '%s'", expected.ModifiedAt, actual.ModifiedAt)
+	}
+}
+
+func TestBranch_Validate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		actual         *branchmodel.Branch
+		expectedFields []string
+	}{
+		{
+			name: "model is nil",
+		},
+		{
+			name:   "valid",
+			actual: &branchmodel.Branch{Name: "feature/JIRA-1", RepositoryID: 1, TicketID: "JIRA-1", BaseBranch: "master"},
+		},
+		{
+			name:           "empty",
+			actual:         &branchmodel.Branch{},
+			expectedFields: []string{"name", "repository_id"},
+		},
+		{
+			name: "too long",
+			actual: &branchmodel.Branch{
+				Name:         strings.Repeat("a", branchmodel.MaxLengthName+1),
+				RepositoryID: 1,
+				TicketID:     strings.Repeat("a", branchmodel.MaxLengthTicketID+1),
+				BaseBranch:   strings.Repeat("a", branchmodel.MaxLengthBaseBranch+1),
+			},
+			expectedFields: []string{"name", "ticket_id", "base_branch"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Validate()
+			if len(testCase.expectedFields) == 0 {
+				if err != nil {
+					t.Errorf("expected no error but got: %v", err)
+				}
+
+				return
+			}
+
+			var invalid *validation.Error
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected validation error but got: %v", err)
+			}
+
+			if len(testCase.expectedFields) != len(invalid.Errors) {
+				t.Fatalf("expected fields %v to be invalid but got: %v", testCase.expectedFields, err)
+			}
+
+			for i, field := range testCase.expectedFields {
+				if invalid.Errors[i].Field != field {
+					t.Errorf("expected field '%s' to be invalid but got '%s'", field, invalid.Errors[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestBranch_MarshalRecord(t *testing.T) {
+	actual := (&branchmodel.Branch{
+		ID:           1,
+		Name:         "feature/JIRA-1",
+		RepositoryID: 2,
+		TicketID:     "JIRA-1",
+		Ticket:       &ticketmodel.Ticket{Key: "JIRA-1"},
+		Closed:       true,
+		BaseBranch:   "main",
+		CreatedAt:    time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC),
+	}).MarshalRecord()
+
+	expected := bulk.Record{
+		"id":            "1",
+		"name":          "feature/JIRA-1",
+		"repository_id": "2",
+		"ticket_id":     "JIRA-1",
+		"closed":        "true",
+		"base_branch":   "main",
+		"created_at":    "2020-03-01T12:00:00Z",
+		"modified_at":   "",
+	}
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected record %v but got %v", expected, actual)
+	}
+
+	for _, column := range branchmodel.Columns() {
+		if expected[column] != actual[column] {
+			t.Errorf("expected column %s to be '%s' but got '%s'", column, expected[column], actual[column])
+		}
+	}
+}
+
+func TestBranch_UnmarshalRecord(t *testing.T) {
+	testCases := []struct {
+		name     string
+		record   bulk.Record
+		expected *branchmodel.Branch
+		err      error
+	}{
+		{
+			name: "success",
+			record: bulk.Record{
+				"id":            "1",
+				"name":          "feature/JIRA-1",
+				"repository_id": "2",
+				"ticket_id":     " JIRA-1",
+				"closed":        "true",
+				"base_branch":   "main",
+				"modified_at":   "yesterday",
+			},
+			expected: &branchmodel.Branch{
+				ID:           1,
+				Name:         "feature/JIRA-1",
+				RepositoryID: 2,
+				TicketID:     "JIRA-1",
+				Closed:       true,
+				BaseBranch:   "main",
+			},
+		},
+		{
+			name:     "empty values",
+			record:   bulk.Record{"name": "master", "repository_id": "", "closed": ""},
+			expected: &branchmodel.Branch{Name: "master"},
+		},
+		{
+			name:   "repository id not integer",
+			record: bulk.Record{"repository_id": "repo"},
+			err:    bulk.ErrDecode,
+		},
+		{
+			name:   "closed not boolean",
+			record: bulk.Record{"closed": "maybe"},
+			err:    bulk.ErrDecode,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := &branchmodel.Branch{ID: 5, Ticket: &ticketmodel.Ticket{Key: "JIRA-5"}}
+
+			err := actual.UnmarshalRecord(testCase.record)
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.err, err)
+			}
+
+			if err == nil && *testCase.expected != *actual {
+				t.Errorf("expected %#v but got %#v", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,4 @@
+package branchmodel
+
+// Branches represents a collection of Branch
+type Branches []*Branch
@@ -0,0 +1,2 @@
+// Package branchmodel provides functionality and business logic to manage branches
+package branchmodel
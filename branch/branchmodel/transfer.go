@@ -0,0 +1,49 @@
+package branchmodel
+
+import (
+	"strings"
+
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+// Transfer represents a branch as it is exported and imported. Besides by ID the repository is referenced by its name
+// and url, so branches can be moved between instances where the IDs of the repositories differ.
+type Transfer struct {
+	Branch         `yaml:",inline"`
+	RepositoryName string `json:"repository_name,omitempty" yaml:"repository_name,omitempty"`
+	RepositoryURL  string `json:"repository_url,omitempty" yaml:"repository_url,omitempty"`
+}
+
+// TransferColumns returns the columns of a transfer in CSV
+func TransferColumns() []string {
+	return append(Columns(), "repository_name", "repository_url")
+}
+
+// MarshalRecord returns the transfer as row of CSV
+func (t *Transfer) MarshalRecord() bulk.Record {
+	if t == nil {
+		return bulk.Record{}
+	}
+
+	record := t.Branch.MarshalRecord()
+	record["repository_name"] = t.RepositoryName
+	record["repository_url"] = t.RepositoryURL
+
+	return record
+}
+
+// UnmarshalRecord sets the transfer from a row of CSV
+func (t *Transfer) UnmarshalRecord(record bulk.Record) error {
+	if t == nil {
+		return nil
+	}
+
+	if err := t.Branch.UnmarshalRecord(record); err != nil {
+		return err
+	}
+
+	t.RepositoryName = strings.TrimSpace(record["repository_name"])
+	t.RepositoryURL = strings.TrimSpace(record["repository_url"])
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package branchmodel_test
+
+import (
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+func TestTransfer_Record(t *testing.T) {
+	expected := &branchmodel.Transfer{
+		Branch:         branchmodel.Branch{ID: 1, Name: "master", RepositoryID: 2},
+		RepositoryName: "branma",
+		RepositoryURL:  "/srv/git/branma",
+	}
+
+	record := expected.MarshalRecord()
+	if len(record) != len(branchmodel.TransferColumns()) {
+		t.Errorf("expected a value for every column %v but got %v", branchmodel.TransferColumns(), record)
+	}
+
+	if record["repository_name"] != "branma" || record["repository_url"] != "/srv/git/branma" {
+		t.Errorf("expected name and url of the repository but got %v", record)
+	}
+
+	actual := &branchmodel.Transfer{}
+	if err := actual.UnmarshalRecord(record); err != nil {
+		t.Fatalf("expected no error but got '%v'", err)
+	}
+
+	if *expected != *actual {
+		t.Errorf("expected %#v but got %#v", expected, actual)
+	}
+
+	if err := actual.UnmarshalRecord(bulk.Record{"id": "x"}); err == nil {
+		t.Error("expected error on invalid id but got none")
+	}
+}
@@ -2,10 +2,13 @@ package branchstore
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
 )
 
 var (
@@ -17,21 +20,27 @@ var (
 
 	// ErrDataMissing will be thrown if mandatory data is not set
 	ErrDataMissing = errors.New("no data or mandatory data missing")
+
+	// ErrDuplicate will be thrown if a branch with the same name already exists in the repository
+	ErrDuplicate = errors.New("branch with same name already exists in repository")
+
+	// ErrRepositoryMissing will be thrown if the repository of a branch to save doesn't exist
+	ErrRepositoryMissing = errors.New("repository of branch doesn't exist")
+
+	// ErrModified will be thrown if a branch to change was modified meanwhile or doesn't exist anymore
+	ErrModified = errors.New("branch was modified meanwhile or doesn't exist")
 )
 
 // Branch represents the branch in the database
 type Branch struct {
-	ID             int       `db:"id"`
-	Name           string    `db:"branch_name"`
-	TicketID       string    `db:"ticket_id"`
-	ParentTicketID string    `db:"parent_ticket_id"`
-	RepositoryID   int       `db:"repository_id"`
-	TicketSummary  string    `db:"ticket_summary"`
-	TicketStatus   string    `db:"ticket_status"`
-	TicketType     string    `db:"ticket_type"`
-	Closed         bool      `db:"closed"`
-	CreatedAt      time.Time `db:"created_at"`
-	ModifiedAt     time.Time `db:"modified_at"`
+	ID           int       `db:"id"`
+	Name         string    `db:"branch_name"`
+	TicketID     string    `db:"ticket_id"`
+	RepositoryID int       `db:"repository_id"`
+	Closed       bool      `db:"closed"`
+	BaseBranch   string    `db:"base_branch"`
+	CreatedAt    time.Time `db:"created_at"`
+	ModifiedAt   time.Time `db:"modified_at"`
 }
 
 // Create creates current branch in the database
@@ -47,19 +56,16 @@ func (b *Branch) Create(ctx context.Context, db *sqlx.DB) error {
 	q := db.Rebind(`
 		INSERT INTO branches (
 			ticket_id,
-			parent_ticket_id,
 			repository_id,
-			ticket_summary,
-			ticket_status,
-			ticket_type,
 			branch_name,
-			closed
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+			closed,
+			base_branch
+		) VALUES (?, ?, ?, ?, ?);
 	`)
 
 	res, err := db.ExecContext(ctx, q, b.getCreateArgs()...)
 	if err != nil {
-		return err
+		return constraint(err)
 	}
 
 	id, err := res.LastInsertId()
@@ -80,11 +86,44 @@ func (b *Branch) Read(ctx context.Context, db *sqlx.DB) error {
 
 	q := db.Rebind(`SELECT * FROM branches WHERE id = ?`)
 
-	return db.GetContext(ctx, b, q, b.ID)
+	return b.get(ctx, db, q, b.ID)
+}
+
+// ReadByName sets the branch from database by given name and repository ID
+func (b *Branch) ReadByName(ctx context.Context, db *sqlx.DB) error {
+	if b == nil || b.Name == "" || b.RepositoryID == 0 {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM branches WHERE branch_name = ? AND repository_id = ?`)
+
+	return b.get(ctx, db, q, b.Name, b.RepositoryID)
+}
+
+// get scans into a new branch, so the current one is untouched if loading fails
+func (b *Branch) get(ctx context.Context, db *sqlx.DB, q string, args ...interface{}) error {
+	res := &Branch{}
+	if err := db.GetContext(ctx, res, q, args...); err != nil {
+		return err
+	}
+
+	*b = *res
+
+	return nil
 }
 
 // Update changes the current branch on the database by ID
 func (b *Branch) Update(ctx context.Context, db *sqlx.DB) error {
+	return b.update(ctx, db, nil)
+}
+
+// UpdateUnmodified changes the current branch on the database by ID, if it wasn't modified since the given time.
+// Otherwise ErrModified is returned.
+func (b *Branch) UpdateUnmodified(ctx context.Context, db *sqlx.DB, modifiedAt time.Time) error {
+	return b.update(ctx, db, &modifiedAt)
+}
+
+func (b *Branch) update(ctx context.Context, db *sqlx.DB, modifiedAt *time.Time) error {
 	if !b.IsValid() {
 		return ErrDataMissing
 	}
@@ -93,37 +132,126 @@ func (b *Branch) Update(ctx context.Context, db *sqlx.DB) error {
 		return ErrIDMissing
 	}
 
+	condition, args := unmodified(modifiedAt)
 	q := db.Rebind(`
 		UPDATE branches 
 		SET ticket_id = ?,
-			parent_ticket_id = ?,
 			repository_id = ?,
-			ticket_summary = ?,
-			ticket_status = ?,
-			ticket_type = ?,
 			branch_name = ?,
-			closed = ?
-		WHERE id = ?
-	`)
+			closed = ?,
+			base_branch = ?
+		WHERE id = ?` + condition)
 
-	if _, err := db.ExecContext(ctx, q, b.getUpdateArgs()...); err != nil {
-		return err
+	res, err := db.ExecContext(ctx, q, append(b.getUpdateArgs(), args...)...)
+	if err != nil {
+		return constraint(err)
+	}
+
+	if modifiedAt != nil {
+		if err = modified(res); err != nil {
+			return err
+		}
 	}
 
 	return b.Read(ctx, db)
 }
 
-// Delete removes the current branch from database by its ID
+// Delete removes the current branch with its assignments to versions and its commits from database by its ID in one
+// transaction, versions having it as release branch lose the link. Returns sql.ErrNoRows if the branch doesn't exist.
 func (b *Branch) Delete(ctx context.Context, db *sqlx.DB) error {
+	return b.delete(ctx, db, nil)
+}
+
+// DeleteUnmodified removes the current branch like Delete, if it wasn't modified since the given time. Otherwise
+// nothing is removed and ErrModified is returned.
+func (b *Branch) DeleteUnmodified(ctx context.Context, db *sqlx.DB, modifiedAt time.Time) error {
+	return b.delete(ctx, db, &modifiedAt)
+}
+
+func (b *Branch) delete(ctx context.Context, db *sqlx.DB, modifiedAt *time.Time) (err error) {
 	if b == nil || b.ID == 0 {
 		return ErrIDMissing
 	}
 
-	q := db.Rebind(`DELETE FROM branches WHERE id = ?`)
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
 
-	_, err := db.ExecContext(ctx, q, b.ID)
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
 
-	return err
+	for _, q := range []string{
+		`UPDATE versions SET branch_id = NULL WHERE branch_id = ?`,
+		`DELETE FROM branch_commits WHERE branch_id = ?`,
+		`DELETE FROM branch_versions WHERE branch_id = ?`,
+	} {
+		if _, err = tx.ExecContext(ctx, tx.Rebind(q), b.ID); err != nil {
+			return err
+		}
+	}
+
+	condition, args := unmodified(modifiedAt)
+	q := tx.Rebind(`DELETE FROM branches WHERE id = ?` + condition)
+
+	res, err := tx.ExecContext(ctx, q, append([]interface{}{b.ID}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	if modifiedAt != nil {
+		err = modified(res)
+	} else {
+		err = deleted(res)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// deleted returns sql.ErrNoRows if the delete statement didn't remove any row
+func deleted(res sql.Result) error {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// unmodified returns the condition and its arguments matching only rows not modified since the given time, both are
+// empty if no time is given. The times are compared in the format SQLite stores them, so with milliseconds.
+func unmodified(modifiedAt *time.Time) (string, []interface{}) {
+	if modifiedAt == nil {
+		return "", nil
+	}
+
+	return ` AND strftime('%Y-%m-%d %H:%M:%f', modified_at) = ?`,
+		[]interface{}{modifiedAt.UTC().Format("2006-01-02 15:04:05.000")}
+}
+
+// modified returns ErrModified if the statement restricted to unmodified rows didn't affect any row
+func modified(res sql.Result) error {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrModified
+	}
+
+	return nil
 }
 
 // IsValid returns true if all mandatory fields are set
@@ -138,13 +266,10 @@ func (b *Branch) IsValid() bool {
 func (b *Branch) getCreateArgs() []interface{} {
 	return []interface{}{
 		b.TicketID,
-		b.ParentTicketID,
 		b.RepositoryID,
-		b.TicketSummary,
-		b.TicketStatus,
-		b.TicketType,
 		b.Name,
 		b.Closed,
+		b.BaseBranch,
 	}
 }
 
@@ -154,3 +279,21 @@ func (b *Branch) getUpdateArgs() []interface{} {
 
 	return args
 }
+
+// constraint returns ErrDuplicate if the error is caused by the unique index on name and repository and
+// ErrRepositoryMissing if it is caused by the foreign key of the repository
+func constraint(err error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintUnique:
+		return fmt.Errorf("%w: %v", ErrDuplicate, err)
+	case sqlite3.ErrConstraintForeignKey:
+		return fmt.Errorf("%w: %v", ErrRepositoryMissing, err)
+	default:
+		return err
+	}
+}
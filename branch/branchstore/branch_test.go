@@ -12,6 +12,7 @@ import (
 	"github.com/rebel-l/branma_be/branch/branchstore"
 	"github.com/rebel-l/branma_be/repository/repositorystore"
 	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
 )
 
 const (
@@ -66,40 +67,30 @@ func TestBranch_Create(t *testing.T) { // nolint:funlen
 		{
 			name: "success",
 			actual: &branchstore.Branch{
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
+				BaseBranch:   "master",
 			},
 			expected: &branchstore.Branch{
-				ID:             1,
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				ID:           1,
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
+				BaseBranch:   "master",
 			},
 		},
 		{
 			name: "duplicate",
 			actual: &branchstore.Branch{
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
 			},
-			expectedErr: errors.New("UNIQUE constraint failed: branches.branch_name, branches.repository_id"),
+			expectedErr: branchstore.ErrDuplicate,
 		},
 	}
 
@@ -151,24 +142,16 @@ func TestBranch_Read(t *testing.T) { // nolint:funlen
 		{
 			name: "success",
 			prepare: &branchstore.Branch{
-				Name:           "mybranch",
-				TicketID:       "ID-123",
-				ParentTicketID: "EPIC-123",
-				RepositoryID:   1,
-				TicketSummary:  "create a branch manager",
-				TicketStatus:   "done",
-				TicketType:     "story",
+				Name:         "mybranch",
+				TicketID:     "ID-123",
+				RepositoryID: 1,
 			},
 			actual: &branchstore.Branch{ID: 1},
 			expected: &branchstore.Branch{
-				ID:             1,
-				Name:           "mybranch",
-				TicketID:       "ID-123",
-				ParentTicketID: "EPIC-123",
-				RepositoryID:   1,
-				TicketSummary:  "create a branch manager",
-				TicketStatus:   "done",
-				TicketType:     "story",
+				ID:           1,
+				Name:         "mybranch",
+				TicketID:     "ID-123",
+				RepositoryID: 1,
 			},
 		},
 	}
@@ -186,6 +169,72 @@ func TestBranch_Read(t *testing.T) { // nolint:funlen
 	}
 }
 
+func TestBranch_ReadByName(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByName")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "testreporead", URL: "testreporead.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	prepare := &branchstore.Branch{Name: "feature/ID-123", TicketID: "ID-123", RepositoryID: repo.ID}
+	if err := prepare.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *branchstore.Branch
+		expected    *branchstore.Branch
+		expectedErr error
+	}{
+		{
+			name:        "branch is nil",
+			expectedErr: branchstore.ErrDataMissing,
+		},
+		{
+			name:        "repository ID not set",
+			actual:      &branchstore.Branch{Name: "feature/ID-123"},
+			expectedErr: branchstore.ErrDataMissing,
+		},
+		{
+			name:        "branch not existing",
+			actual:      &branchstore.Branch{Name: "feature/ID-456", RepositoryID: repo.ID},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name:   "success",
+			actual: &branchstore.Branch{Name: "feature/ID-123", RepositoryID: repo.ID},
+			expected: &branchstore.Branch{
+				ID:           1,
+				Name:         "feature/ID-123",
+				TicketID:     "ID-123",
+				RepositoryID: repo.ID,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.ReadByName(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+			testBranch(t, testCase.expected, testCase.actual)
+		})
+	}
+}
+
 func TestBranch_Update(t *testing.T) { // nolint:funlen
 	if testing.Short() {
 		t.Skip("long running test")
@@ -220,92 +269,66 @@ func TestBranch_Update(t *testing.T) { // nolint:funlen
 		{
 			name: "branch has no name",
 			actual: &branchstore.Branch{
-				ID:             1,
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				ID:           1,
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
 			},
 			expectedErr: branchstore.ErrDataMissing,
 		},
 		{
 			name: "branch has no repository id",
 			actual: &branchstore.Branch{
-				ID:             1,
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				ID:       1,
+				Name:     "myname",
+				TicketID: "JIRA-1",
+				Closed:   true,
 			},
 			expectedErr: repositorystore.ErrDataMissing,
 		},
 		{
 			name: "branch has no ID",
 			actual: &branchstore.Branch{
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
 			},
 			expectedErr: branchstore.ErrIDMissing,
 		},
 		{
 			name: "success",
 			prepare: &branchstore.Branch{
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
 			},
 			actual: &branchstore.Branch{
-				ID:             1,
-				Name:           "new name",
-				TicketID:       "NEW-1",
-				ParentTicketID: "NEW-2",
-				RepositoryID:   1,
-				TicketSummary:  "a new summary",
-				TicketStatus:   "done",
-				TicketType:     "bug",
-				Closed:         false,
+				ID:           1,
+				Name:         "new name",
+				TicketID:     "NEW-1",
+				RepositoryID: 1,
+				Closed:       false,
+				BaseBranch:   "release/1.0.0",
 			},
 			expected: &branchstore.Branch{
-				ID:             1,
-				Name:           "new name",
-				TicketID:       "NEW-1",
-				ParentTicketID: "NEW-2",
-				RepositoryID:   1,
-				TicketSummary:  "a new summary",
-				TicketStatus:   "done",
-				TicketType:     "bug",
-				Closed:         false,
+				ID:           1,
+				Name:         "new name",
+				TicketID:     "NEW-1",
+				RepositoryID: 1,
+				Closed:       false,
+				BaseBranch:   "release/1.0.0",
 			},
 		},
 		{
 			name: "not existing branch",
 			actual: &branchstore.Branch{
-				ID:             2,
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
-				Closed:         true,
+				ID:           2,
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
+				Closed:       true,
 			},
 			expectedErr: sql.ErrNoRows,
 		},
@@ -381,16 +404,17 @@ func TestRepository_Delete(t *testing.T) { // nolint:funlen
 		{
 			name: "success",
 			prepare: &branchstore.Branch{
-				Name:           "myname",
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				RepositoryID:   1,
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
+				Name:         "myname",
+				TicketID:     "JIRA-1",
+				RepositoryID: 1,
 			},
 			actual: &branchstore.Branch{ID: 1},
 		},
+		{
+			name:        "branch doesn't exist",
+			actual:      &branchstore.Branch{ID: 99},
+			expectedErr: sql.ErrNoRows,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -419,6 +443,124 @@ func TestRepository_Delete(t *testing.T) { // nolint:funlen
 	}
 }
 
+func TestBranch_Delete_References(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeDeleteReferences")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	repo := &repositorystore.Repository{Name: "testrepodeletereferences", URL: "testrepodeletereferences.url"}
+	if err := repo.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	branch := &branchstore.Branch{Name: "release/1.0.0", RepositoryID: repo.ID}
+	if err := branch.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0", BranchID: &branch.ID}
+	if err := version.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if err := (&versionstore.BranchVersion{BranchID: branch.ID, VersionID: version.ID}).Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, q := range []string{
+		`INSERT INTO commits (commit_hash) VALUES ('abc')`,
+		`INSERT INTO branch_commits (branch_id, commit_id) VALUES (1, 1)`,
+	} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	stale := branch.ModifiedAt.Add(-time.Second)
+	test.CheckErrors(t, branchstore.ErrModified, branch.DeleteUnmodified(ctx, db, stale))
+	test.CheckErrors(t, nil, branch.Read(ctx, db))
+	test.CheckErrors(t, nil, branch.Delete(ctx, db))
+	test.CheckErrors(t, sql.ErrNoRows, branch.Read(ctx, db))
+
+	for table, expected := range map[string]int{
+		"branch_versions": 0,
+		"branch_commits":  0,
+		"commits":         1,
+		"versions":        1,
+	} {
+		var actual int
+		if err := db.GetContext(ctx, &actual, `SELECT COUNT(*) FROM `+table); err != nil || actual != expected {
+			t.Errorf("expected %d rows in %s but got %d: %v", expected, table, actual, err)
+		}
+	}
+
+	if err := version.Read(ctx, db); err != nil || version.BranchID != nil {
+		t.Errorf("expected version to lose its release branch but got %v: %v", version.BranchID, err)
+	}
+}
+
+func TestBranch_Unmodified(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeUnmodified")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "testrepounmodified", URL: "testrepounmodified.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	current := &branchstore.Branch{Name: "myname", TicketID: "JIRA-1", RepositoryID: repo.ID}
+	if err := current.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	stale := current.ModifiedAt.Add(-time.Second)
+
+	// 2. test
+	update := &branchstore.Branch{ID: current.ID, Name: "changed", TicketID: "JIRA-1", RepositoryID: repo.ID}
+	test.CheckErrors(t, branchstore.ErrModified, update.UpdateUnmodified(context.Background(), db, stale))
+
+	actual := &branchstore.Branch{ID: current.ID}
+	if err := actual.Read(context.Background(), db); err != nil {
+		t.Fatalf("failed to read branch: %v", err)
+	}
+
+	if actual.Name != "myname" {
+		t.Errorf("expected modified branch not to be changed but got %#v", actual)
+	}
+
+	test.CheckErrors(t, nil, update.UpdateUnmodified(context.Background(), db, current.ModifiedAt))
+
+	if update.Name != "changed" {
+		t.Errorf("expected unmodified branch to be changed but got %#v", update)
+	}
+
+	test.CheckErrors(t, branchstore.ErrModified, update.DeleteUnmodified(context.Background(), db, stale))
+	test.CheckErrors(t, nil, update.DeleteUnmodified(context.Background(), db, update.ModifiedAt))
+	test.CheckErrors(t, branchstore.ErrModified, update.DeleteUnmodified(context.Background(), db, update.ModifiedAt))
+}
+
 func TestBranch_IsValid(t *testing.T) { // nolint:funlen
 	testCases := []struct {
 		name     string
@@ -453,79 +595,59 @@ func TestBranch_IsValid(t *testing.T) { // nolint:funlen
 		{
 			name: "ticket ID missing",
 			actual: &branchstore.Branch{
-				ID:             123,
-				Name:           "test",
-				RepositoryID:   456,
-				ParentTicketID: "JIRA-2",
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
+				ID:           123,
+				Name:         "test",
+				RepositoryID: 456,
 			},
 			expected: true,
 		},
 		{
 			name: "parent ticket ID missing",
 			actual: &branchstore.Branch{
-				ID:            123,
-				Name:          "test",
-				RepositoryID:  456,
-				TicketID:      "JIRA-1",
-				TicketSummary: "a nice summary",
-				TicketStatus:  "in progress",
-				TicketType:    "improvement",
+				ID:           123,
+				Name:         "test",
+				RepositoryID: 456,
+				TicketID:     "JIRA-1",
 			},
 			expected: true,
 		},
 		{
 			name: "ticket summary missing",
 			actual: &branchstore.Branch{
-				ID:             123,
-				Name:           "test",
-				RepositoryID:   456,
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
+				ID:           123,
+				Name:         "test",
+				RepositoryID: 456,
+				TicketID:     "JIRA-1",
 			},
 			expected: true,
 		},
 		{
 			name: "ticket status missing",
 			actual: &branchstore.Branch{
-				ID:             123,
-				Name:           "test",
-				RepositoryID:   456,
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				TicketSummary:  "a nice summary",
-				TicketType:     "improvement",
+				ID:           123,
+				Name:         "test",
+				RepositoryID: 456,
+				TicketID:     "JIRA-1",
 			},
 			expected: true,
 		},
 		{
 			name: "ticket type missing",
 			actual: &branchstore.Branch{
-				ID:             123,
-				Name:           "test",
-				RepositoryID:   456,
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
+				ID:           123,
+				Name:         "test",
+				RepositoryID: 456,
+				TicketID:     "JIRA-1",
 			},
 			expected: true,
 		},
 		{
 			name: "all data",
 			actual: &branchstore.Branch{
-				ID:             123,
-				Name:           "test",
-				RepositoryID:   456,
-				TicketID:       "JIRA-1",
-				ParentTicketID: "JIRA-2",
-				TicketSummary:  "a nice summary",
-				TicketStatus:   "in progress",
-				TicketType:     "improvement",
+				ID:           123,
+				Name:         "test",
+				RepositoryID: 456,
+				TicketID:     "JIRA-1",
 			},
 			expected: true,
 		},
@@ -564,30 +686,18 @@ func testBranch(t *testing.T, expected, actual *branchstore.Branch) {
 		t.Errorf("expected ticket ID '%s' but got '%s'", expected.TicketID, actual.TicketID)
 	}
 
-	if expected.ParentTicketID != actual.ParentTicketID {
-		t.Errorf("expected parent ticket ID '%s' but got '%s'", expected.ParentTicketID, actual.ParentTicketID)
-	}
-
 	if expected.RepositoryID != actual.RepositoryID {
 		t.Errorf("expected repository ID '%d' but got '%d'", expected.RepositoryID, actual.RepositoryID)
 	}
 
-	if expected.TicketSummary != actual.TicketSummary {
-		t.Errorf("expected ticket summary '%s' but got '%s'", expected.TicketSummary, actual.TicketSummary)
-	}
-
-	if expected.TicketStatus != actual.TicketStatus {
-		t.Errorf("expected ticket status '%s' but got '%s'", expected.TicketStatus, actual.TicketStatus)
-	}
-
-	if expected.TicketType != actual.TicketType {
-		t.Errorf("expected ticket type '%s' but got '%s'", expected.TicketType, actual.TicketType)
-	}
-
 	if expected.Closed != actual.Closed {
 		t.Errorf("expected close '%t' but got '%t'", expected.Closed, actual.Closed)
 	}
 
+	if expected.BaseBranch != actual.BaseBranch {
+		t.Errorf("expected base branch '%s' but got '%s'", expected.BaseBranch, actual.BaseBranch)
+	}
+
 	if actual.CreatedAt.IsZero() {
 		t.Error("created at should be greater than the zero date")
 	}
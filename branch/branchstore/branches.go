@@ -0,0 +1,118 @@
+package branchstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Branches represents a collection of Branch
+type Branches []*Branch
+
+// Filter restricts the branches to load, fields with zero values are ignored
+type Filter struct {
+	RepositoryID int
+	TicketID     string
+	Closed       *bool
+
+	// Status and Category are matched against the ticket of the branch
+	Status   string
+	Category string
+}
+
+// ReadByRepository loads all branches belonging to the given repository
+func (b *Branches) ReadByRepository(ctx context.Context, db *sqlx.DB, repositoryID int) error {
+	if b == nil {
+		return ErrDataMissing
+	}
+
+	if repositoryID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM branches WHERE repository_id = ? ORDER BY branch_name`)
+
+	return db.SelectContext(ctx, b, q, repositoryID)
+}
+
+// ReadByVersion loads all branches assigned to the given version
+func (b *Branches) ReadByVersion(ctx context.Context, db *sqlx.DB, versionID int) error {
+	if b == nil {
+		return ErrDataMissing
+	}
+
+	if versionID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`
+		SELECT b.* FROM branches b
+		INNER JOIN branch_versions bv ON bv.branch_id = b.id
+		WHERE bv.version_id = ?
+		ORDER BY b.branch_name
+	`)
+
+	return db.SelectContext(ctx, b, q, versionID)
+}
+
+// ReadWithTicket loads all branches belonging to a ticket ordered by ticket ID
+func (b *Branches) ReadWithTicket(ctx context.Context, db *sqlx.DB) error {
+	if b == nil {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM branches WHERE ticket_id != '' ORDER BY ticket_id, id`)
+
+	return db.SelectContext(ctx, b, q)
+}
+
+// ReadByFilter loads all branches matching the filter ordered by repository and name
+func (b *Branches) ReadByFilter(ctx context.Context, db *sqlx.DB, filter *Filter) error {
+	if b == nil {
+		return ErrDataMissing
+	}
+
+	var (
+		where []string
+		args  []interface{}
+	)
+
+	if filter == nil {
+		filter = &Filter{}
+	}
+
+	if filter.RepositoryID != 0 {
+		where = append(where, "b.repository_id = ?")
+		args = append(args, filter.RepositoryID)
+	}
+
+	if filter.TicketID != "" {
+		where = append(where, "b.ticket_id = ?")
+		args = append(args, filter.TicketID)
+	}
+
+	if filter.Closed != nil {
+		where = append(where, "b.closed = ?")
+		args = append(args, *filter.Closed)
+	}
+
+	if filter.Status != "" {
+		where = append(where, "t.status = ?")
+		args = append(args, filter.Status)
+	}
+
+	if filter.Category != "" {
+		where = append(where, "t.status_category = ?")
+		args = append(args, filter.Category)
+	}
+
+	q := `SELECT b.* FROM branches b LEFT JOIN tickets t ON t.ticket_key = b.ticket_id`
+	if len(where) > 0 {
+		q += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	q += " ORDER BY b.repository_id, b.branch_name"
+
+	return db.SelectContext(ctx, b, db.Rebind(q), args...)
+}
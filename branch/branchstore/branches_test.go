@@ -0,0 +1,269 @@
+package branchstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestBranches_ReadByRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByRepository")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, name := range []string{"repo1", "repo2"} {
+		repo := &repositorystore.Repository{Name: name, URL: name + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-1", RepositoryID: 1},
+		{Name: "master", RepositoryID: 2},
+	} {
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var branches branchstore.Branches
+	test.CheckErrors(t, branchstore.ErrIDMissing, branches.ReadByRepository(context.Background(), db, 0))
+
+	if err := branches.ReadByRepository(context.Background(), db, 1); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	expected := []string{"feature/JIRA-1", "master"}
+	if len(branches) != len(expected) {
+		t.Fatalf("expected %d branches but got %d", len(expected), len(branches))
+	}
+
+	for i, name := range expected {
+		if branches[i].Name != name || branches[i].RepositoryID != 1 {
+			t.Errorf("expected branch '%s' of repository 1 but got '%s' of repository %d",
+				name, branches[i].Name, branches[i].RepositoryID)
+		}
+	}
+}
+
+func TestBranches_ReadByVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByVersion")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, name := range []string{"feature/JIRA-2", "feature/JIRA-1", "feature/JIRA-3"} {
+		b := &branchstore.Branch{Name: name, RepositoryID: repo.ID}
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+
+		if name == "feature/JIRA-3" {
+			continue
+		}
+
+		bv := &versionstore.BranchVersion{BranchID: b.ID, VersionID: version.ID}
+		if err := bv.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var branches branchstore.Branches
+	test.CheckErrors(t, branchstore.ErrIDMissing, branches.ReadByVersion(context.Background(), db, 0))
+
+	if err := branches.ReadByVersion(context.Background(), db, version.ID); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(branches) != 2 || branches[0].Name != "feature/JIRA-1" || branches[1].Name != "feature/JIRA-2" {
+		t.Errorf("expected branches feature/JIRA-1 and feature/JIRA-2 but got %#v", branches)
+	}
+}
+
+func TestBranches_ReadWithTicket(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadWithTicket")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-2", TicketID: "JIRA-2", RepositoryID: 1},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 1, Closed: true},
+	} {
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var branches branchstore.Branches
+	if err := branches.ReadWithTicket(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	expected := []string{"JIRA-1", "JIRA-2"}
+	if len(branches) != len(expected) {
+		t.Fatalf("expected %d branches but got %d", len(expected), len(branches))
+	}
+
+	for i, ticketID := range expected {
+		if branches[i].TicketID != ticketID {
+			t.Errorf("expected branch of ticket '%s' but got '%s'", ticketID, branches[i].TicketID)
+		}
+	}
+}
+
+func TestBranches_ReadByFilter(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByFilter")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, name := range []string{"repo1", "repo2"} {
+		repo := &repositorystore.Repository{Name: name, URL: name + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, ticket := range []*ticketstore.Ticket{
+		{Key: "JIRA-1", Status: "Code Review", Category: "review"},
+		{Key: "JIRA-2", Status: "Done", Category: "done"},
+	} {
+		if err := ticket.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 1},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 2},
+		{Name: "feature/JIRA-2", TicketID: "JIRA-2", RepositoryID: 2, Closed: true},
+	} {
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	closed := true
+
+	// 2. test
+	testCases := []struct {
+		name     string
+		filter   *branchstore.Filter
+		expected []int
+	}{
+		{
+			name:     "no filter",
+			expected: []int{2, 1, 3, 4},
+		},
+		{
+			name:     "repository",
+			filter:   &branchstore.Filter{RepositoryID: 2},
+			expected: []int{3, 4},
+		},
+		{
+			name:     "ticket",
+			filter:   &branchstore.Filter{TicketID: "JIRA-1"},
+			expected: []int{2, 3},
+		},
+		{
+			name:     "closed",
+			filter:   &branchstore.Filter{Closed: &closed},
+			expected: []int{4},
+		},
+		{
+			name:     "status",
+			filter:   &branchstore.Filter{Status: "Done"},
+			expected: []int{4},
+		},
+		{
+			name:     "category and repository",
+			filter:   &branchstore.Filter{Category: "review", RepositoryID: 1},
+			expected: []int{2},
+		},
+		{
+			name:   "nothing matches",
+			filter: &branchstore.Filter{Category: "todo"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			var branches branchstore.Branches
+			if err := branches.ReadByFilter(context.Background(), db, testCase.filter); err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			if len(branches) != len(testCase.expected) {
+				t.Fatalf("expected %d branches but got %d", len(testCase.expected), len(branches))
+			}
+
+			for i, id := range testCase.expected {
+				if branches[i].ID != id {
+					t.Errorf("expected branch %d at position %d but got %d", id, i, branches[i].ID)
+				}
+			}
+		})
+	}
+}
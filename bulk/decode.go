@@ -0,0 +1,216 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// ErrDecode occurs if the input is not a list in the format, rows which can't be decoded into a model return it too
+	ErrDecode = errors.New("failed to decode rows")
+
+	// ErrNoRecordUnmarshaler occurs if a CSV row should be decoded into a model not implementing RecordUnmarshaler
+	ErrNoRecordUnmarshaler = errors.New("model can't be decoded from CSV")
+)
+
+// Record represents a row of a CSV table, the keys are the columns named by the header
+type Record map[string]string
+
+// RecordUnmarshaler is implemented by models which can be decoded from CSV
+type RecordUnmarshaler interface {
+	UnmarshalRecord(record Record) error
+}
+
+// Int returns the value of the column as integer, an empty value is zero
+func (r Record) Int(column string) (int, error) {
+	value := strings.TrimSpace(r[column])
+	if value == "" {
+		return 0, nil
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be an integer", ErrDecode, column)
+	}
+
+	return i, nil
+}
+
+// Bool returns the value of the column as boolean, an empty value is false
+func (r Record) Bool(column string) (bool, error) {
+	value := strings.TrimSpace(r[column])
+	if value == "" {
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be a boolean", ErrDecode, column)
+	}
+
+	return b, nil
+}
+
+// Row is a single row of the input, it is decoded into a model on demand, so a broken row doesn't fail the others
+type Row struct {
+	// Number is the position of the row starting with 1, the header of CSV is not counted
+	Number int
+
+	format Format
+	raw    []byte
+	record Record
+	err    error
+}
+
+// Decode decodes the row into the model, CSV rows need a model implementing RecordUnmarshaler
+func (r *Row) Decode(model interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+
+	var err error
+
+	switch r.format {
+	case FormatCSV:
+		u, ok := model.(RecordUnmarshaler)
+		if !ok {
+			return ErrNoRecordUnmarshaler
+		}
+
+		return u.UnmarshalRecord(r.record)
+	case FormatYAML:
+		err = yaml.Unmarshal(r.raw, model)
+	default:
+		err = json.Unmarshal(r.raw, model)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecode, err)
+	}
+
+	return nil
+}
+
+// Decode splits the list read from the reader into its rows, it fails only if the input is no list in the format
+func Decode(format Format, reader io.Reader) ([]*Row, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(reader)
+	case FormatYAML:
+		return decodeYAML(reader)
+	case FormatJSON:
+		return decodeJSON(reader)
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownFormat, format)
+	}
+}
+
+func decodeJSON(reader io.Reader) ([]*Row, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecode, err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return []*Row{}, nil
+	}
+
+	if data[0] != '[' {
+		return nil, fmt.Errorf("%w: expected a list", ErrDecode)
+	}
+
+	var list []json.RawMessage
+	if err = json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecode, err)
+	}
+
+	rows := make([]*Row, 0, len(list))
+	for i, raw := range list {
+		rows = append(rows, &Row{Number: i + 1, format: FormatJSON, raw: raw})
+	}
+
+	return rows, nil
+}
+
+func decodeYAML(reader io.Reader) ([]*Row, error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecode, err)
+	}
+
+	var list []interface{}
+	if err = yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecode, err)
+	}
+
+	rows := make([]*Row, 0, len(list))
+
+	for i, item := range list {
+		row := &Row{Number: i + 1, format: FormatYAML}
+
+		row.raw, err = yaml.Marshal(item)
+		if err != nil {
+			row.err = fmt.Errorf("%w: %v", ErrDecode, err)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func decodeCSV(reader io.Reader) ([]*Row, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return []*Row{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecode, err)
+	}
+
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+
+	rows := []*Row{}
+
+	for number := 1; ; number++ {
+		var fields []string
+
+		fields, err = r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrDecode, err)
+		}
+
+		row := &Row{Number: number, format: FormatCSV, record: Record{}}
+
+		if len(fields) != len(header) {
+			row.err = fmt.Errorf("%w: expected %d columns but got %d", ErrDecode, len(header), len(fields))
+		}
+
+		for i, field := range fields {
+			if i < len(header) {
+				row.record[header[i]] = field
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,158 @@
+package bulk_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+// item is a model to decode and encode in tests
+type item struct {
+	Name   string `json:"name" yaml:"name"`
+	Count  int    `json:"count" yaml:"count"`
+	Active bool   `json:"active" yaml:"active"`
+}
+
+func (i *item) UnmarshalRecord(record bulk.Record) error {
+	count, err := record.Int("count")
+	if err != nil {
+		return err
+	}
+
+	active, err := record.Bool("active")
+	if err != nil {
+		return err
+	}
+
+	*i = item{Name: record["name"], Count: count, Active: active}
+
+	return nil
+}
+
+func TestDecode(t *testing.T) { // nolint:funlen
+	testCases := []struct {
+		name     string
+		format   bulk.Format
+		input    string
+		expected []*item
+		errRows  []int
+		err      error
+	}{
+		{
+			name:     "json",
+			format:   bulk.FormatJSON,
+			input:    `[{"name": "a", "count": 1, "active": true}, {"name": "b", "count": "x"}, {"name": "c"}]`,
+			expected: []*item{{Name: "a", Count: 1, Active: true}, nil, {Name: "c"}},
+			errRows:  []int{2},
+		},
+		{
+			name:     "json empty",
+			format:   bulk.FormatJSON,
+			input:    ``,
+			expected: []*item{},
+		},
+		{
+			name:   "json no list",
+			format: bulk.FormatJSON,
+			input:  `{"name": "a"}`,
+			err:    bulk.ErrDecode,
+		},
+		{
+			name:     "yaml",
+			format:   bulk.FormatYAML,
+			input:    "- name: a\n  count: 1\n  active: true\n- name: b\n  count: [1]\n- name: c\n",
+			expected: []*item{{Name: "a", Count: 1, Active: true}, nil, {Name: "c"}},
+			errRows:  []int{2},
+		},
+		{
+			name:   "yaml no list",
+			format: bulk.FormatYAML,
+			input:  "name: a\n",
+			err:    bulk.ErrDecode,
+		},
+		{
+			name:     "csv",
+			format:   bulk.FormatCSV,
+			input:    "Name, count,active,unknown\na,1,true,x\nb,x,false,x\nc,,,\nd,1\n",
+			expected: []*item{{Name: "a", Count: 1, Active: true}, nil, {Name: "c"}, nil},
+			errRows:  []int{2, 4},
+		},
+		{
+			name:     "csv empty",
+			format:   bulk.FormatCSV,
+			input:    "",
+			expected: []*item{},
+		},
+		{
+			name:   "csv broken quotes",
+			format: bulk.FormatCSV,
+			input:  "name\n\"a\n",
+			err:    bulk.ErrDecode,
+		},
+		{
+			name:   "unknown format",
+			format: bulk.Format("xml"),
+			err:    bulk.ErrUnknownFormat,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows, err := bulk.Decode(testCase.format, strings.NewReader(testCase.input))
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.err, err)
+			}
+
+			if len(testCase.expected) != len(rows) {
+				t.Fatalf("expected %d rows but got %d", len(testCase.expected), len(rows))
+			}
+
+			var errRows []int
+
+			for i, row := range rows {
+				if row.Number != i+1 {
+					t.Errorf("expected row number %d but got %d", i+1, row.Number)
+				}
+
+				actual := &item{}
+				if err := row.Decode(actual); err != nil {
+					if !errors.Is(err, bulk.ErrDecode) {
+						t.Errorf("expected error '%v' but got '%v'", bulk.ErrDecode, err)
+					}
+
+					errRows = append(errRows, row.Number)
+
+					continue
+				}
+
+				if *testCase.expected[i] != *actual {
+					t.Errorf("expected row %d to be %#v but got %#v", row.Number, testCase.expected[i], actual)
+				}
+			}
+
+			if len(testCase.errRows) != len(errRows) {
+				t.Fatalf("expected rows %v to fail but got %v", testCase.errRows, errRows)
+			}
+
+			for i := range errRows {
+				if testCase.errRows[i] != errRows[i] {
+					t.Errorf("expected rows %v to fail but got %v", testCase.errRows, errRows)
+				}
+			}
+		})
+	}
+}
+
+func TestRow_Decode_NoRecordUnmarshaler(t *testing.T) {
+	rows, err := bulk.Decode(bulk.FormatCSV, strings.NewReader("name\na\n"))
+	if err != nil {
+		t.Fatalf("expected no error but got '%v'", err)
+	}
+
+	var actual struct{ Name string }
+	if err = rows[0].Decode(&actual); !errors.Is(err, bulk.ErrNoRecordUnmarshaler) {
+		t.Errorf("expected error '%v' but got '%v'", bulk.ErrNoRecordUnmarshaler, err)
+	}
+}
@@ -0,0 +1,97 @@
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// ErrEncode occurs if the models can't be written in the format
+	ErrEncode = errors.New("failed to encode rows")
+
+	// ErrNoRecordMarshaler occurs if a model should be written as CSV but doesn't implement RecordMarshaler
+	ErrNoRecordMarshaler = errors.New("model can't be encoded to CSV")
+)
+
+// RecordMarshaler is implemented by models which can be encoded to CSV
+type RecordMarshaler interface {
+	MarshalRecord() Record
+}
+
+// Encode writes the models as list in the format, the columns are the header of CSV and define the order of the
+// fields. Models written as CSV need to implement RecordMarshaler.
+func Encode(writer io.Writer, format Format, columns []string, models []interface{}) error {
+	if models == nil {
+		models = []interface{}{}
+	}
+
+	var err error
+
+	switch format {
+	case FormatCSV:
+		return encodeCSV(writer, columns, models)
+	case FormatYAML:
+		var data []byte
+		if data, err = yaml.Marshal(models); err == nil {
+			_, err = writer.Write(data)
+		}
+	case FormatJSON:
+		err = json.NewEncoder(writer).Encode(models)
+	default:
+		return fmt.Errorf("%w: '%s'", ErrUnknownFormat, format)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrEncode, err)
+	}
+
+	return nil
+}
+
+// FormatTime returns the time as value of a CSV column in RFC 3339, the zero time is empty
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+func encodeCSV(writer io.Writer, columns []string, models []interface{}) error {
+	w := csv.NewWriter(writer)
+	if err := w.Write(columns); err != nil {
+		return fmt.Errorf("%w: %v", ErrEncode, err)
+	}
+
+	for _, model := range models {
+		m, ok := model.(RecordMarshaler)
+		if !ok {
+			return ErrNoRecordMarshaler
+		}
+
+		record := m.MarshalRecord()
+		fields := make([]string, len(columns))
+
+		for i, column := range columns {
+			fields[i] = record[column]
+		}
+
+		if err := w.Write(fields); err != nil {
+			return fmt.Errorf("%w: %v", ErrEncode, err)
+		}
+	}
+
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("%w: %v", ErrEncode, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package bulk_test
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+func (i *item) MarshalRecord() bulk.Record {
+	return bulk.Record{"name": i.Name, "count": strconv.Itoa(i.Count), "active": strconv.FormatBool(i.Active)}
+}
+
+func TestEncode(t *testing.T) {
+	models := []interface{}{&item{Name: "a", Count: 1, Active: true}, &item{Name: "b, c"}}
+	columns := []string{"name", "count", "active"}
+
+	testCases := []struct {
+		name     string
+		format   bulk.Format
+		models   []interface{}
+		expected string
+		err      error
+	}{
+		{
+			name:     "json",
+			format:   bulk.FormatJSON,
+			models:   models,
+			expected: `[{"name":"a","count":1,"active":true},{"name":"b, c","count":0,"active":false}]` + "\n",
+		},
+		{
+			name:     "json empty",
+			format:   bulk.FormatJSON,
+			expected: "[]\n",
+		},
+		{
+			name:     "yaml",
+			format:   bulk.FormatYAML,
+			models:   models,
+			expected: "- name: a\n  count: 1\n  active: true\n- name: b, c\n  count: 0\n  active: false\n",
+		},
+		{
+			name:     "csv",
+			format:   bulk.FormatCSV,
+			models:   models,
+			expected: "name,count,active\na,1,true\n\"b, c\",0,false\n",
+		},
+		{
+			name:     "csv empty",
+			format:   bulk.FormatCSV,
+			expected: "name,count,active\n",
+		},
+		{
+			name:   "csv no record marshaler",
+			format: bulk.FormatCSV,
+			models: []interface{}{struct{ Name string }{Name: "a"}},
+			err:    bulk.ErrNoRecordMarshaler,
+		},
+		{
+			name:   "unknown format",
+			format: bulk.Format("xml"),
+			err:    bulk.ErrUnknownFormat,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var actual bytes.Buffer
+
+			err := bulk.Encode(&actual, testCase.format, columns, testCase.models)
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.err, err)
+			}
+
+			if err == nil && testCase.expected != actual.String() {
+				t.Errorf("expected '%s' but got '%s'", testCase.expected, actual.String())
+			}
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	if actual := bulk.FormatTime(time.Time{}); actual != "" {
+		t.Errorf("expected zero time to be empty but got '%s'", actual)
+	}
+
+	expected := "2020-03-01T12:00:00Z"
+	if actual := bulk.FormatTime(time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)); actual != expected {
+		t.Errorf("expected '%s' but got '%s'", expected, actual)
+	}
+}
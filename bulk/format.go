@@ -0,0 +1,62 @@
+package bulk
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// FormatJSON is a JSON array of objects
+	FormatJSON Format = "json"
+
+	// FormatCSV is a table with a header naming the columns
+	FormatCSV Format = "csv"
+
+	// FormatYAML is a YAML sequence of mappings
+	FormatYAML Format = "yaml"
+)
+
+// ErrUnknownFormat occurs if neither the query parameter nor the header name a supported format
+var ErrUnknownFormat = errors.New("unknown format, use json, csv or yaml")
+
+// Format is the format of the list of models
+type Format string
+
+// ContentType returns the content type of the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatYAML:
+		return "application/x-yaml"
+	default:
+		return "application/json"
+	}
+}
+
+// ParseFormat returns the format by its name like csv
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(name))); f {
+	case FormatJSON, FormatCSV, FormatYAML:
+		return f, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("%w: '%s'", ErrUnknownFormat, name)
+	}
+}
+
+// byMediaType returns the format of the media type, it is empty if the media type is not supported
+func byMediaType(mediaType string) Format {
+	switch mediaType {
+	case "application/json", "*/*":
+		return FormatJSON
+	case "text/csv", "application/csv":
+		return FormatCSV
+	case "application/x-yaml", "application/yaml", "text/yaml", "text/x-yaml":
+		return FormatYAML
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,35 @@
+package bulk_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected bulk.Format
+		err      error
+	}{
+		{name: "json", expected: bulk.FormatJSON},
+		{name: "CSV", expected: bulk.FormatCSV},
+		{name: "yaml", expected: bulk.FormatYAML},
+		{name: "yml", expected: bulk.FormatYAML},
+		{name: "xml", err: bulk.ErrUnknownFormat},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := bulk.ParseFormat(testCase.name)
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.err, err)
+			}
+
+			if testCase.expected != actual {
+				t.Errorf("expected format '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
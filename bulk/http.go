@@ -0,0 +1,85 @@
+package bulk
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/rebel-l/smis"
+)
+
+const (
+	// QueryKeyFormat is the query parameter to choose the format, it takes precedence over the headers
+	QueryKeyFormat = "format"
+
+	// QueryKeyDryRun is the query parameter to check an import without saving anything
+	QueryKeyDryRun = "dry_run"
+
+	// HeaderKeyAccept is the header naming the formats accepted by the client of an export
+	HeaderKeyAccept = "Accept"
+
+	// HeaderKeyContentDisposition is the header naming the file of an export
+	HeaderKeyContentDisposition = "Content-Disposition"
+)
+
+// NewFormat returns the format requested by the query parameter format or else by the header, which is the
+// Content-Type for imports and the Accept for exports. The first media type of the header being supported is used,
+// without query parameter and header the format is JSON.
+func NewFormat(request *http.Request, header string) (Format, error) {
+	if name := request.URL.Query().Get(QueryKeyFormat); name != "" {
+		return ParseFormat(name)
+	}
+
+	value := request.Header.Get(header)
+	if strings.TrimSpace(value) == "" {
+		return FormatJSON, nil
+	}
+
+	for _, candidate := range strings.Split(value, ",") {
+		mediaType, _, err := mime.ParseMediaType(candidate)
+		if err != nil {
+			continue
+		}
+
+		if f := byMediaType(mediaType); f != "" {
+			return f, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s '%s'", ErrUnknownFormat, header, value)
+}
+
+// DryRun returns true if the query parameter dry_run is set to true, a value not being a boolean is an error
+func DryRun(request *http.Request) (bool, error) {
+	value := request.URL.Query().Get(QueryKeyDryRun)
+	if value == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean", QueryKeyDryRun)
+	}
+
+	return dryRun, nil
+}
+
+// Write sends the models as attachment in the format, the format is the extension of the file name. Nothing is sent if
+// the models can't be encoded.
+func Write(writer http.ResponseWriter, format Format, name string, columns []string, models []interface{}) error {
+	var body bytes.Buffer
+	if err := Encode(&body, format, columns, models); err != nil {
+		return err
+	}
+
+	writer.Header().Set(smis.HeaderKeyContentType, format.ContentType())
+	writer.Header().Set(HeaderKeyContentDisposition, fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+	writer.WriteHeader(http.StatusOK)
+
+	_, err := writer.Write(body.Bytes())
+
+	return err
+}
@@ -0,0 +1,96 @@
+package bulk_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+func TestNewFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		query    string
+		header   string
+		expected bulk.Format
+		err      error
+	}{
+		{name: "default", expected: bulk.FormatJSON},
+		{name: "query", query: "?format=csv", header: "application/json", expected: bulk.FormatCSV},
+		{name: "query unknown", query: "?format=xml", err: bulk.ErrUnknownFormat},
+		{name: "header", header: "text/csv; charset=utf-8", expected: bulk.FormatCSV},
+		{name: "header list", header: "text/html, application/x-yaml;q=0.9", expected: bulk.FormatYAML},
+		{name: "header any", header: "*/*", expected: bulk.FormatJSON},
+		{name: "header unknown", header: "text/html", err: bulk.ErrUnknownFormat},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/export"+testCase.query, nil)
+			request.Header.Set(bulk.HeaderKeyAccept, testCase.header)
+
+			actual, err := bulk.NewFormat(request, bulk.HeaderKeyAccept)
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.err, err)
+			}
+
+			if testCase.expected != actual {
+				t.Errorf("expected format '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	testCases := []struct {
+		query    string
+		expected bool
+		err      bool
+	}{
+		{query: ""},
+		{query: "?dry_run=true", expected: true},
+		{query: "?dry_run=0"},
+		{query: "?dry_run=maybe", err: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.query, func(t *testing.T) {
+			actual, err := bulk.DryRun(httptest.NewRequest(http.MethodPost, "/import"+testCase.query, nil))
+			if testCase.err != (err != nil) {
+				t.Fatalf("expected error %t but got '%v'", testCase.err, err)
+			}
+
+			if testCase.expected != actual {
+				t.Errorf("expected %t but got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	if err := bulk.Write(w, bulk.FormatCSV, "items", []string{"name"}, []interface{}{&item{Name: "a"}}); err != nil {
+		t.Fatalf("expected no error but got '%v'", err)
+	}
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected code %d but got %d", http.StatusOK, w.Code)
+	}
+
+	if actual := w.Header().Get(smis.HeaderKeyContentType); actual != bulk.FormatCSV.ContentType() {
+		t.Errorf("expected content type '%s' but got '%s'", bulk.FormatCSV.ContentType(), actual)
+	}
+
+	expected := `attachment; filename="items.csv"`
+	if actual := w.Header().Get(bulk.HeaderKeyContentDisposition); actual != expected {
+		t.Errorf("expected content disposition '%s' but got '%s'", expected, actual)
+	}
+
+	if w.Body.String() != "name\na\n" {
+		t.Errorf("expected body 'name\\na\\n' but got '%s'", w.Body.String())
+	}
+}
@@ -0,0 +1,3 @@
+// Package bulk provides reading and writing lists of models in JSON, CSV or YAML for imports and exports, rows are
+// decoded one by one, so errors can be reported per row
+package bulk
@@ -0,0 +1,74 @@
+package bulk
+
+import (
+	"errors"
+
+	"github.com/rebel-l/branma_be/validation"
+)
+
+const (
+	// ActionCreated means the model of the row didn't exist and is created
+	ActionCreated = "created"
+
+	// ActionUpdated means the model of the row existed with different values and is updated
+	ActionUpdated = "updated"
+
+	// ActionUnchanged means the model of the row existed with the same values
+	ActionUnchanged = "unchanged"
+
+	// ActionFailed means the row was rejected, the reason is given by the detail and errors
+	ActionFailed = "failed"
+)
+
+// RowResult represents what an import did with a row, the ID is the one of the model created, updated or matched. In a
+// dry run nothing is saved and the ID of models to be created is zero.
+type RowResult struct {
+	Row    int                      `json:"row"`
+	Action string                   `json:"action"`
+	ID     int                      `json:"id,omitempty"`
+	Detail string                   `json:"detail,omitempty"`
+	Errors []*validation.FieldError `json:"errors,omitempty"`
+}
+
+// Result represents the outcome of an import, the rows are in the order of the input
+type Result struct {
+	DryRun    bool         `json:"dry_run"`
+	Created   int          `json:"created"`
+	Updated   int          `json:"updated"`
+	Unchanged int          `json:"unchanged"`
+	Failed    int          `json:"failed"`
+	Rows      []*RowResult `json:"rows"`
+}
+
+// NewResult returns an empty result
+func NewResult(dryRun bool) *Result {
+	return &Result{DryRun: dryRun, Rows: []*RowResult{}}
+}
+
+// Add appends the result of a row and counts its action
+func (r *Result) Add(row *RowResult) {
+	switch row.Action {
+	case ActionCreated:
+		r.Created++
+	case ActionUpdated:
+		r.Updated++
+	case ActionUnchanged:
+		r.Unchanged++
+	case ActionFailed:
+		r.Failed++
+	}
+
+	r.Rows = append(r.Rows, row)
+}
+
+// Fail appends the row as failed, the fields of a *validation.Error are listed as errors
+func (r *Result) Fail(row *Row, err error) {
+	res := &RowResult{Row: row.Number, Action: ActionFailed, Detail: err.Error()}
+
+	var invalid *validation.Error
+	if errors.As(err, &invalid) {
+		res.Errors = invalid.Errors
+	}
+
+	r.Add(res)
+}
@@ -0,0 +1,39 @@
+package bulk_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestResult(t *testing.T) {
+	result := bulk.NewResult(true)
+	result.Add(&bulk.RowResult{Row: 1, Action: bulk.ActionCreated})
+	result.Add(&bulk.RowResult{Row: 2, Action: bulk.ActionUpdated, ID: 2})
+	result.Add(&bulk.RowResult{Row: 3, Action: bulk.ActionUnchanged, ID: 3})
+
+	invalid := &validation.Error{}
+	invalid.Required("name", "")
+	result.Fail(&bulk.Row{Number: 4}, invalid)
+	result.Fail(&bulk.Row{Number: 5}, errors.New("broken"))
+
+	if !result.DryRun || result.Created != 1 || result.Updated != 1 || result.Unchanged != 1 || result.Failed != 2 {
+		t.Errorf("unexpected counts %#v", result)
+	}
+
+	if len(result.Rows) != 5 {
+		t.Fatalf("expected 5 rows but got %d", len(result.Rows))
+	}
+
+	row := result.Rows[3]
+	if row.Row != 4 || row.Action != bulk.ActionFailed || row.Detail != invalid.Error() || len(row.Errors) != 1 {
+		t.Errorf("unexpected result of invalid row %#v", row)
+	}
+
+	row = result.Rows[4]
+	if row.Row != 5 || row.Detail != "broken" || row.Errors != nil {
+		t.Errorf("unexpected result of failed row %#v", row)
+	}
+}
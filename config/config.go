@@ -32,6 +32,13 @@ type Config struct {
 	Git     *Git      `json:"git"`
 	Jira    *Jira     `json:"jira"`
 	Service *Service  `json:"service"`
+
+	// JiraInstances are further JIRA instances, the settings for sync, webhook and reports are taken from Jira only
+	JiraInstances []*Jira `json:"jira_instances"`
+
+	// TicketProviders maps the names of repositories to the provider of their tickets, repositories not configured
+	// track their tickets in JIRA
+	TicketProviders map[string]*TicketProvider `json:"ticket_providers"`
 }
 
 // Load loads the given JSON file into the struct
@@ -89,6 +96,32 @@ func (c *Config) GetJira() *Jira {
 	return c.Jira
 }
 
+// GetJiraInstances returns all JIRA instances configured with a base url, the one of Jira comes first
+func (c *Config) GetJiraInstances() []*Jira {
+	if c == nil {
+		return nil
+	}
+
+	var instances []*Jira
+
+	for _, j := range append([]*Jira{c.Jira}, c.JiraInstances...) {
+		if j.GetBaseURL() != "" {
+			instances = append(instances, j)
+		}
+	}
+
+	return instances
+}
+
+// GetTicketProviders returns the providers of tickets mapped by the names of the repositories
+func (c *Config) GetTicketProviders() map[string]*TicketProvider {
+	if c == nil {
+		return nil
+	}
+
+	return c.TicketProviders
+}
+
 // GetService returns the configuration for the service
 func (c *Config) GetService() *Service {
 	if c == nil {
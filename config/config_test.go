@@ -24,10 +24,15 @@ func getTestCasesConfig() []tcConfig {
 
 	gitBaseURL := "https://github.com"
 	gitPrefix := "live"
+	gitWorkPath := "./my_git_path/"
 
 	jiraBaseURL := "https://jira.atlassion.com"
 	jiraUser := "jira"
 	jiraPassword := "let me in"
+	legacyName := "legacy"
+	legacyBaseURL := "https://jira.example.com"
+	providerType := config.ProviderGitHub
+	providerRepository := "rebel-l/docs"
 
 	port := 3333
 	tc := tcConfig{
@@ -41,6 +46,8 @@ func getTestCasesConfig() []tcConfig {
 			Git: &config.Git{
 				BaseURL:             &gitBaseURL,
 				ReleaseBranchPrefix: &gitPrefix,
+				WorkPath:            &gitWorkPath,
+				ExpectedBases:       map[string]string{"Bug": config.BaseRelease},
 			},
 			Jira: &config.Jira{
 				BaseURL:  &jiraBaseURL,
@@ -50,6 +57,12 @@ func getTestCasesConfig() []tcConfig {
 			Service: &config.Service{
 				Port: &port,
 			},
+			JiraInstances: []*config.Jira{
+				{Name: &legacyName, ProjectKeys: []string{"OLD", "LEGACY"}, BaseURL: &legacyBaseURL},
+			},
+			TicketProviders: map[string]*config.TicketProvider{
+				"docs": {Type: &providerType, Repository: &providerRepository},
+			},
 		},
 	}
 
@@ -118,6 +131,27 @@ func testConfig(t *testing.T, expected, got *config.Config) {
 	testGit(t, expected.GetGit(), got.GetGit())
 	testJira(t, expected.GetJira(), got.GetJira())
 	testService(t, expected.GetService(), got.GetService())
+
+	if len(expected.JiraInstances) != len(got.JiraInstances) {
+		t.Fatalf("expected %d JIRA instances but got %d", len(expected.JiraInstances), len(got.JiraInstances))
+	}
+
+	for i := range expected.JiraInstances {
+		testJira(t, expected.JiraInstances[i], got.JiraInstances[i])
+	}
+
+	if len(expected.GetTicketProviders()) != len(got.GetTicketProviders()) {
+		t.Fatalf("expected %d ticket providers but got %d",
+			len(expected.GetTicketProviders()), len(got.GetTicketProviders()))
+	}
+
+	for repository, provider := range expected.GetTicketProviders() {
+		if got.GetTicketProviders()[repository].GetType() != provider.GetType() ||
+			got.GetTicketProviders()[repository].GetRepository() != provider.GetRepository() {
+			t.Errorf("expected ticket provider %#v for repository '%s' but got %#v",
+				provider, repository, got.GetTicketProviders()[repository])
+		}
+	}
 }
 
 func TestConfig_GetDB(t *testing.T) {
@@ -141,6 +175,38 @@ func TestConfig_GetJira(t *testing.T) {
 	}
 }
 
+func TestConfig_GetJiraInstances(t *testing.T) {
+	var cfg *config.Config
+	if cfg.GetJiraInstances() != nil {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+
+	cloud := "https://example.atlassian.net"
+	legacy := "https://jira.example.com"
+
+	cfg = config.New()
+	cfg.JiraInstances = []*config.Jira{{BaseURL: &legacy}, {}}
+
+	instances := cfg.GetJiraInstances()
+	if len(instances) != 1 || instances[0].GetBaseURL() != legacy {
+		t.Errorf("expected only instances with base url but got %#v", instances)
+	}
+
+	cfg.GetJira().BaseURL = &cloud
+
+	instances = cfg.GetJiraInstances()
+	if len(instances) != 2 || instances[0].GetBaseURL() != cloud || instances[1].GetBaseURL() != legacy {
+		t.Errorf("expected default instance to come first but got %#v", instances)
+	}
+}
+
+func TestConfig_GetTicketProviders(t *testing.T) {
+	var cfg *config.Config
+	if cfg.GetTicketProviders() != nil {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
 func TestConfig_GetService(t *testing.T) {
 	var cfg *config.Config
 	if cfg.GetService() == nil {
@@ -1,9 +1,32 @@
 package config
 
+const (
+	// DefaultPathToGitRepositories defines the path where the git repositories are cloned to
+	DefaultPathToGitRepositories = "./storage/git"
+
+	// DefaultMainBranch defines the branch all features and hotfixes end up in
+	DefaultMainBranch = "master"
+
+	// DefaultTagPattern defines the regular expression for tags marking a release, the first group is the version
+	DefaultTagPattern = `^v(\d+(?:\.\d+)*)$`
+
+	// BaseMain defines that branches are expected to be cut from the main branch
+	BaseMain = "main"
+
+	// BaseRelease defines that branches are expected to be cut from a release branch
+	BaseRelease = "release"
+)
+
 // Git provides the configuration for Git
 type Git struct {
 	BaseURL             *string `json:"base_url"`
 	ReleaseBranchPrefix *string `json:"release_branch_prefix"`
+	WorkPath            *string `json:"work_path"`
+	TagPattern          *string `json:"tag_pattern"`
+	MainBranch          *string `json:"main_branch"`
+
+	// ExpectedBases maps ticket types to the base branches are expected to be cut from, see BaseMain and BaseRelease
+	ExpectedBases map[string]string `json:"expected_bases"`
 }
 
 // GetBaseURL returns the base url
@@ -24,6 +47,48 @@ func (g *Git) GetReleaseBranchPrefix() string {
 	return *g.ReleaseBranchPrefix
 }
 
+// GetWorkPath returns the path where the git repositories are cloned to
+func (g *Git) GetWorkPath() string {
+	if g == nil || g.WorkPath == nil {
+		return DefaultPathToGitRepositories
+	}
+
+	return *g.WorkPath
+}
+
+// GetTagPattern returns the regular expression for tags marking a release
+func (g *Git) GetTagPattern() string {
+	if g == nil || g.TagPattern == nil {
+		return DefaultTagPattern
+	}
+
+	return *g.TagPattern
+}
+
+// GetMainBranch returns the name of the main branch
+func (g *Git) GetMainBranch() string {
+	if g == nil || g.MainBranch == nil {
+		return DefaultMainBranch
+	}
+
+	return *g.MainBranch
+}
+
+// GetExpectedBases returns the base branches are expected to be cut from by ticket type
+func (g *Git) GetExpectedBases() map[string]string {
+	if g == nil {
+		return nil
+	}
+
+	return g.ExpectedBases
+}
+
+// GetExpectedBase returns the base branches of the given ticket type are expected to be cut from, it is empty if
+// nothing is expected
+func (g *Git) GetExpectedBase(ticketType string) string {
+	return g.GetExpectedBases()[ticketType]
+}
+
 // Merge overwrites the values given by the config from parameter if they differ from default values
 func (g *Git) Merge(cfg *Git) {
 	if cfg == nil || g == nil {
@@ -37,4 +102,20 @@ func (g *Git) Merge(cfg *Git) {
 	if cfg.GetBaseURL() != "" {
 		g.BaseURL = cfg.BaseURL
 	}
+
+	if cfg.GetWorkPath() != DefaultPathToGitRepositories {
+		g.WorkPath = cfg.WorkPath
+	}
+
+	if cfg.GetTagPattern() != DefaultTagPattern {
+		g.TagPattern = cfg.TagPattern
+	}
+
+	if cfg.GetMainBranch() != DefaultMainBranch {
+		g.MainBranch = cfg.MainBranch
+	}
+
+	if len(cfg.GetExpectedBases()) > 0 {
+		g.ExpectedBases = cfg.ExpectedBases
+	}
 }
@@ -20,6 +20,51 @@ func TestGit_GetReleaseBranchPrefix(t *testing.T) {
 	}
 }
 
+func TestGit_GetWorkPath(t *testing.T) {
+	var git *config.Git
+	if git.GetWorkPath() != config.DefaultPathToGitRepositories {
+		t.Errorf(
+			"failed to retrieve default value '%s' from nil struct but got '%s'",
+			config.DefaultPathToGitRepositories,
+			git.GetWorkPath(),
+		)
+	}
+}
+
+func TestGit_GetTagPattern(t *testing.T) {
+	var git *config.Git
+	if git.GetTagPattern() != config.DefaultTagPattern {
+		t.Errorf(
+			"failed to retrieve default value '%s' from nil struct but got '%s'",
+			config.DefaultTagPattern,
+			git.GetTagPattern(),
+		)
+	}
+}
+
+func TestGit_GetExpectedBase(t *testing.T) {
+	var git *config.Git
+	if git.GetExpectedBase("Bug") != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+
+	git = &config.Git{ExpectedBases: map[string]string{"Bug": config.BaseRelease}}
+	if git.GetExpectedBase("Bug") != config.BaseRelease || git.GetExpectedBase("Story") != "" {
+		t.Errorf("failed to retrieve expected base by ticket type")
+	}
+}
+
+func TestGit_GetMainBranch(t *testing.T) {
+	var git *config.Git
+	if git.GetMainBranch() != config.DefaultMainBranch {
+		t.Errorf(
+			"failed to retrieve default value '%s' from nil struct but got '%s'",
+			config.DefaultMainBranch,
+			git.GetMainBranch(),
+		)
+	}
+}
+
 type tcGitMerge struct {
 	name      string
 	actual    *config.Git
@@ -34,9 +79,17 @@ func getTestCasesGitMerge(t *testing.T) []tcGitMerge { // nolint:dupl,funlen
 
 	baseURL := "git.url"
 	branchPrefix := "myprefix"
+	workPath := "/mygit"
+	tagPattern := "^release-(.*)$"
+	mainBranch := "main"
+	expectedBases := map[string]string{"Bug": config.BaseRelease}
 
 	newBaseURL := "mynew.url"
 	newBranchPrefix := "mynewprefix"
+	newWorkPath := "/mynewgit"
+	newTagPattern := "^(.*)$"
+	newMainBranch := "develop"
+	newExpectedBases := map[string]string{"Story": config.BaseMain}
 
 	// 1.
 	tc := tcGitMerge{
@@ -59,8 +112,22 @@ func getTestCasesGitMerge(t *testing.T) []tcGitMerge { // nolint:dupl,funlen
 	tc = tcGitMerge{
 		name:      "config has default values, parameter has values",
 		actual:    &config.Git{},
-		mergeWith: &config.Git{BaseURL: &baseURL, ReleaseBranchPrefix: &branchPrefix},
-		expected:  &config.Git{BaseURL: &baseURL, ReleaseBranchPrefix: &branchPrefix},
+		mergeWith: &config.Git{
+			BaseURL:             &baseURL,
+			ReleaseBranchPrefix: &branchPrefix,
+			WorkPath:            &workPath,
+			TagPattern:          &tagPattern,
+			MainBranch:          &mainBranch,
+			ExpectedBases:       expectedBases,
+		},
+		expected: &config.Git{
+			BaseURL:             &baseURL,
+			ReleaseBranchPrefix: &branchPrefix,
+			WorkPath:            &workPath,
+			TagPattern:          &tagPattern,
+			MainBranch:          &mainBranch,
+			ExpectedBases:       expectedBases,
+		},
 	}
 
 	testCases = append(testCases, tc)
@@ -68,9 +135,30 @@ func getTestCasesGitMerge(t *testing.T) []tcGitMerge { // nolint:dupl,funlen
 	// 4.
 	tc = tcGitMerge{
 		name:      "config has values, parameter has values",
-		actual:    &config.Git{BaseURL: &baseURL, ReleaseBranchPrefix: &branchPrefix},
-		mergeWith: &config.Git{BaseURL: &newBaseURL, ReleaseBranchPrefix: &newBranchPrefix},
-		expected:  &config.Git{BaseURL: &newBaseURL, ReleaseBranchPrefix: &newBranchPrefix},
+		actual: &config.Git{
+			BaseURL:             &baseURL,
+			ReleaseBranchPrefix: &branchPrefix,
+			WorkPath:            &workPath,
+			TagPattern:          &tagPattern,
+			MainBranch:          &mainBranch,
+			ExpectedBases:       expectedBases,
+		},
+		mergeWith: &config.Git{
+			BaseURL:             &newBaseURL,
+			ReleaseBranchPrefix: &newBranchPrefix,
+			WorkPath:            &newWorkPath,
+			TagPattern:          &newTagPattern,
+			MainBranch:          &newMainBranch,
+			ExpectedBases:       newExpectedBases,
+		},
+		expected: &config.Git{
+			BaseURL:             &newBaseURL,
+			ReleaseBranchPrefix: &newBranchPrefix,
+			WorkPath:            &newWorkPath,
+			TagPattern:          &newTagPattern,
+			MainBranch:          &newMainBranch,
+			ExpectedBases:       newExpectedBases,
+		},
 	}
 
 	testCases = append(testCases, tc)
@@ -119,4 +207,31 @@ func testGit(t *testing.T, expected, got *config.Git) {
 		t.Errorf("failed to set git release branch prefix: expected '%s' but got '%s'",
 			expected.GetReleaseBranchPrefix(), got.GetReleaseBranchPrefix())
 	}
+
+	if expected.GetWorkPath() != got.GetWorkPath() {
+		t.Errorf("failed to set git work path: expected '%s' but got '%s'",
+			expected.GetWorkPath(), got.GetWorkPath())
+	}
+
+	if expected.GetTagPattern() != got.GetTagPattern() {
+		t.Errorf("failed to set git tag pattern: expected '%s' but got '%s'",
+			expected.GetTagPattern(), got.GetTagPattern())
+	}
+
+	if expected.GetMainBranch() != got.GetMainBranch() {
+		t.Errorf("failed to set git main branch: expected '%s' but got '%s'",
+			expected.GetMainBranch(), got.GetMainBranch())
+	}
+
+	if len(expected.GetExpectedBases()) != len(got.GetExpectedBases()) {
+		t.Errorf("failed to set git expected bases: expected '%v' but got '%v'",
+			expected.GetExpectedBases(), got.GetExpectedBases())
+	}
+
+	for ticketType, base := range expected.GetExpectedBases() {
+		if got.GetExpectedBase(ticketType) != base {
+			t.Errorf("failed to set git expected base of '%s': expected '%s' but got '%s'",
+				ticketType, base, got.GetExpectedBase(ticketType))
+		}
+	}
 }
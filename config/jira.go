@@ -1,10 +1,120 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultJiraMaxRetries defines how often a request rate limited by JIRA is retried
+	DefaultJiraMaxRetries = 3
+
+	// AuthBasic authenticates with username and password, it is only supported by JIRA server
+	AuthBasic = "basic"
+
+	// AuthToken authenticates with username (the email for JIRA Cloud) and API token
+	AuthToken = "token"
+
+	// AuthBearer authenticates with a personal access token sent as bearer token
+	AuthBearer = "bearer"
+
+	// CategoryTodo is the category of tickets nobody started to work on
+	CategoryTodo = "todo"
+
+	// CategoryInProgress is the category of tickets somebody is working on
+	CategoryInProgress = "in_progress"
+
+	// CategoryReview is the category of tickets in review or QA
+	CategoryReview = "review"
+
+	// CategoryDone is the category of finished tickets
+	CategoryDone = "done"
+)
+
+// ErrUnknownCategory is the error if a status of JIRA is mapped to a value which is not a category of tickets
+var ErrUnknownCategory = errors.New("unknown category")
+
+// IsCategory returns true if the given value is one of the categories tickets are mapped to
+func IsCategory(category string) bool {
+	switch category {
+	case CategoryTodo, CategoryInProgress, CategoryReview, CategoryDone:
+		return true
+	default:
+		return false
+	}
+}
+
 // Jira provides the configuration for Jira
 type Jira struct {
+	// Name identifies the instance if several JIRA instances are configured
+	Name *string `json:"name"`
+
+	// ProjectKeys are the keys of the projects tracked in this instance, e.g. PROJ for ticket PROJ-1. An instance
+	// without project keys is responsible for all projects not assigned to another instance.
+	ProjectKeys []string `json:"project_keys"`
+
 	BaseURL  *string `json:"base_url"`
 	Username *string `json:"username"`
 	Password *string `json:"password"`
+
+	// AuthType is one of AuthBasic, AuthToken or AuthBearer, if empty it is derived from the credentials configured
+	AuthType *string `json:"auth_type"`
+
+	// TokenEnv is the name of the environment variable containing the API token or personal access token
+	TokenEnv *string `json:"token_env"`
+
+	// TokenFile is the path to the file containing the API token or personal access token, it is used if the
+	// environment variable is not set
+	TokenFile *string `json:"token_file"`
+
+	// CacheTTL defines how long issues fetched are cached, e.g. "5m", empty disables the cache
+	CacheTTL *string `json:"cache_ttl"`
+
+	// MaxRetries defines how often a request rate limited by JIRA is retried with exponential backoff, 0 disables
+	// retries
+	MaxRetries *int `json:"max_retries"`
+
+	// SyncInterval defines how often the tickets are synchronised, e.g. "15m", empty disables the schedule
+	SyncInterval *string `json:"sync_interval"`
+
+	// WebhookSecret is the shared secret JIRA has to send with webhooks, empty disables the webhook
+	WebhookSecret *string `json:"webhook_secret"`
+
+	// WebhookSecretInQuery accepts the shared secret as query parameter too, for JIRA instances not able to send
+	// headers with webhooks. The url including the secret ends up in the logs of proxies and servers, so use it only
+	// if the header can't be sent.
+	WebhookSecretInQuery *bool `json:"webhook_secret_in_query"`
+
+	// StatusCategories maps the statuses of the JIRA workflow to the categories CategoryTodo, CategoryInProgress,
+	// CategoryReview and CategoryDone. Statuses not configured are mapped by the status category of JIRA.
+	StatusCategories map[string]string `json:"status_categories"`
+
+	// WriteBack maps the names of repositories to the changes written back to JIRA for their tickets, repositories
+	// not configured don't write anything back
+	WriteBack map[string]*WriteBack `json:"write_back"`
+
+	// MissingBranchQuery is the JQL of the tickets which are expected to have a branch, e.g. status = "In Progress"
+	MissingBranchQuery *string `json:"missing_branch_query"`
+}
+
+// GetName returns the name of the instance
+func (j *Jira) GetName() string {
+	if j == nil || j.Name == nil {
+		return ""
+	}
+
+	return *j.Name
+}
+
+// GetProjectKeys returns the keys of the projects tracked in this instance
+func (j *Jira) GetProjectKeys() []string {
+	if j == nil {
+		return nil
+	}
+
+	return j.ProjectKeys
 }
 
 // GetBaseURL returns the base url
@@ -25,7 +135,7 @@ func (j *Jira) GetUsername() string {
 	return *j.Username
 }
 
-// GetPassword returns the username
+// GetPassword returns the password
 func (j *Jira) GetPassword() string {
 	if j == nil || j.Password == nil {
 		return ""
@@ -34,12 +144,171 @@ func (j *Jira) GetPassword() string {
 	return *j.Password
 }
 
+// GetAuthType returns the authentication scheme, if not configured it is derived from the credentials:
+// AuthBearer for a token without username, AuthToken for a token with username and AuthBasic for a username only
+func (j *Jira) GetAuthType() string {
+	if j != nil && j.AuthType != nil && *j.AuthType != "" {
+		return *j.AuthType
+	}
+
+	switch {
+	case j.GetTokenEnv() != "" || j.GetTokenFile() != "":
+		if j.GetUsername() == "" {
+			return AuthBearer
+		}
+
+		return AuthToken
+	case j.GetUsername() != "":
+		return AuthBasic
+	default:
+		return ""
+	}
+}
+
+// GetTokenEnv returns the name of the environment variable containing the token
+func (j *Jira) GetTokenEnv() string {
+	if j == nil || j.TokenEnv == nil {
+		return ""
+	}
+
+	return *j.TokenEnv
+}
+
+// GetTokenFile returns the path to the file containing the token
+func (j *Jira) GetTokenFile() string {
+	if j == nil || j.TokenFile == nil {
+		return ""
+	}
+
+	return *j.TokenFile
+}
+
+// GetCacheTTL returns how long issues are cached
+func (j *Jira) GetCacheTTL() string {
+	if j == nil || j.CacheTTL == nil {
+		return ""
+	}
+
+	return *j.CacheTTL
+}
+
+// GetMaxRetries returns how often a rate limited request is retried
+func (j *Jira) GetMaxRetries() int {
+	if j == nil || j.MaxRetries == nil {
+		return DefaultJiraMaxRetries
+	}
+
+	return *j.MaxRetries
+}
+
+// GetSyncInterval returns the interval the tickets are synchronised in
+func (j *Jira) GetSyncInterval() string {
+	if j == nil || j.SyncInterval == nil {
+		return ""
+	}
+
+	return *j.SyncInterval
+}
+
+// GetWebhookSecret returns the shared secret of the webhook
+func (j *Jira) GetWebhookSecret() string {
+	if j == nil || j.WebhookSecret == nil {
+		return ""
+	}
+
+	return *j.WebhookSecret
+}
+
+// GetWebhookSecretInQuery returns true if the shared secret of the webhook is accepted as query parameter
+func (j *Jira) GetWebhookSecretInQuery() bool {
+	if j == nil || j.WebhookSecretInQuery == nil {
+		return false
+	}
+
+	return *j.WebhookSecretInQuery
+}
+
+// GetStatusCategories returns the categories mapped by the statuses of the JIRA workflow
+func (j *Jira) GetStatusCategories() map[string]string {
+	if j == nil {
+		return nil
+	}
+
+	return j.StatusCategories
+}
+
+// ValidateStatusCategories returns an error if a status is mapped to a value which is not a category of tickets
+func (j *Jira) ValidateStatusCategories() error {
+	statuses := make([]string, 0, len(j.GetStatusCategories()))
+	for status := range j.GetStatusCategories() {
+		statuses = append(statuses, status)
+	}
+
+	sort.Strings(statuses)
+
+	for _, status := range statuses {
+		if category := j.StatusCategories[status]; !IsCategory(category) {
+			return fmt.Errorf("%w '%s' for status '%s' of jira", ErrUnknownCategory, category, status)
+		}
+	}
+
+	return nil
+}
+
+// GetStatusCategory returns the category the given status of JIRA is mapped to, the status is compared case
+// insensitive. It is empty if the status is not configured.
+func (j *Jira) GetStatusCategory(status string) string {
+	if category, ok := j.GetStatusCategories()[status]; ok {
+		return category
+	}
+
+	for s, category := range j.GetStatusCategories() {
+		if strings.EqualFold(s, status) {
+			return category
+		}
+	}
+
+	return ""
+}
+
+// GetWriteBacks returns the changes written back to JIRA mapped by the names of repositories
+func (j *Jira) GetWriteBacks() map[string]*WriteBack {
+	if j == nil {
+		return nil
+	}
+
+	return j.WriteBack
+}
+
+// GetWriteBack returns the changes written back to JIRA for the tickets of the given repository, it is nil if the
+// repository doesn't write back anything
+func (j *Jira) GetWriteBack(repository string) *WriteBack {
+	return j.GetWriteBacks()[repository]
+}
+
+// GetMissingBranchQuery returns the JQL of the tickets which are expected to have a branch
+func (j *Jira) GetMissingBranchQuery() string {
+	if j == nil || j.MissingBranchQuery == nil {
+		return ""
+	}
+
+	return *j.MissingBranchQuery
+}
+
 // Merge overwrites the values given by the config from parameter if they differ from default values
 func (j *Jira) Merge(cfg *Jira) {
 	if cfg == nil || j == nil {
 		return
 	}
 
+	if cfg.GetName() != "" {
+		j.Name = cfg.Name
+	}
+
+	if len(cfg.GetProjectKeys()) > 0 {
+		j.ProjectKeys = cfg.ProjectKeys
+	}
+
 	if cfg.GetBaseURL() != "" {
 		j.BaseURL = cfg.BaseURL
 	}
@@ -51,4 +320,48 @@ func (j *Jira) Merge(cfg *Jira) {
 	if cfg.GetPassword() != "" {
 		j.Password = cfg.Password
 	}
+
+	if cfg.AuthType != nil && *cfg.AuthType != "" {
+		j.AuthType = cfg.AuthType
+	}
+
+	if cfg.GetTokenEnv() != "" {
+		j.TokenEnv = cfg.TokenEnv
+	}
+
+	if cfg.GetTokenFile() != "" {
+		j.TokenFile = cfg.TokenFile
+	}
+
+	if cfg.GetCacheTTL() != "" {
+		j.CacheTTL = cfg.CacheTTL
+	}
+
+	if cfg.GetMaxRetries() != DefaultJiraMaxRetries {
+		j.MaxRetries = cfg.MaxRetries
+	}
+
+	if cfg.GetSyncInterval() != "" {
+		j.SyncInterval = cfg.SyncInterval
+	}
+
+	if cfg.GetWebhookSecret() != "" {
+		j.WebhookSecret = cfg.WebhookSecret
+	}
+
+	if cfg.GetWebhookSecretInQuery() {
+		j.WebhookSecretInQuery = cfg.WebhookSecretInQuery
+	}
+
+	if len(cfg.GetStatusCategories()) > 0 {
+		j.StatusCategories = cfg.StatusCategories
+	}
+
+	if len(cfg.GetWriteBacks()) > 0 {
+		j.WriteBack = cfg.WriteBack
+	}
+
+	if cfg.GetMissingBranchQuery() != "" {
+		j.MissingBranchQuery = cfg.MissingBranchQuery
+	}
 }
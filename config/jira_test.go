@@ -1,11 +1,27 @@
 package config_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/rebel-l/branma_be/config"
 )
 
+func TestJira_GetName(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetName() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetProjectKeys(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetProjectKeys() != nil {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
 func TestJira_GetBaseURL(t *testing.T) {
 	var jira *config.Jira
 	if jira.GetBaseURL() != "" {
@@ -27,6 +43,180 @@ func TestJira_GetPassword(t *testing.T) {
 	}
 }
 
+func TestJira_GetAuthType(t *testing.T) {
+	username := "branma"
+	tokenEnv := "JIRA_TOKEN"
+	tokenFile := "/run/secrets/jira"
+	authType := config.AuthBasic
+
+	testCases := []struct {
+		name     string
+		jira     *config.Jira
+		expected string
+	}{
+		{
+			name: "nil struct",
+		},
+		{
+			name: "no credentials",
+			jira: &config.Jira{},
+		},
+		{
+			name:     "username only",
+			jira:     &config.Jira{Username: &username},
+			expected: config.AuthBasic,
+		},
+		{
+			name:     "username and token",
+			jira:     &config.Jira{Username: &username, TokenEnv: &tokenEnv},
+			expected: config.AuthToken,
+		},
+		{
+			name:     "token only",
+			jira:     &config.Jira{TokenFile: &tokenFile},
+			expected: config.AuthBearer,
+		},
+		{
+			name:     "configured",
+			jira:     &config.Jira{AuthType: &authType, TokenFile: &tokenFile},
+			expected: config.AuthBasic,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := testCase.jira.GetAuthType(); actual != testCase.expected {
+				t.Errorf("expected auth type '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestJira_GetTokenEnv(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetTokenEnv() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetTokenFile(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetTokenFile() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetCacheTTL(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetCacheTTL() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetMaxRetries(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetMaxRetries() != config.DefaultJiraMaxRetries {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetSyncInterval(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetSyncInterval() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetWebhookSecret(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetWebhookSecret() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetWebhookSecretInQuery(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetWebhookSecretInQuery() {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetStatusCategory(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetStatusCategory("Done") != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+
+	jira = &config.Jira{StatusCategories: map[string]string{"Code Review": config.CategoryReview}}
+
+	testCases := []struct {
+		status   string
+		expected string
+	}{
+		{status: "Code Review", expected: config.CategoryReview},
+		{status: "code review", expected: config.CategoryReview},
+		{status: "QA"},
+	}
+
+	for _, testCase := range testCases {
+		if actual := jira.GetStatusCategory(testCase.status); actual != testCase.expected {
+			t.Errorf("expected category '%s' for status '%s' but got '%s'", testCase.expected, testCase.status, actual)
+		}
+	}
+}
+
+func TestJira_ValidateStatusCategories(t *testing.T) {
+	var jira *config.Jira
+	if err := jira.ValidateStatusCategories(); err != nil {
+		t.Errorf("expected no error for nil struct but got: %v", err)
+	}
+
+	jira = &config.Jira{StatusCategories: map[string]string{
+		"Code Review": config.CategoryReview,
+		"QA":          config.CategoryReview,
+	}}
+
+	if err := jira.ValidateStatusCategories(); err != nil {
+		t.Errorf("expected no error but got: %v", err)
+	}
+
+	jira.StatusCategories["QA"] = "reveiw"
+
+	err := jira.ValidateStatusCategories()
+	if !errors.Is(err, config.ErrUnknownCategory) || !strings.Contains(err.Error(), "'reveiw' for status 'QA'") {
+		t.Errorf("expected error for unknown category of status QA but got: %v", err)
+	}
+}
+
+func TestIsCategory(t *testing.T) {
+	for _, category := range []string{
+		config.CategoryTodo, config.CategoryInProgress, config.CategoryReview, config.CategoryDone,
+	} {
+		if !config.IsCategory(category) {
+			t.Errorf("expected '%s' to be a category", category)
+		}
+	}
+
+	if config.IsCategory("in progress") {
+		t.Error("expected 'in progress' not to be a category")
+	}
+}
+
+func TestJira_GetWriteBack(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetWriteBack("repo") != nil {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestJira_GetMissingBranchQuery(t *testing.T) {
+	var jira *config.Jira
+	if jira.GetMissingBranchQuery() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
 type tcJiraMerge struct {
 	name      string
 	actual    *config.Jira
@@ -39,13 +229,36 @@ func getTestCasesJiraMerge(t *testing.T) []tcJiraMerge { // nolint:funlen
 
 	var testCases []tcJiraMerge
 
+	name := "cloud"
 	baseURL := "my.url"
 	username := "myUsername"
 	password := "myPassword"
+	authType := config.AuthToken
+	tokenEnv := "JIRA_TOKEN"
+	tokenFile := "/run/secrets/jira"
+	syncInterval := "15m"
+	cacheTTL := "5m"
+	maxRetries := 5
+	webhookSecret := "mySecret"
+	webhookSecretInQuery := true
+	missingBranchQuery := `status = "In Progress"`
+	statusCategories := map[string]string{"QA": config.CategoryReview}
+	writeBack := map[string]*config.WriteBack{"repo": {MergedTransition: &syncInterval}}
 
+	newName := "legacy"
 	newBaseURL := "my.url"
 	newUsername := "myNewUsername"
 	newPassword := "myNewPassword"
+	newAuthType := config.AuthBearer
+	newTokenEnv := "JIRA_PAT"
+	newTokenFile := "/run/secrets/jira_pat"
+	newSyncInterval := "1h"
+	newCacheTTL := "10m"
+	newMaxRetries := 0
+	newWebhookSecret := "myNewSecret"
+	newMissingBranchQuery := `project = JIRA AND status = "In Progress"`
+	newStatusCategories := map[string]string{"Code Review": config.CategoryReview}
+	newWriteBack := map[string]*config.WriteBack{"other": {MergedTransition: &newSyncInterval}}
 
 	// 1.
 	tc := tcJiraMerge{
@@ -66,20 +279,105 @@ func getTestCasesJiraMerge(t *testing.T) []tcJiraMerge { // nolint:funlen
 
 	// 3.
 	tc = tcJiraMerge{
-		name:      "config has default values, parameter has values",
-		actual:    &config.Jira{},
-		mergeWith: &config.Jira{BaseURL: &baseURL, Username: &username, Password: &password},
-		expected:  &config.Jira{BaseURL: &baseURL, Username: &username, Password: &password},
+		name:   "config has default values, parameter has values",
+		actual: &config.Jira{},
+		mergeWith: &config.Jira{
+			Name:                 &name,
+			ProjectKeys:          []string{"PROJ"},
+			BaseURL:              &baseURL,
+			Username:             &username,
+			Password:             &password,
+			AuthType:             &authType,
+			TokenEnv:             &tokenEnv,
+			TokenFile:            &tokenFile,
+			CacheTTL:             &cacheTTL,
+			MaxRetries:           &maxRetries,
+			SyncInterval:         &syncInterval,
+			WebhookSecret:        &webhookSecret,
+			WebhookSecretInQuery: &webhookSecretInQuery,
+			MissingBranchQuery:   &missingBranchQuery,
+			StatusCategories:     statusCategories,
+			WriteBack:            writeBack,
+		},
+		expected: &config.Jira{
+			Name:                 &name,
+			ProjectKeys:          []string{"PROJ"},
+			BaseURL:              &baseURL,
+			Username:             &username,
+			Password:             &password,
+			AuthType:             &authType,
+			TokenEnv:             &tokenEnv,
+			TokenFile:            &tokenFile,
+			CacheTTL:             &cacheTTL,
+			MaxRetries:           &maxRetries,
+			SyncInterval:         &syncInterval,
+			WebhookSecret:        &webhookSecret,
+			WebhookSecretInQuery: &webhookSecretInQuery,
+			MissingBranchQuery:   &missingBranchQuery,
+			StatusCategories:     statusCategories,
+			WriteBack:            writeBack,
+		},
 	}
 
 	testCases = append(testCases, tc)
 
 	// 4.
 	tc = tcJiraMerge{
-		name:      "config has values, parameter has values",
-		actual:    &config.Jira{BaseURL: &baseURL, Username: &username, Password: &password},
-		mergeWith: &config.Jira{BaseURL: &newBaseURL, Username: &newUsername, Password: &newPassword},
-		expected:  &config.Jira{BaseURL: &newBaseURL, Username: &newUsername, Password: &newPassword},
+		name: "config has values, parameter has values",
+		actual: &config.Jira{
+			Name:                 &name,
+			ProjectKeys:          []string{"PROJ"},
+			BaseURL:              &baseURL,
+			Username:             &username,
+			Password:             &password,
+			AuthType:             &authType,
+			TokenEnv:             &tokenEnv,
+			TokenFile:            &tokenFile,
+			CacheTTL:             &cacheTTL,
+			MaxRetries:           &maxRetries,
+			SyncInterval:         &syncInterval,
+			WebhookSecret:        &webhookSecret,
+			WebhookSecretInQuery: &webhookSecretInQuery,
+			MissingBranchQuery:   &missingBranchQuery,
+			StatusCategories:     statusCategories,
+			WriteBack:            writeBack,
+		},
+		mergeWith: &config.Jira{
+			Name:                 &newName,
+			ProjectKeys:          []string{"OLD"},
+			BaseURL:              &newBaseURL,
+			Username:             &newUsername,
+			Password:             &newPassword,
+			AuthType:             &newAuthType,
+			TokenEnv:             &newTokenEnv,
+			TokenFile:            &newTokenFile,
+			CacheTTL:             &newCacheTTL,
+			MaxRetries:           &newMaxRetries,
+			SyncInterval:         &newSyncInterval,
+			WebhookSecret:        &newWebhookSecret,
+			WebhookSecretInQuery: &webhookSecretInQuery,
+			MissingBranchQuery:   &newMissingBranchQuery,
+			StatusCategories:     newStatusCategories,
+			WriteBack:            newWriteBack,
+		},
+		expected: &config.Jira{
+			Name:                 &newName,
+			ProjectKeys:          []string{"OLD"},
+			BaseURL:              &newBaseURL,
+			Username:             &newUsername,
+			Password:             &newPassword,
+			AuthType:             &newAuthType,
+			TokenEnv:             &newTokenEnv,
+			TokenFile:            &newTokenFile,
+			CacheTTL:             &newCacheTTL,
+			MaxRetries:           &newMaxRetries,
+			SyncInterval:         &newSyncInterval,
+			WebhookSecret:        &newWebhookSecret,
+			WebhookSecretInQuery: &webhookSecretInQuery,
+			MissingBranchQuery:   &newMissingBranchQuery,
+			StatusCategories:     newStatusCategories,
+			WriteBack:            newWriteBack,
+		},
 	}
 
 	testCases = append(testCases, tc)
@@ -119,6 +417,15 @@ func TestJira_Merge(t *testing.T) {
 func testJira(t *testing.T, expected, got *config.Jira) {
 	t.Helper()
 
+	if expected.GetName() != got.GetName() {
+		t.Errorf("failed to set JIRA name: expected '%s' but got '%s'", expected.GetName(), got.GetName())
+	}
+
+	if strings.Join(expected.GetProjectKeys(), ",") != strings.Join(got.GetProjectKeys(), ",") {
+		t.Errorf("failed to set JIRA project keys: expected %v but got %v",
+			expected.GetProjectKeys(), got.GetProjectKeys())
+	}
+
 	if expected.GetBaseURL() != got.GetBaseURL() {
 		t.Errorf("failed to set JIRA base url: expected '%s' but got '%s'",
 			expected.GetBaseURL(), got.GetBaseURL())
@@ -133,4 +440,69 @@ func testJira(t *testing.T, expected, got *config.Jira) {
 		t.Errorf("failed to set JIRA password: expected '%s' but got '%s'",
 			expected.GetPassword(), got.GetPassword())
 	}
+
+	if expected.GetAuthType() != got.GetAuthType() {
+		t.Errorf("failed to set JIRA auth type: expected '%s' but got '%s'",
+			expected.GetAuthType(), got.GetAuthType())
+	}
+
+	if expected.GetTokenEnv() != got.GetTokenEnv() {
+		t.Errorf("failed to set JIRA token env: expected '%s' but got '%s'",
+			expected.GetTokenEnv(), got.GetTokenEnv())
+	}
+
+	if expected.GetTokenFile() != got.GetTokenFile() {
+		t.Errorf("failed to set JIRA token file: expected '%s' but got '%s'",
+			expected.GetTokenFile(), got.GetTokenFile())
+	}
+
+	if expected.GetCacheTTL() != got.GetCacheTTL() {
+		t.Errorf("failed to set JIRA cache ttl: expected '%s' but got '%s'", expected.GetCacheTTL(), got.GetCacheTTL())
+	}
+
+	if expected.GetMaxRetries() != got.GetMaxRetries() {
+		t.Errorf("failed to set JIRA max retries: expected %d but got %d", expected.GetMaxRetries(), got.GetMaxRetries())
+	}
+
+	if expected.GetSyncInterval() != got.GetSyncInterval() {
+		t.Errorf("failed to set JIRA sync interval: expected '%s' but got '%s'",
+			expected.GetSyncInterval(), got.GetSyncInterval())
+	}
+
+	if expected.GetWebhookSecret() != got.GetWebhookSecret() {
+		t.Errorf("failed to set JIRA webhook secret: expected '%s' but got '%s'",
+			expected.GetWebhookSecret(), got.GetWebhookSecret())
+	}
+
+	if expected.GetWebhookSecretInQuery() != got.GetWebhookSecretInQuery() {
+		t.Errorf("failed to set JIRA webhook secret in query: expected %t but got %t",
+			expected.GetWebhookSecretInQuery(), got.GetWebhookSecretInQuery())
+	}
+
+	if len(expected.GetStatusCategories()) != len(got.GetStatusCategories()) {
+		t.Errorf("failed to set JIRA status categories: expected %v but got %v",
+			expected.GetStatusCategories(), got.GetStatusCategories())
+	}
+
+	for status, category := range expected.GetStatusCategories() {
+		if got.GetStatusCategory(status) != category {
+			t.Errorf("failed to set JIRA status category of '%s': expected '%s' but got '%s'",
+				status, category, got.GetStatusCategory(status))
+		}
+	}
+
+	if len(expected.GetWriteBacks()) != len(got.GetWriteBacks()) {
+		t.Errorf("failed to set JIRA write back: expected %v but got %v", expected.GetWriteBacks(), got.GetWriteBacks())
+	}
+
+	for repository, writeBack := range expected.GetWriteBacks() {
+		if got.GetWriteBack(repository).GetMergedTransition() != writeBack.GetMergedTransition() {
+			t.Errorf("failed to set JIRA write back of repository '%s'", repository)
+		}
+	}
+
+	if expected.GetMissingBranchQuery() != got.GetMissingBranchQuery() {
+		t.Errorf("failed to set JIRA missing branch query: expected '%s' but got '%s'",
+			expected.GetMissingBranchQuery(), got.GetMissingBranchQuery())
+	}
 }
@@ -0,0 +1,93 @@
+package config
+
+const (
+	// ProviderJira fetches the tickets from the JIRA instances configured
+	ProviderJira = "jira"
+
+	// ProviderGitHub fetches the tickets from the issues of a repository by a GitHub compatible REST API
+	ProviderGitHub = "github"
+
+	// ProviderFile reads the tickets from a static YAML or JSON file
+	ProviderFile = "file"
+
+	// DefaultGitHubURL defines the url of the GitHub REST API
+	DefaultGitHubURL = "https://api.github.com"
+
+	// DefaultGitHubKeyPrefix defines the prefix of ticket keys mapped to issue numbers, e.g. GH-12 for issue 12
+	DefaultGitHubKeyPrefix = "GH"
+)
+
+// TicketProvider configures where the tickets of a repository are tracked
+type TicketProvider struct {
+	// Type is one of ProviderJira, ProviderGitHub or ProviderFile, default is ProviderJira
+	Type *string `json:"type"`
+
+	// BaseURL is the url of the REST API of GitHub, e.g. of GitHub Enterprise
+	BaseURL *string `json:"base_url"`
+
+	// Repository is the GitHub repository tracking the issues in the form owner/name
+	Repository *string `json:"repository"`
+
+	// TokenEnv is the name of the environment variable containing the token for the REST API of GitHub
+	TokenEnv *string `json:"token_env"`
+
+	// KeyPrefix is the prefix of ticket keys mapped to issue numbers of GitHub
+	KeyPrefix *string `json:"key_prefix"`
+
+	// Path is the path to the YAML or JSON file containing the tickets
+	Path *string `json:"path"`
+}
+
+// GetType returns the type of the provider
+func (t *TicketProvider) GetType() string {
+	if t == nil || t.Type == nil || *t.Type == "" {
+		return ProviderJira
+	}
+
+	return *t.Type
+}
+
+// GetBaseURL returns the url of the REST API of GitHub
+func (t *TicketProvider) GetBaseURL() string {
+	if t == nil || t.BaseURL == nil {
+		return DefaultGitHubURL
+	}
+
+	return *t.BaseURL
+}
+
+// GetRepository returns the GitHub repository tracking the issues
+func (t *TicketProvider) GetRepository() string {
+	if t == nil || t.Repository == nil {
+		return ""
+	}
+
+	return *t.Repository
+}
+
+// GetTokenEnv returns the name of the environment variable containing the token for GitHub
+func (t *TicketProvider) GetTokenEnv() string {
+	if t == nil || t.TokenEnv == nil {
+		return ""
+	}
+
+	return *t.TokenEnv
+}
+
+// GetKeyPrefix returns the prefix of ticket keys mapped to issue numbers of GitHub
+func (t *TicketProvider) GetKeyPrefix() string {
+	if t == nil || t.KeyPrefix == nil {
+		return DefaultGitHubKeyPrefix
+	}
+
+	return *t.KeyPrefix
+}
+
+// GetPath returns the path to the file containing the tickets
+func (t *TicketProvider) GetPath() string {
+	if t == nil || t.Path == nil {
+		return ""
+	}
+
+	return *t.Path
+}
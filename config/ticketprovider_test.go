@@ -0,0 +1,56 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+)
+
+func TestTicketProvider_GetType(t *testing.T) {
+	var provider *config.TicketProvider
+	if provider.GetType() != config.ProviderJira {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+
+	empty := ""
+	provider = &config.TicketProvider{Type: &empty}
+
+	if provider.GetType() != config.ProviderJira {
+		t.Errorf("expected empty type to default to '%s' but got '%s'", config.ProviderJira, provider.GetType())
+	}
+}
+
+func TestTicketProvider_GetBaseURL(t *testing.T) {
+	var provider *config.TicketProvider
+	if provider.GetBaseURL() != config.DefaultGitHubURL {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestTicketProvider_GetRepository(t *testing.T) {
+	var provider *config.TicketProvider
+	if provider.GetRepository() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestTicketProvider_GetTokenEnv(t *testing.T) {
+	var provider *config.TicketProvider
+	if provider.GetTokenEnv() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestTicketProvider_GetKeyPrefix(t *testing.T) {
+	var provider *config.TicketProvider
+	if provider.GetKeyPrefix() != config.DefaultGitHubKeyPrefix {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestTicketProvider_GetPath(t *testing.T) {
+	var provider *config.TicketProvider
+	if provider.GetPath() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+// WriteBack configures the changes written back to JIRA for the tickets of a repository
+type WriteBack struct {
+	// Comment enables comments on tickets if their branch was merged into a release branch or their version released
+	Comment *bool `json:"comment"`
+
+	// FixVersion enables adding the version to the fix versions of tickets
+	FixVersion *bool `json:"fix_version"`
+
+	// MergedTransition is the transition or status tickets are moved to if their branch was merged into a release
+	// branch, empty disables the transition
+	MergedTransition *string `json:"merged_transition"`
+
+	// ReleasedTransition is the transition or status tickets are moved to if their version was released, empty
+	// disables the transition
+	ReleasedTransition *string `json:"released_transition"`
+}
+
+// GetComment returns true if comments are enabled
+func (w *WriteBack) GetComment() bool {
+	if w == nil || w.Comment == nil {
+		return false
+	}
+
+	return *w.Comment
+}
+
+// GetFixVersion returns true if adding fix versions is enabled
+func (w *WriteBack) GetFixVersion() bool {
+	if w == nil || w.FixVersion == nil {
+		return false
+	}
+
+	return *w.FixVersion
+}
+
+// GetMergedTransition returns the transition for tickets merged into a release branch
+func (w *WriteBack) GetMergedTransition() string {
+	if w == nil || w.MergedTransition == nil {
+		return ""
+	}
+
+	return *w.MergedTransition
+}
+
+// GetReleasedTransition returns the transition for tickets of a released version
+func (w *WriteBack) GetReleasedTransition() string {
+	if w == nil || w.ReleasedTransition == nil {
+		return ""
+	}
+
+	return *w.ReleasedTransition
+}
+
+// IsEnabled returns true if anything is written back
+func (w *WriteBack) IsEnabled() bool {
+	return w.GetComment() || w.GetFixVersion() || w.GetMergedTransition() != "" || w.GetReleasedTransition() != ""
+}
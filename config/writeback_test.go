@@ -0,0 +1,60 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+)
+
+func TestWriteBack_GetComment(t *testing.T) {
+	var writeBack *config.WriteBack
+	if writeBack.GetComment() {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestWriteBack_GetFixVersion(t *testing.T) {
+	var writeBack *config.WriteBack
+	if writeBack.GetFixVersion() {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestWriteBack_GetMergedTransition(t *testing.T) {
+	var writeBack *config.WriteBack
+	if writeBack.GetMergedTransition() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestWriteBack_GetReleasedTransition(t *testing.T) {
+	var writeBack *config.WriteBack
+	if writeBack.GetReleasedTransition() != "" {
+		t.Errorf("failed to retrieve default value from nil struct")
+	}
+}
+
+func TestWriteBack_IsEnabled(t *testing.T) {
+	enabled := true
+	disabled := false
+	transition := "Done"
+
+	testCases := []struct {
+		name      string
+		writeBack *config.WriteBack
+		expected  bool
+	}{
+		{name: "nil struct"},
+		{name: "disabled", writeBack: &config.WriteBack{Comment: &disabled, FixVersion: &disabled}},
+		{name: "comment", writeBack: &config.WriteBack{Comment: &enabled}, expected: true},
+		{name: "fix version", writeBack: &config.WriteBack{FixVersion: &enabled}, expected: true},
+		{name: "merged transition", writeBack: &config.WriteBack{MergedTransition: &transition}, expected: true},
+		{name: "released transition", writeBack: &config.WriteBack{ReleasedTransition: &transition}, expected: true},
+	}
+
+	for _, testCase := range testCases {
+		if actual := testCase.writeBack.IsEnabled(); actual != testCase.expected {
+			t.Errorf("%s: expected %t but got %t", testCase.name, testCase.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package branch
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+)
+
+// delete removes a branch identified by ID
+func (h *Handler) delete(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	idRaw, ok := mux.Vars(request)["id"]
+	if !ok {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errNoID)
+
+		return
+	}
+
+	id, err := strconv.Atoi(idRaw)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "converting id to integer failed")
+
+		return
+	}
+
+	// 1. delete model if the precondition is met
+	err = h.mapper.DeleteIfMatch(request.Context(), id, request.Header.Get(etag.HeaderKeyIfMatch))
+
+	switch {
+	case errors.Is(err, branchmapper.ErrPreconditionFailed):
+		problem.Writef(
+			writer, request, response.Log, http.StatusPreconditionFailed,
+			"precondition failed for branch with id %d: %v", id, err,
+		)
+
+		return
+	case errors.Is(err, branchmapper.ErrNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "branch with id %d not found", id)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to delete branch for id: %d", id,
+		)
+
+		return
+	}
+
+	// 2. send response
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
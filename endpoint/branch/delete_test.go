@@ -0,0 +1,67 @@
+package branch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestHandler_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointDelete")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+	if _, err := mapper.Save(context.Background(), &branchmodel.Branch{Name: "master", RepositoryID: 1}); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	assignment := &versionstore.BranchVersion{BranchID: 1, VersionID: version.ID}
+	if err := assignment.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	// 2. test
+	req, err := http.NewRequest(http.MethodDelete, "/branch/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, actual := serve(t, svc, req, nil)
+	if code != http.StatusOK {
+		t.Errorf("expected code %d but got %d", http.StatusOK, code)
+	}
+
+	testPayload(t, &Payload{}, actual)
+
+	if _, err = mapper.Load(context.Background(), 1); !errors.Is(err, branchmapper.ErrNotFound) {
+		t.Errorf("expected branch to be deleted but got error '%v'", err)
+	}
+
+	req, err = http.NewRequest(http.MethodDelete, "/branch/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serve(t, svc, req, problem.New(http.StatusNotFound, "branch with id 1 not found"))
+}
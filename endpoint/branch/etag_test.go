@@ -0,0 +1,116 @@
+package branch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/etag"
+)
+
+func TestHandler_ETag(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointETag")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+
+	model, err := mapper.Save(context.Background(), &branchmodel.Branch{Name: "feature/x", RepositoryID: 1})
+	if err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	tag := branchmapper.ETag(model)
+
+	// 2. test
+	testCases := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		ifMatch        string
+		expectedCode   int
+		expectedBranch bool
+	}{
+		{
+			name:           "get",
+			method:         http.MethodGet,
+			path:           "/branch/1",
+			expectedCode:   http.StatusOK,
+			expectedBranch: true,
+		},
+		{
+			name:         "put outdated",
+			method:       http.MethodPut,
+			path:         "/branch",
+			body:         `{"id": 1, "name": "feature/y", "repository_id": 1}`,
+			ifMatch:      `"outdated"`,
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "patch outdated",
+			method:       http.MethodPatch,
+			path:         "/branch/1",
+			body:         `{"name": "feature/y"}`,
+			ifMatch:      `"outdated"`,
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "delete not existing",
+			method:       http.MethodDelete,
+			path:         "/branch/2",
+			ifMatch:      "*",
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "put",
+			method:         http.MethodPut,
+			path:           "/branch",
+			body:           `{"id": 1, "name": "feature/y", "repository_id": 1}`,
+			ifMatch:        tag,
+			expectedCode:   http.StatusOK,
+			expectedBranch: true,
+		},
+		{
+			name:         "delete outdated",
+			method:       http.MethodDelete,
+			path:         "/branch/1",
+			ifMatch:      tag,
+			expectedCode: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(testCase.method, testCase.path, strings.NewReader(testCase.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.ifMatch != "" {
+				req.Header.Set(etag.HeaderKeyIfMatch, testCase.ifMatch)
+			}
+
+			code, actual := serve(t, svc, req, nil)
+			if testCase.expectedCode != code {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, code)
+			}
+
+			if testCase.expectedBranch && actual.Branch == nil {
+				t.Error("expected branch in response")
+			}
+		})
+	}
+}
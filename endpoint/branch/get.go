@@ -0,0 +1,66 @@
+package branch
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/smis"
+
+	"github.com/gorilla/mux"
+)
+
+// get returns a branch identified by ID
+func (h *Handler) get(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	idRaw, ok := mux.Vars(request)["id"]
+	if !ok {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errNoID)
+
+		return
+	}
+
+	id, err := strconv.Atoi(idRaw)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "converting id to integer failed")
+
+		return
+	}
+
+	// 1. load model
+	model, err := h.mapper.Load(request.Context(), id)
+	if errors.Is(err, branchmapper.ErrNotFound) {
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "branch with id %d not found", id)
+
+		return
+	} else if err != nil {
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to load branch for id: %d", id,
+		)
+
+		return
+	}
+
+	// 2. send response
+	writer.Header().Set(etag.HeaderKeyETag, branchmapper.ETag(model))
+	h.link(model)
+	payload.Branch = model
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
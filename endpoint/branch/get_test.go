@@ -0,0 +1,102 @@
+package branch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketmodel"
+)
+
+func TestHandler_Get(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointGet")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+	if _, err := mapper.Save(context.Background(), &branchmodel.Branch{
+		Name:         "feature/JIRA-1",
+		RepositoryID: 1,
+		TicketID:     "JIRA-1",
+	}); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		path            string
+		expectedCode    int
+		expectedPayload *Payload
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:         "success",
+			path:         "/branch/1",
+			expectedCode: http.StatusOK,
+			expectedPayload: NewPayload(&branchmodel.Branch{
+				ID:           1,
+				Name:         "feature/JIRA-1",
+				RepositoryID: 1,
+				TicketID:     "JIRA-1",
+				Ticket: &ticketmodel.Ticket{
+					Key:     "JIRA-1",
+					Summary: "first",
+					Status:  "Open",
+					Type:    "Story",
+					URL:     testJiraURL + "/browse/JIRA-1",
+				},
+			}),
+		},
+		{
+			name:            "branch not found",
+			path:            "/branch/3",
+			expectedCode:    http.StatusNotFound,
+			expectedProblem: problem.New(http.StatusNotFound, "branch with id 3 not found"),
+		},
+		{
+			name:            "id not integer",
+			path:            "/branch/abc",
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: problem.New(http.StatusBadRequest, "converting id to integer failed"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			code, actual := serve(t, svc, req, testCase.expectedProblem)
+			if testCase.expectedCode != code {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, code)
+			}
+
+			testPayload(t, testCase.expectedPayload, actual)
+		})
+	}
+}
+
+func TestHandler_Get_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.get(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
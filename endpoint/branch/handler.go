@@ -0,0 +1,85 @@
+package branch
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/jira"
+)
+
+const (
+	errRequestEmpty = "request is empty"
+	errNoID         = "id must be given"
+)
+
+// Handler provides useful variables for the specific endpoint handlers
+type Handler struct {
+	svc    *smis.Service
+	mapper *branchmapper.Mapper
+	links  jira.Linker
+}
+
+// New returns a new handler, the links are used to add the url to the ticket of the branch
+func New(svc *smis.Service, db *sqlx.DB, links jira.Linker) *Handler {
+	return &Handler{
+		svc:    svc,
+		mapper: branchmapper.New(db),
+		links:  links,
+	}
+}
+
+// Init initialises the endpoints for the branch
+func Init(svc *smis.Service, db *sqlx.DB, links jira.Linker) error {
+	endpoint := New(svc, db, links)
+
+	_, err := svc.RegisterEndpoint("/branch/{id}", http.MethodGet, endpoint.get)
+	if err != nil {
+		return fmt.Errorf("failed to init get endpoint for branch: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/branches", http.MethodGet, endpoint.list)
+	if err != nil {
+		return fmt.Errorf("failed to init list endpoint for branch: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/branch", http.MethodPut, endpoint.put)
+	if err != nil {
+		return fmt.Errorf("failed to init put endpoint for branch: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/branch/{id}", http.MethodPatch, endpoint.patch)
+	if err != nil {
+		return fmt.Errorf("failed to init patch endpoint for branch: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/branch/{id}", http.MethodDelete, endpoint.delete)
+	if err != nil {
+		return fmt.Errorf("failed to init delete endpoint for branch: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/branches/import", http.MethodPost, endpoint.importList)
+	if err != nil {
+		return fmt.Errorf("failed to init import endpoint for branch: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/branches/export", http.MethodGet, endpoint.exportList)
+	if err != nil {
+		return fmt.Errorf("failed to init export endpoint for branch: %w", err)
+	}
+
+	return err
+}
+
+// link sets the url of the ticket of the branch
+func (h *Handler) link(model *branchmodel.Branch) {
+	if h.links == nil || model == nil || model.Ticket == nil {
+		return
+	}
+
+	model.Ticket.URL = h.links.Link(model.Ticket.Key)
+}
@@ -0,0 +1,122 @@
+package branch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+const (
+	testCluster = "test_endpoint_branch"
+	testJiraURL = "https://jira.example.com"
+)
+
+func setup(t *testing.T, name string) (*smis.Service, *sqlx.DB) {
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	ticket := &ticketstore.Ticket{Key: "JIRA-1", Summary: "first", Status: "Open", Type: "Story"}
+	if err = ticket.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	jiraURL := testJiraURL
+	links := jira.NewInstances([]*config.Jira{{BaseURL: &jiraURL, ProjectKeys: []string{"JIRA"}}})
+
+	if err = Init(svc, db, links); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	return svc, db
+}
+
+// serve sends the request to the service and decodes the payload of the response, the response is checked against the
+// problem if one is expected
+func serve(t *testing.T, svc *smis.Service, request *http.Request, expected *problem.Problem) (int, *Payload) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	svc.Router.ServeHTTP(w, request)
+
+	if expected != nil {
+		test.CheckProblem(t, expected, w)
+		return w.Code, &Payload{}
+	}
+
+	contentType := w.Header().Get(smis.HeaderKeyContentType)
+	if contentType == problem.ContentType {
+		return w.Code, &Payload{}
+	}
+
+	if contentType != smis.HeaderContentTypeJSON {
+		t.Errorf("expected content type '%s' but got '%s'", smis.HeaderContentTypeJSON, contentType)
+	}
+
+	actual := &Payload{}
+	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+	}
+
+	return w.Code, actual
+}
+
+func testPayload(t *testing.T, expected, actual *Payload) {
+	t.Helper()
+
+	if expected == nil {
+		return
+	}
+
+	if expected.Branch == nil && actual.Branch == nil {
+		return
+	}
+
+	if expected.Branch != nil && actual.Branch == nil || expected.Branch == nil && actual.Branch != nil {
+		t.Errorf("expected branch to be '%v' but got '%v'", expected.Branch, actual.Branch)
+		return
+	}
+
+	e, a := expected.Branch, actual.Branch
+	if e.ID != a.ID || e.Name != a.Name || e.RepositoryID != a.RepositoryID || e.TicketID != a.TicketID ||
+		e.Closed != a.Closed || e.BaseBranch != a.BaseBranch {
+		t.Errorf("expected branch '%#v' but got '%#v'", e, a)
+	}
+
+	if e.Ticket == nil && a.Ticket != nil || e.Ticket != nil && (a.Ticket == nil || *e.Ticket != *a.Ticket) {
+		t.Errorf("expected ticket '%#v' but got '%#v'", e.Ticket, a.Ticket)
+	}
+
+	if a.CreatedAt.IsZero() {
+		t.Error("created at should be greater than the zero date")
+	}
+
+	if a.ModifiedAt.IsZero() {
+		t.Error("modified at should be greater than the zero date")
+	}
+}
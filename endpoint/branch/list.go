@@ -0,0 +1,95 @@
+package branch
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/problem"
+)
+
+const (
+	queryKeyRepositoryID = "repository_id"
+	queryKeyTicketID     = "ticket_id"
+	queryKeyClosed       = "closed"
+	queryKeyStatus       = "status"
+	queryKeyCategory     = "category"
+)
+
+// list returns the branches matching the filters given as query parameters
+func (h *Handler) list(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	filter, msg := newFilter(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. load models
+	models, err := h.mapper.List(request.Context(), filter)
+	if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to load branches")
+
+		return
+	}
+
+	// 2. send response
+	for _, model := range models {
+		h.link(model)
+	}
+
+	payload.Branches = models
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
+
+// newFilter returns the filter given by the query parameters, the message is set if a parameter is invalid
+func newFilter(request *http.Request) (*branchstore.Filter, string) {
+	query := request.URL.Query()
+	filter := &branchstore.Filter{
+		TicketID: query.Get(queryKeyTicketID),
+		Status:   query.Get(queryKeyStatus),
+		Category: query.Get(queryKeyCategory),
+	}
+
+	if raw := query.Get(queryKeyRepositoryID); raw != "" {
+		id, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Sprintf("%s must be an integer", queryKeyRepositoryID)
+		}
+
+		filter.RepositoryID = id
+	}
+
+	if raw := query.Get(queryKeyClosed); raw != "" {
+		closed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Sprintf("%s must be a boolean", queryKeyClosed)
+		}
+
+		filter.Closed = &closed
+	}
+
+	if filter.Category != "" && !config.IsCategory(filter.Category) {
+		return nil, fmt.Sprintf("unknown %s '%s'", queryKeyCategory, filter.Category)
+	}
+
+	return filter, ""
+}
@@ -0,0 +1,130 @@
+package branch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+func TestHandler_List(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointList")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ticket := &ticketstore.Ticket{Key: "JIRA-2", Status: "Code Review", Category: "review"}
+	if err := ticket.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	mapper := branchmapper.New(db)
+	for _, b := range []*branchmodel.Branch{
+		{Name: "feature/JIRA-1", RepositoryID: 1, TicketID: "JIRA-1"},
+		{Name: "feature/JIRA-2", RepositoryID: 1, TicketID: "JIRA-2"},
+		{Name: "master", RepositoryID: 1, Closed: true},
+	} {
+		if _, err := mapper.Save(context.Background(), b); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		path            string
+		expectedCode    int
+		expectedNames   []string
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:          "all",
+			path:          "/branches",
+			expectedCode:  http.StatusOK,
+			expectedNames: []string{"feature/JIRA-1", "feature/JIRA-2", "master"},
+		},
+		{
+			name:          "category",
+			path:          "/branches?category=review",
+			expectedCode:  http.StatusOK,
+			expectedNames: []string{"feature/JIRA-2"},
+		},
+		{
+			name:          "status and repository",
+			path:          "/branches?status=Open&repository_id=1",
+			expectedCode:  http.StatusOK,
+			expectedNames: []string{"feature/JIRA-1"},
+		},
+		{
+			name:          "closed",
+			path:          "/branches?closed=true",
+			expectedCode:  http.StatusOK,
+			expectedNames: []string{"master"},
+		},
+		{
+			name:            "unknown category",
+			path:            "/branches?category=QA",
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: problem.New(http.StatusBadRequest, "unknown category 'QA'"),
+		},
+		{
+			name:            "repository not integer",
+			path:            "/branches?repository_id=abc",
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: problem.New(http.StatusBadRequest, "repository_id must be an integer"),
+		},
+		{
+			name:            "closed not boolean",
+			path:            "/branches?closed=maybe",
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: problem.New(http.StatusBadRequest, "closed must be a boolean"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			code, actual := serve(t, svc, req, testCase.expectedProblem)
+			if testCase.expectedCode != code {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, code)
+			}
+
+			if len(testCase.expectedNames) != len(actual.Branches) {
+				t.Fatalf("expected %d branches but got %d", len(testCase.expectedNames), len(actual.Branches))
+			}
+
+			for i, name := range testCase.expectedNames {
+				if actual.Branches[i].Name != name {
+					t.Errorf("expected branch '%s' at position %d but got '%s'", name, i, actual.Branches[i].Name)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_List_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.list(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
@@ -0,0 +1,2 @@
+// Package branch provides the endpoint to manage branches.
+package branch
@@ -0,0 +1,103 @@
+package branch
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+// patch changes the branch identified by ID with a JSON merge patch (RFC 7396), only the fields given are changed
+func (h *Handler) patch(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	idRaw, ok := mux.Vars(request)["id"]
+	if !ok {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errNoID)
+
+		return
+	}
+
+	id, err := strconv.Atoi(idRaw)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "converting id to integer failed")
+
+		return
+	}
+
+	if request.Body == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "request body is empty")
+
+		return
+	}
+
+	// 1. patch model if the precondition is met
+	model, err := h.mapper.PatchIfMatch(request.Context(), id, request.Body, request.Header.Get(etag.HeaderKeyIfMatch))
+
+	var invalid *validation.Error
+
+	switch {
+	case errors.Is(err, branchmapper.ErrPreconditionFailed):
+		problem.Writef(
+			writer, request, response.Log, http.StatusPreconditionFailed,
+			"precondition failed for branch with id %d: %v", id, err,
+		)
+
+		return
+	case errors.Is(err, branchmapper.ErrNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "branch with id %d not found", id)
+
+		return
+	case errors.As(err, &invalid):
+		p := problem.New(http.StatusUnprocessableEntity, fmt.Sprintf("failed to patch branch: %v", err))
+		p.Errors = invalid.Errors
+		problem.WriteProblem(writer, request, response.Log, p)
+
+		return
+	case errors.Is(err, branchmodel.ErrDecodeJSON):
+		problem.Writef(writer, request, response.Log, http.StatusBadRequest, "failed to patch branch: %v", err)
+
+		return
+	case errors.Is(err, branchmapper.ErrDuplicate):
+		problem.Writef(
+			writer, request, response.Log, http.StatusConflict,
+			"failed to patch branch: %v", branchmapper.ErrDuplicate,
+		)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to patch branch for id: %d", id,
+		)
+
+		return
+	}
+
+	// 2. send response
+	writer.Header().Set(etag.HeaderKeyETag, branchmapper.ETag(model))
+	h.link(model)
+	payload.Branch = model
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,136 @@
+package branch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketmodel"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestHandler_Patch(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointPatch")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := branchmapper.New(db)
+	if _, err := mapper.Save(context.Background(), &branchmodel.Branch{
+		Name:         "feature/x",
+		RepositoryID: 1,
+		BaseBranch:   "main",
+	}); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		path            string
+		body            string
+		expectedCode    int
+		expectedPayload *Payload
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:         "success",
+			path:         "/branch/1",
+			body:         `{"ticket_id": "JIRA-1", "closed": true}`,
+			expectedCode: http.StatusOK,
+			expectedPayload: NewPayload(&branchmodel.Branch{
+				ID:           1,
+				Name:         "feature/x",
+				RepositoryID: 1,
+				TicketID:     "JIRA-1",
+				Closed:       true,
+				BaseBranch:   "main",
+				Ticket: &ticketmodel.Ticket{
+					Key:     "JIRA-1",
+					Summary: "first",
+					Status:  "Open",
+					Type:    "Story",
+					URL:     testJiraURL + "/browse/JIRA-1",
+				},
+			}),
+		},
+		{
+			name:         "invalid",
+			path:         "/branch/1",
+			body:         `{"name": null}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Problem{
+				Type:   problem.TypeDefault,
+				Title:  http.StatusText(http.StatusUnprocessableEntity),
+				Status: http.StatusUnprocessableEntity,
+				Detail: "failed to patch branch: validation failed: name is required",
+				Errors: []*validation.FieldError{{Field: "name", Code: validation.CodeRequired, Message: "is required"}},
+			},
+		},
+		{
+			name:         "no JSON format",
+			path:         "/branch/1",
+			body:         `no JSON`,
+			expectedCode: http.StatusBadRequest,
+			expectedProblem: &problem.Problem{
+				Type:   problem.TypeDefault,
+				Title:  http.StatusText(http.StatusBadRequest),
+				Status: http.StatusBadRequest,
+				Detail: "failed to patch branch: failed to decode JSON: merge patch is not valid JSON: " +
+					"invalid character 'o' in literal null (expecting 'u')",
+			},
+		},
+		{
+			name:            "branch not found",
+			path:            "/branch/3",
+			body:            `{}`,
+			expectedCode:    http.StatusNotFound,
+			expectedProblem: problem.New(http.StatusNotFound, "branch with id 3 not found"),
+		},
+		{
+			name:            "id not integer",
+			path:            "/branch/abc",
+			body:            `{}`,
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: problem.New(http.StatusBadRequest, "converting id to integer failed"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPatch, testCase.path, strings.NewReader(testCase.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			code, actual := serve(t, svc, req, testCase.expectedProblem)
+			if testCase.expectedCode != code {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, code)
+			}
+
+			testPayload(t, testCase.expectedPayload, actual)
+		})
+	}
+}
+
+func TestHandler_Patch_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.patch(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
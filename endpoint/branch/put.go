@@ -0,0 +1,94 @@
+package branch
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+// put creates or updates the branch
+func (h *Handler) put(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	if request.Body == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "request body is empty")
+
+		return
+	}
+
+	// 1. decode payload
+	model := &branchmodel.Branch{}
+	if err := model.DecodeJSON(request.Body); err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	code := http.StatusOK
+	if model.ID == 0 {
+		code = http.StatusCreated
+	}
+
+	// 2. save model if the precondition is met
+	id := model.ID
+	model, err := h.mapper.SaveIfMatch(request.Context(), model, request.Header.Get(etag.HeaderKeyIfMatch))
+
+	var invalid *validation.Error
+
+	switch {
+	case errors.Is(err, branchmapper.ErrPreconditionFailed):
+		problem.Writef(
+			writer, request, response.Log, http.StatusPreconditionFailed,
+			"precondition failed for branch with id %d: %v", id, err,
+		)
+
+		return
+	case errors.Is(err, branchmapper.ErrNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "branch with id %d not found", id)
+
+		return
+	case errors.As(err, &invalid):
+		p := problem.New(http.StatusUnprocessableEntity, fmt.Sprintf("failed to save branch: %v", err))
+		p.Errors = invalid.Errors
+		problem.WriteProblem(writer, request, response.Log, p)
+
+		return
+	case errors.Is(err, branchmapper.ErrDuplicate):
+		problem.Writef(
+			writer, request, response.Log, http.StatusConflict,
+			"failed to save branch: %v", branchmapper.ErrDuplicate,
+		)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to save branch")
+
+		return
+	}
+
+	// 3. send response
+	writer.Header().Set(etag.HeaderKeyETag, branchmapper.ETag(model))
+	h.link(model)
+	payload.Branch = model
+	response.WriteJSON(writer, code, payload)
+}
@@ -0,0 +1,148 @@
+package branch
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/ticket/ticketmodel"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestHandler_Put(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointPut")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		body            string
+		expectedCode    int
+		expectedPayload *Payload
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:         "new branch",
+			body:         `{"name": "feature/JIRA-1", "repository_id": 1, "ticket_id": "JIRA-1"}`,
+			expectedCode: http.StatusCreated,
+			expectedPayload: NewPayload(&branchmodel.Branch{
+				ID:           1,
+				Name:         "feature/JIRA-1",
+				RepositoryID: 1,
+				TicketID:     "JIRA-1",
+				Ticket: &ticketmodel.Ticket{
+					Key:     "JIRA-1",
+					Summary: "first",
+					Status:  "Open",
+					Type:    "Story",
+					URL:     testJiraURL + "/browse/JIRA-1",
+				},
+			}),
+		},
+		{
+			name:         "update branch with unknown ticket",
+			body:         `{"id": 1, "name": "feature/JIRA-2", "repository_id": 1, "ticket_id": "JIRA-2", "closed": true}`,
+			expectedCode: http.StatusOK,
+			expectedPayload: NewPayload(&branchmodel.Branch{
+				ID:           1,
+				Name:         "feature/JIRA-2",
+				RepositoryID: 1,
+				TicketID:     "JIRA-2",
+				Ticket:       &ticketmodel.Ticket{Key: "JIRA-2", URL: testJiraURL + "/browse/JIRA-2"},
+				Closed:       true,
+			}),
+		},
+		{
+			name:         "repository not existing",
+			body:         `{"name": "feature/JIRA-3", "repository_id": 42}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Problem{
+				Type:   problem.TypeDefault,
+				Title:  http.StatusText(http.StatusUnprocessableEntity),
+				Status: http.StatusUnprocessableEntity,
+				Detail: "failed to save branch: validation failed: repository_id doesn't exist",
+				Errors: []*validation.FieldError{
+					{Field: "repository_id", Code: validation.CodeNotFound, Message: "doesn't exist"},
+				},
+			},
+		},
+		{
+			name:         "invalid branch",
+			body:         `{"name": "feature/JIRA-3"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Problem{
+				Type:   problem.TypeDefault,
+				Title:  http.StatusText(http.StatusUnprocessableEntity),
+				Status: http.StatusUnprocessableEntity,
+				Detail: "failed to save branch: validation failed: repository_id is required",
+				Errors: []*validation.FieldError{
+					{Field: "repository_id", Code: validation.CodeRequired, Message: "is required"},
+				},
+			},
+		},
+		{
+			name:         "too long",
+			body:         `{"name": "feature/JIRA-3", "repository_id": 1, "ticket_id": "` + strings.Repeat("A", 51) + `"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Problem{
+				Type:   problem.TypeDefault,
+				Title:  http.StatusText(http.StatusUnprocessableEntity),
+				Status: http.StatusUnprocessableEntity,
+				Detail: "failed to save branch: validation failed: ticket_id must not be longer than 50 characters",
+				Errors: []*validation.FieldError{
+					{Field: "ticket_id", Code: validation.CodeTooLong, Message: "must not be longer than 50 characters"},
+				},
+			},
+		},
+		{
+			name:            "duplicate",
+			body:            `{"name": "feature/JIRA-2", "repository_id": 1}`,
+			expectedCode:    http.StatusConflict,
+			expectedProblem: problem.New(http.StatusConflict, "failed to save branch: "+branchmapper.ErrDuplicate.Error()),
+		},
+		{
+			name:            "update not existing branch",
+			body:            `{"id": 99, "name": "feature/JIRA-3", "repository_id": 1}`,
+			expectedCode:    http.StatusNotFound,
+			expectedProblem: problem.New(http.StatusNotFound, "branch with id 99 not found"),
+		},
+		{
+			name:         "no JSON format",
+			body:         `no JSON`,
+			expectedCode: http.StatusBadRequest,
+			expectedProblem: problem.New(
+				http.StatusBadRequest,
+				"failed to decode JSON: invalid character 'o' in literal null (expecting 'u')",
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, "/branch", strings.NewReader(testCase.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			code, actual := serve(t, svc, req, testCase.expectedProblem)
+			if testCase.expectedCode != code {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, code)
+			}
+
+			testPayload(t, testCase.expectedPayload, actual)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package branch
+
+import (
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/bulk"
+)
+
+// Payload represents response payload for endpoint
+type Payload struct {
+	Branch   *branchmodel.Branch  `json:"branch,omitempty"`
+	Branches branchmodel.Branches `json:"branches,omitempty"`
+	Import   *bulk.Result         `json:"import,omitempty"`
+}
+
+// NewPayload returns a new Payload struct
+func NewPayload(branch *branchmodel.Branch) *Payload {
+	return &Payload{Branch: branch}
+}
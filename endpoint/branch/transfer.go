@@ -0,0 +1,125 @@
+package branch
+
+import (
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/problem"
+)
+
+// exportFileName is the name of the file of exports without extension
+const exportFileName = "branches"
+
+// importList creates or updates the branches listed in JSON, CSV or YAML, the result reports per row what was done or
+// why it failed
+func (h *Handler) importList(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	if request.Body == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "request body is empty")
+
+		return
+	}
+
+	format, err := bulk.NewFormat(request, smis.HeaderKeyContentType)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusUnsupportedMediaType, err.Error())
+
+		return
+	}
+
+	dryRun, err := bulk.DryRun(request)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// 1. decode rows
+	rows, err := bulk.Decode(format, request.Body)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// 2. import rows
+	result, err := h.mapper.Import(request.Context(), rows, dryRun)
+	if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to import branches")
+
+		return
+	}
+
+	// 3. send response
+	payload.Import = result
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
+
+// exportList returns the branches matching the filters given as query parameters in JSON, CSV or YAML, the result
+// can be imported again
+func (h *Handler) exportList(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	format, err := bulk.NewFormat(request, bulk.HeaderKeyAccept)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusNotAcceptable, err.Error())
+
+		return
+	}
+
+	filter, msg := newFilter(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. load models
+	transfers, err := h.mapper.Export(request.Context(), filter)
+	if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to load branches")
+
+		return
+	}
+
+	// 2. send response
+	list := make([]interface{}, 0, len(transfers))
+
+	for _, t := range transfers {
+		h.link(&t.Branch)
+		list = append(list, t)
+	}
+
+	if err = bulk.Write(writer, format, exportFileName, branchmodel.TransferColumns(), list); err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to export branches")
+	}
+}
@@ -0,0 +1,269 @@
+package branch
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/branch/branchmapper"
+	"github.com/rebel-l/branma_be/branch/branchmodel"
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+func TestHandler_Import(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointImport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		query           string
+		contentType     string
+		body            string
+		expected        *bulk.Result
+		expectedProblem *problem.Problem
+	}{
+		{
+			name: "import",
+			body: `[
+				{"name": "feature/JIRA-1", "repository_id": 1, "ticket_id": "JIRA-1"},
+				{"name": "master", "repository_name": "repo", "repository_url": "repo.url", "closed": true},
+				{"name": "develop", "repository_id": 5}
+			]`,
+			expected: &bulk.Result{
+				Created: 2,
+				Failed:  1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionCreated, ID: 1},
+					{Row: 2, Action: bulk.ActionCreated, ID: 2},
+					{Row: 3, Action: bulk.ActionFailed, Detail: "repository of branch was not found: id 5"},
+				},
+			},
+		},
+		{
+			name:        "dry run",
+			query:       "?dry_run=1",
+			contentType: "text/csv",
+			body:        "name,repository_id,ticket_id,closed\nmaster,1,,false\nfeature/JIRA-1,1,JIRA-1,false\n",
+			expected: &bulk.Result{
+				DryRun:    true,
+				Updated:   1,
+				Unchanged: 1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionUpdated, ID: 2},
+					{Row: 2, Action: bulk.ActionUnchanged, ID: 1},
+				},
+			},
+		},
+		{
+			name:        "unsupported format",
+			contentType: "text/plain",
+			body:        "master",
+			expectedProblem: problem.New(
+				http.StatusUnsupportedMediaType,
+				"unknown format, use json, csv or yaml: Content-Type 'text/plain'",
+			),
+		},
+		{
+			name:  "no list",
+			query: "?format=yaml",
+			body:  "name: master\n",
+			expectedProblem: problem.New(
+				http.StatusBadRequest,
+				"failed to decode rows: yaml: unmarshal errors:\n  line 1: cannot unmarshal !!map into []interface {}",
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(
+				http.MethodPost, "/branches/import"+testCase.query, strings.NewReader(testCase.body),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.contentType != "" {
+				req.Header.Set(smis.HeaderKeyContentType, testCase.contentType)
+			}
+
+			code, actual := serve(t, svc, req, testCase.expectedProblem)
+			if testCase.expectedProblem != nil {
+				return
+			}
+
+			if code != http.StatusOK {
+				t.Errorf("expected code %d but got %d", http.StatusOK, code)
+			}
+
+			test.CheckResult(t, testCase.expected, actual.Import)
+		})
+	}
+
+	master, err := branchmapper.New(db).Load(context.Background(), 2)
+	if err != nil || !master.Closed {
+		t.Errorf("expected master to be closed after dry run but got %#v and error '%v'", master, err)
+	}
+}
+
+func TestHandler_Export(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointExport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ticket := &ticketstore.Ticket{Key: "JIRA-1"}
+	if err := ticket.ReadByKey(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	ticket.Category = config.CategoryReview
+	if err := ticket.Update(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	mapper := branchmapper.New(db)
+	for _, b := range []*branchmodel.Branch{
+		{Name: "feature/JIRA-1", RepositoryID: 1, TicketID: "JIRA-1"},
+		{Name: "master", RepositoryID: 1, Closed: true},
+	} {
+		if _, err := mapper.Save(context.Background(), b); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		query           string
+		expectedFormat  bulk.Format
+		expectedNames   []string
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:           "all",
+			expectedFormat: bulk.FormatJSON,
+			expectedNames:  []string{"feature/JIRA-1", "master"},
+		},
+		{
+			name:           "closed as csv",
+			query:          "?format=csv&closed=true",
+			expectedFormat: bulk.FormatCSV,
+			expectedNames:  []string{"master"},
+		},
+		{
+			name:           "ticket as yaml",
+			query:          "?format=yaml&ticket_id=JIRA-1",
+			expectedFormat: bulk.FormatYAML,
+			expectedNames:  []string{"feature/JIRA-1"},
+		},
+		{
+			name:           "category",
+			query:          "?category=review",
+			expectedFormat: bulk.FormatJSON,
+			expectedNames:  []string{"feature/JIRA-1"},
+		},
+		{
+			name:            "unknown category",
+			query:           "?category=reveiw",
+			expectedProblem: problem.New(http.StatusBadRequest, "unknown category 'reveiw'"),
+		},
+		{
+			name:            "invalid filter",
+			query:           "?closed=maybe",
+			expectedProblem: problem.New(http.StatusBadRequest, "closed must be a boolean"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/branches/export"+testCase.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected code %d but got %d", http.StatusOK, w.Code)
+			}
+
+			if actual := w.Header().Get(smis.HeaderKeyContentType); actual != testCase.expectedFormat.ContentType() {
+				t.Errorf("expected content type '%s' but got '%s'", testCase.expectedFormat.ContentType(), actual)
+			}
+
+			rows, err := bulk.Decode(testCase.expectedFormat, bytes.NewReader(w.Body.Bytes()))
+			if err != nil {
+				t.Fatalf("failed to decode export: %v | %s", err, w.Body.Bytes())
+			}
+
+			if len(testCase.expectedNames) != len(rows) {
+				t.Fatalf("expected %d branches but got %d", len(testCase.expectedNames), len(rows))
+			}
+
+			for i, row := range rows {
+				actual := &branchmodel.Transfer{}
+				if err := row.Decode(actual); err != nil {
+					t.Fatalf("failed to decode row %d: %v", row.Number, err)
+				}
+
+				if actual.Name != testCase.expectedNames[i] || actual.RepositoryName != "repo" ||
+					actual.RepositoryURL != "repo.url" {
+					t.Errorf("expected branch '%s' of repository 'repo' but got %#v", testCase.expectedNames[i], actual)
+				}
+
+				if testCase.expectedFormat != bulk.FormatCSV && actual.TicketID != "" &&
+					(actual.Ticket == nil || actual.Ticket.URL != testJiraURL+"/browse/"+actual.TicketID) {
+					t.Errorf("expected ticket with url but got %#v", actual.Ticket)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_Transfer_RequestNil(t *testing.T) {
+	handler := Handler{}
+
+	for name, f := range map[string]http.HandlerFunc{"import": handler.importList, "export": handler.exportList} {
+		w := httptest.NewRecorder()
+		f(w, nil)
+
+		t.Run(name, func(t *testing.T) {
+			test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitsync"
+)
+
+const (
+	errRequestEmpty = "request is empty"
+)
+
+// Handler provides useful variables for the specific endpoint handlers
+type Handler struct {
+	ctx    context.Context
+	svc    *smis.Service
+	syncer *gitsync.Syncer
+}
+
+// New returns a new handler, the write back is optional and can be nil. Synchronisations run in the background until
+// they finished or the context is done.
+func New(
+	ctx context.Context,
+	svc *smis.Service,
+	db *sqlx.DB,
+	cfg *config.Git,
+	writeBack gitsync.WriteBack,
+) (*Handler, error) {
+	syncer, err := gitsync.New(db, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if writeBack != nil {
+		syncer.WithWriteBack(writeBack)
+	}
+
+	return &Handler{
+		ctx:    ctx,
+		svc:    svc,
+		syncer: syncer,
+	}, nil
+}
+
+// Init initialises the endpoints for git, synchronisations are cancelled when the context is done
+func Init(ctx context.Context, svc *smis.Service, db *sqlx.DB, cfg *config.Git, writeBack gitsync.WriteBack) error {
+	endpoint, err := New(ctx, svc, db, cfg, writeBack)
+	if err != nil {
+		return fmt.Errorf("failed to init handler for git: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint(pathSync, http.MethodPost, endpoint.sync)
+	if err != nil {
+		return fmt.Errorf("failed to init sync endpoint for git: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint(pathSync, http.MethodGet, endpoint.lastSync)
+	if err != nil {
+		return fmt.Errorf("failed to init last sync endpoint for git: %w", err)
+	}
+
+	return err
+}
@@ -0,0 +1,2 @@
+// Package git provides the endpoints to synchronise the git repositories.
+package git
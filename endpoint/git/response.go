@@ -0,0 +1,9 @@
+package git
+
+import "github.com/rebel-l/branma_be/git/gitsync"
+
+// Payload represents response payload for endpoint
+type Payload struct {
+	Running bool            `json:"running"`
+	Result  *gitsync.Result `json:"result,omitempty"`
+}
@@ -0,0 +1,84 @@
+package git
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/git/gitsync"
+	"github.com/rebel-l/branma_be/problem"
+)
+
+const (
+	headerKeyLocation = "Location"
+	pathSync          = "/git/sync"
+)
+
+// sync starts the synchronisation of the branches and versions of all repositories with git in the background, as
+// fetching all repositories takes longer than a request may last. Its state is provided by lastSync.
+func (h *Handler) sync(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	// 1. start synchronisation
+	err := h.syncer.Start(h.ctx, response.Log)
+	if errors.Is(err, gitsync.ErrRunning) {
+		problem.Write(writer, request, response.Log, http.StatusConflict, err.Error())
+
+		return
+	} else if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to synchronise git repositories",
+		)
+
+		return
+	}
+
+	// 2. send response
+	payload.Running = true
+	payload.Result = h.syncer.Last()
+
+	writer.Header().Set(headerKeyLocation, pathSync)
+	response.WriteJSON(writer, http.StatusAccepted, payload)
+}
+
+// lastSync returns whether a synchronisation of the git repositories is running and the result of the last one
+func (h *Handler) lastSync(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	// 1. load state
+	payload.Running = h.syncer.Running()
+	payload.Result = h.syncer.Last()
+
+	if !payload.Running && payload.Result == nil {
+		problem.Write(writer, request, response.Log, http.StatusNotFound, "git repositories were not synchronised yet")
+
+		return
+	}
+
+	// 2. send response
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,149 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+)
+
+const (
+	testCluster = "test_endpoint_git"
+)
+
+func TestHandler_Sync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "endpointSync")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := test.NewGitRemote(t, testCluster, "endpointSync")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 feature")
+	remote.Branch("release/1.0.0", "master")
+	remote.Merge("release/1.0.0", "feature/JIRA-1")
+
+	repo := &repositorystore.Repository{Name: "repo", URL: remote.Path}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	workPath := filepath.Join(filepath.Dir(remote.Path), "work")
+	if err = Init(context.Background(), svc, db, &config.Git{WorkPath: &workPath}, nil); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	// 2. test
+	w := serve(t, svc, http.MethodGet)
+	test.CheckProblem(t, problem.New(http.StatusNotFound, "git repositories were not synchronised yet"), w)
+
+	w = serve(t, svc, http.MethodPost)
+	if w.Code != http.StatusAccepted {
+		t.Errorf("expected code %d but got %d", http.StatusAccepted, w.Code)
+	}
+
+	if location := w.Header().Get(headerKeyLocation); location != pathSync {
+		t.Errorf("expected location '%s' but got '%s'", pathSync, location)
+	}
+
+	if actual := decode(t, w); !actual.Running {
+		t.Errorf("expected synchronisation to run but got %s", w.Body.Bytes())
+	}
+
+	// the synchronisation runs in the background, so its state is polled until it finished
+	var actual *Payload
+
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		w = serve(t, svc, http.MethodGet)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected code %d but got %d", http.StatusOK, w.Code)
+		}
+
+		if actual = decode(t, w); !actual.Running || time.Now().After(deadline) {
+			break
+		}
+	}
+
+	if actual.Running || actual.Result == nil {
+		t.Fatalf("expected finished synchronisation with result but got: %s", w.Body.Bytes())
+	}
+
+	if actual.Result.Repositories != 1 || actual.Result.BranchesCreated != 3 ||
+		actual.Result.VersionsCreated != 1 || actual.Result.BranchVersionsCreated != 1 {
+		t.Errorf("unexpected result %#v", actual.Result)
+	}
+}
+
+// serve sends a request with the given method to the sync endpoint
+func serve(t *testing.T, svc *smis.Service, method string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest(method, pathSync, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	svc.Router.ServeHTTP(w, req)
+
+	return w
+}
+
+// decode returns the payload of the response, it must be JSON
+func decode(t *testing.T, w *httptest.ResponseRecorder) *Payload {
+	t.Helper()
+
+	contentType := w.Header().Get(smis.HeaderKeyContentType)
+	if contentType != smis.HeaderContentTypeJSON {
+		t.Errorf("expected content type '%s' but got '%s'", smis.HeaderContentTypeJSON, contentType)
+	}
+
+	actual := &Payload{}
+	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+	}
+
+	return actual
+}
+
+func TestHandler_Sync_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.sync(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
+
+func TestHandler_LastSync_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.lastSync(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
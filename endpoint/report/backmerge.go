@@ -0,0 +1,65 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/report/backmerge"
+)
+
+// backMergeReport returns the changes of release branches never merged back into the main branch of a repository
+func (h *Handler) backMergeReport(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	repositoryID, msg := id(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	includeUnreleased := request.URL.Query().Get("unreleased") == "true"
+
+	// 1. create report
+	report, err := h.backMerge.Report(request.Context(), repositoryID, includeUnreleased)
+
+	switch {
+	case errors.Is(err, backmerge.ErrRepositoryNotFound):
+		problem.Writef(
+			writer, request, response.Log, http.StatusNotFound,
+			"repository with id %d not found", repositoryID,
+		)
+
+		return
+	case errors.Is(err, backmerge.ErrNotSynchronised):
+		problem.Write(writer, request, response.Log, http.StatusConflict, err.Error())
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to create back merge report for repository id: %d", repositoryID,
+		)
+
+		return
+	}
+
+	// 2. send response
+	payload.BackMerge = report
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitsync"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+)
+
+const (
+	testCluster = "test_endpoint_report"
+)
+
+func TestHandler_BackMergeReport(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "backmerge")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := test.NewGitRemote(t, testCluster, "backmerge")
+	remote.Branch("release/1.0.0", "master")
+	remote.Commit("release/1.0.0", "a.txt", "JIRA-5 hotfix never merged back")
+	remote.Tag("v1.0.0", "release/1.0.0")
+
+	repo := &repositorystore.Repository{Name: "repo", URL: remote.Path}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	workPath := filepath.Join(filepath.Dir(remote.Path), "work")
+	cfg := &config.Git{WorkPath: &workPath}
+
+	syncer, err := gitsync.New(db, cfg)
+	if err != nil {
+		t.Fatalf("failed to create syncer: %v", err)
+	}
+
+	if _, err = syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	if err = Init(svc, db, cfg, jira.New(&config.Jira{}), &config.Jira{}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected int
+		commits  int
+	}{
+		{
+			name:     "success",
+			path:     "/report/repository/1/backmerge",
+			expected: http.StatusOK,
+			commits:  1,
+		},
+		{
+			name:     "not found",
+			path:     "/report/repository/2/backmerge",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "id not integer",
+			path:     "/report/repository/abc/backmerge",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if w.Code != testCase.expected {
+				t.Errorf("expected code %d but got %d", testCase.expected, w.Code)
+			}
+
+			if testCase.expected != http.StatusOK {
+				if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+					t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+				}
+
+				return
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.BackMerge == nil {
+				t.Fatalf("expected report but got: %s", w.Body.Bytes())
+			}
+
+			if len(actual.BackMerge.Commits) != testCase.commits {
+				t.Errorf("expected %d commits but got %d", testCase.commits, len(actual.BackMerge.Commits))
+			}
+		})
+	}
+}
+
+func TestHandler_BackMergeReport_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.backMergeReport(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
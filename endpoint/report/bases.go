@@ -0,0 +1,67 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/report/branchbase"
+)
+
+// basesReport returns the open branches of a repository which were cut from an unexpected base, the query parameter
+// category restricts it to the branches of tickets of one category
+func (h *Handler) basesReport(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	repositoryID, msg := id(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	c, msg := category(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. create report
+	report, err := h.bases.Report(request.Context(), repositoryID, c)
+
+	switch {
+	case errors.Is(err, branchbase.ErrRepositoryNotFound):
+		problem.Writef(
+			writer, request, response.Log, http.StatusNotFound,
+			"repository with id %d not found", repositoryID,
+		)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to create bases report for repository id: %d", repositoryID,
+		)
+
+		return
+	}
+
+	// 2. send response
+	payload.Bases = report
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,147 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+func TestHandler_BasesReport(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "bases")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "https://github.com/rebel-l/repo"}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	if err = (&ticketstore.Ticket{Key: "JIRA-1", Type: "Bug"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	branch := &branchstore.Branch{
+		Name:         "hotfix/JIRA-1",
+		RepositoryID: repo.ID,
+		TicketID:     "JIRA-1",
+		BaseBranch:   "master",
+	}
+	if err = branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	cfg := &config.Git{ExpectedBases: map[string]string{"Bug": config.BaseRelease}}
+	if err = Init(svc, db, cfg, jira.New(&config.Jira{}), &config.Jira{}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected int
+		branches int
+	}{
+		{
+			name:     "success",
+			path:     "/report/repository/1/bases",
+			expected: http.StatusOK,
+			branches: 1,
+		},
+		{
+			name:     "category",
+			path:     "/report/repository/1/bases?category=done",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "unknown category",
+			path:     "/report/repository/1/bases?category=reveiw",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "not found",
+			path:     "/report/repository/2/bases",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "id not integer",
+			path:     "/report/repository/abc/bases",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if w.Code != testCase.expected {
+				t.Errorf("expected code %d but got %d", testCase.expected, w.Code)
+			}
+
+			if testCase.expected != http.StatusOK {
+				if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+					t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+				}
+
+				return
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.Bases == nil {
+				t.Fatalf("expected report but got: %s", w.Body.Bytes())
+			}
+
+			if len(actual.Bases.Branches) != testCase.branches {
+				t.Errorf("expected %d branches but got %d", testCase.branches, len(actual.Bases.Branches))
+			}
+		})
+	}
+}
+
+func TestHandler_BasesReport_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.basesReport(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
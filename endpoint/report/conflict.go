@@ -0,0 +1,84 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/report/conflict"
+)
+
+// conflictsByRepository returns the predicted conflicts between the open branches of a repository, the query parameter
+// category restricts it to the branches of tickets of one category
+func (h *Handler) conflictsByRepository(writer http.ResponseWriter, request *http.Request) {
+	h.conflicts(writer, request, "repository", h.conflict.ReportByRepository)
+}
+
+// conflictsByVersion returns the predicted conflicts between the open branches carrying the tickets of a version, the
+// query parameter category restricts it to the branches of tickets of one category
+func (h *Handler) conflictsByVersion(writer http.ResponseWriter, request *http.Request) {
+	h.conflicts(writer, request, "version", h.conflict.ReportByVersion)
+}
+
+func (h *Handler) conflicts(
+	writer http.ResponseWriter,
+	request *http.Request,
+	entity string,
+	report func(ctx context.Context, id int, category string) (*conflict.Report, error),
+) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	entityID, msg := id(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	c, msg := category(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. create report
+	res, err := report(request.Context(), entityID, c)
+
+	switch {
+	case errors.Is(err, conflict.ErrRepositoryNotFound), errors.Is(err, conflict.ErrVersionNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "%s with id %d not found", entity, entityID)
+
+		return
+	case errors.Is(err, conflict.ErrNotSynchronised):
+		problem.Write(writer, request, response.Log, http.StatusConflict, err.Error())
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to create conflicts report for %s id: %d", entity, entityID,
+		)
+
+		return
+	}
+
+	// 2. send response
+	payload.Conflicts = res
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
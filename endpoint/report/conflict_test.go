@@ -0,0 +1,162 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitsync"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+)
+
+func TestHandler_Conflicts(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "conflicts")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	remote := test.NewGitRemote(t, testCluster, "conflicts")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 feature")
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Commit("feature/JIRA-2", "a.txt", "JIRA-2 feature")
+	remote.Branch("release/1.0.0", "master")
+	remote.Merge("release/1.0.0", "feature/JIRA-1")
+
+	repo := &repositorystore.Repository{Name: "repo", URL: remote.Path}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	workPath := filepath.Join(filepath.Dir(remote.Path), "work")
+	cfg := &config.Git{WorkPath: &workPath}
+
+	syncer, err := gitsync.New(db, cfg)
+	if err != nil {
+		t.Fatalf("failed to create syncer: %v", err)
+	}
+
+	if _, err = syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	if err = Init(svc, db, cfg, jira.New(&config.Jira{}), &config.Jira{}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name      string
+		path      string
+		expected  int
+		conflicts int
+	}{
+		{
+			name:      "repository",
+			path:      "/report/repository/1/conflicts",
+			expected:  http.StatusOK,
+			conflicts: 1,
+		},
+		{
+			name:     "repository and category",
+			path:     "/report/repository/1/conflicts?category=review",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "unknown category",
+			path:     "/report/version/1/conflicts?category=reveiw",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "version",
+			path:     "/report/version/1/conflicts",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "repository not found",
+			path:     "/report/repository/2/conflicts",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "version not found",
+			path:     "/report/version/2/conflicts",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "id not integer",
+			path:     "/report/version/abc/conflicts",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if w.Code != testCase.expected {
+				t.Errorf("expected code %d but got %d", testCase.expected, w.Code)
+			}
+
+			if testCase.expected != http.StatusOK {
+				if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+					t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+				}
+
+				return
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.Conflicts == nil {
+				t.Fatalf("expected report but got: %s", w.Body.Bytes())
+			}
+
+			if len(actual.Conflicts.Conflicts) != testCase.conflicts {
+				t.Errorf("expected %d conflicts but got %d", testCase.conflicts, len(actual.Conflicts.Conflicts))
+			}
+		})
+	}
+}
+
+func TestHandler_Conflicts_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.conflictsByRepository(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
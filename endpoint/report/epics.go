@@ -0,0 +1,58 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/report/epic"
+)
+
+const (
+	queryKeyEpic = "key"
+)
+
+// epicsReport returns the progress of the branches per epic, the query parameter key restricts it to one epic and the
+// query parameter category to the tickets of one category
+func (h *Handler) epicsReport(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	c, msg := category(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. create report
+	report, err := h.epics.Report(request.Context(), request.URL.Query().Get(queryKeyEpic), c)
+
+	switch {
+	case errors.Is(err, epic.ErrNotFound):
+		problem.Write(writer, request, response.Log, http.StatusNotFound, err.Error())
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to create epics report")
+
+		return
+	}
+
+	// 2. send response
+	payload.Epics = report
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+func TestHandler_EpicsReport(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "epics")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "https://github.com/rebel-l/repo"}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	for _, ticket := range []*ticketstore.Ticket{
+		{Key: "EPIC-1", Summary: "epic"},
+		{Key: "JIRA-1", Parent: "EPIC-1"},
+	} {
+		if err = ticket.Create(context.Background(), db); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	branch := &branchstore.Branch{Name: "feature/JIRA-1", RepositoryID: repo.ID, TicketID: "JIRA-1"}
+	if err = branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	if err = Init(svc, db, &config.Git{}, nil, &config.Jira{}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected int
+	}{
+		{
+			name:     "all epics",
+			path:     "/report/epics",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "single epic",
+			path:     "/report/epics?key=EPIC-1",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "unknown epic",
+			path:     "/report/epics?key=EPIC-2",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "unknown category",
+			path:     "/report/epics?category=reveiw",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if w.Code != testCase.expected {
+				t.Errorf("expected code %d but got %d", testCase.expected, w.Code)
+			}
+
+			if testCase.expected != http.StatusOK {
+				if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+					t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+				}
+
+				return
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.Epics == nil || len(actual.Epics.Epics) != 1 {
+				t.Fatalf("expected report with one epic but got: %s", w.Body.Bytes())
+			}
+
+			e := actual.Epics.Epics[0]
+			if e.Key != "EPIC-1" || e.Summary != "epic" || e.Tickets != 1 || e.BranchesOpen != 1 {
+				t.Errorf("expected EPIC-1 with one ticket and one open branch but got %#v", e)
+			}
+		})
+	}
+}
+
+func TestHandler_EpicsReport_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.epicsReport(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
@@ -0,0 +1,67 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/report/fixversion"
+)
+
+// fixVersionsReport returns the differences between the fix versions in JIRA and the contents of a version
+func (h *Handler) fixVersionsReport(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	versionID, msg := id(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. create report
+	report, err := h.fixVersion.Report(request.Context(), versionID)
+
+	switch {
+	case errors.Is(err, fixversion.ErrVersionNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "version with id %d not found", versionID)
+
+		return
+	case errors.Is(err, jira.ErrAuthentication), errors.Is(err, jira.ErrRateLimited),
+		errors.Is(err, jira.ErrUnexpectedResponse):
+		response.Log.Error(err)
+
+		problem.Write(
+			writer, request, response.Log, http.StatusBadGateway,
+			"failed to create fix versions report: "+err.Error(),
+		)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to create fix versions report for version id: %d", versionID,
+		)
+
+		return
+	}
+
+	// 2. send response
+	payload.FixVersions = report
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
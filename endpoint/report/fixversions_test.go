@@ -0,0 +1,159 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestHandler_FixVersionsReport(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "fixVersions")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "https://github.com/rebel-l/repo"}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err = version.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	branch := &branchstore.Branch{Name: "feature/JIRA-1", RepositoryID: repo.ID, TicketID: "JIRA-1"}
+	if err = branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	bv := &versionstore.BranchVersion{BranchID: branch.ID, VersionID: version.ID}
+	if err = bv.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	server := jirafake.NewServer()
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", StatusCategory: "indeterminate", FixVersions: []string{"1.0.0"}})
+	server.AddIssue(&jira.Issue{Key: "JIRA-2", StatusCategory: "done", FixVersions: []string{"1.0.0"}})
+
+	if err = Init(svc, db, &config.Git{}, jira.New(&config.Jira{BaseURL: &server.URL}), &config.Jira{}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		path     string
+		expected int
+		missing  int
+	}{
+		{
+			name:     "success",
+			path:     "/report/version/1/fixversions",
+			expected: http.StatusOK,
+			missing:  1,
+		},
+		{
+			name:     "not found",
+			path:     "/report/version/2/fixversions",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "id not integer",
+			path:     "/report/version/abc/fixversions",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if w.Code != testCase.expected {
+				t.Errorf("expected code %d but got %d", testCase.expected, w.Code)
+			}
+
+			if testCase.expected != http.StatusOK {
+				if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+					t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+				}
+
+				return
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.FixVersions == nil {
+				t.Fatalf("expected report but got: %s", w.Body.Bytes())
+			}
+
+			if len(actual.FixVersions.Missing) != testCase.missing {
+				t.Errorf("expected %d missing tickets but got %d", testCase.missing, len(actual.FixVersions.Missing))
+			}
+		})
+	}
+
+	// 3. jira fails
+	server.SetCredentials("branma", "secret")
+
+	req, err := http.NewRequest(http.MethodGet, "/report/version/1/fixversions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	svc.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected code %d but got %d", http.StatusBadGateway, w.Code)
+	}
+}
+
+func TestHandler_FixVersionsReport_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.fixVersionsReport(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/jmoiron/sqlx"
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/report/backmerge"
+	"github.com/rebel-l/branma_be/report/branchbase"
+	"github.com/rebel-l/branma_be/report/conflict"
+	"github.com/rebel-l/branma_be/report/epic"
+	"github.com/rebel-l/branma_be/report/fixversion"
+	"github.com/rebel-l/branma_be/report/missingbranch"
+)
+
+const (
+	errRequestEmpty = "request is empty"
+	errNoID         = "id must be given"
+	errIDNoInteger  = "converting id to integer failed"
+
+	queryKeyCategory = "category"
+)
+
+// Handler provides useful variables for the specific endpoint handlers
+type Handler struct {
+	svc             *smis.Service
+	backMerge       *backmerge.Reporter
+	conflict        *conflict.Reporter
+	bases           *branchbase.Reporter
+	fixVersion      *fixversion.Reporter
+	missingBranches *missingbranch.Reporter
+	epics           *epic.Reporter
+}
+
+// New returns a new handler, the JIRA configuration provides the JQL used for the missing branch report if the request
+// doesn't provide one and the mapping of statuses to categories
+func New(svc *smis.Service, db *sqlx.DB, cfg *config.Git, client jira.Client, jiraCfg *config.Jira) *Handler {
+	return &Handler{
+		svc:             svc,
+		backMerge:       backmerge.New(db, cfg),
+		conflict:        conflict.New(db, cfg),
+		bases:           branchbase.New(db, cfg),
+		fixVersion:      fixversion.New(db, client),
+		missingBranches: missingbranch.New(db, client, jiraCfg.GetMissingBranchQuery()).WithStatusCategories(jiraCfg),
+		epics:           epic.New(db),
+	}
+}
+
+// Init initialises the endpoints for the reports
+func Init(svc *smis.Service, db *sqlx.DB, cfg *config.Git, client jira.Client, jiraCfg *config.Jira) error {
+	endpoint := New(svc, db, cfg, client, jiraCfg)
+
+	_, err := svc.RegisterEndpoint("/report/repository/{id}/backmerge", http.MethodGet, endpoint.backMergeReport)
+	if err != nil {
+		return fmt.Errorf("failed to init back merge endpoint for report: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/report/repository/{id}/conflicts", http.MethodGet, endpoint.conflictsByRepository)
+	if err != nil {
+		return fmt.Errorf("failed to init repository conflicts endpoint for report: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/report/version/{id}/conflicts", http.MethodGet, endpoint.conflictsByVersion)
+	if err != nil {
+		return fmt.Errorf("failed to init version conflicts endpoint for report: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/report/repository/{id}/bases", http.MethodGet, endpoint.basesReport)
+	if err != nil {
+		return fmt.Errorf("failed to init bases endpoint for report: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/report/version/{id}/fixversions", http.MethodGet, endpoint.fixVersionsReport)
+	if err != nil {
+		return fmt.Errorf("failed to init fix versions endpoint for report: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/report/missingbranches", http.MethodGet, endpoint.missingBranchesReport)
+	if err != nil {
+		return fmt.Errorf("failed to init missing branches endpoint for report: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/report/epics", http.MethodGet, endpoint.epicsReport)
+	if err != nil {
+		return fmt.Errorf("failed to init epics endpoint for report: %w", err)
+	}
+
+	return err
+}
+
+// id returns the ID from the path of the request, the error is the message to send in the response
+func id(request *http.Request) (int, string) {
+	idRaw, ok := mux.Vars(request)["id"]
+	if !ok {
+		return 0, errNoID
+	}
+
+	i, err := strconv.Atoi(idRaw)
+	if err != nil {
+		return 0, errIDNoInteger
+	}
+
+	return i, ""
+}
+
+// category returns the category from the query of the request, the error is the message to send in the response
+func category(request *http.Request) (string, string) {
+	c := request.URL.Query().Get(queryKeyCategory)
+	if c != "" && !config.IsCategory(c) {
+		return "", fmt.Sprintf("unknown %s '%s'", queryKeyCategory, c)
+	}
+
+	return c, ""
+}
@@ -0,0 +1,72 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/report/missingbranch"
+)
+
+const (
+	queryKeyJQL = "jql"
+)
+
+// missingBranchesReport returns the tickets without branch and the branches with unknown tickets, the JQL can be
+// overwritten by the query parameter jql and the query parameter category restricts the tickets to one category
+func (h *Handler) missingBranchesReport(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	c, msg := category(request)
+	if msg != "" {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, msg)
+
+		return
+	}
+
+	// 1. create report
+	report, err := h.missingBranches.Report(request.Context(), request.URL.Query().Get(queryKeyJQL), c)
+
+	switch {
+	case errors.Is(err, missingbranch.ErrQueryMissing), errors.Is(err, jira.ErrInvalidQuery):
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	case errors.Is(err, jira.ErrAuthentication), errors.Is(err, jira.ErrRateLimited),
+		errors.Is(err, jira.ErrUnexpectedResponse):
+		response.Log.Error(err)
+
+		problem.Write(
+			writer, request, response.Log, http.StatusBadGateway,
+			"failed to create missing branches report: "+err.Error(),
+		)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Write(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to create missing branches report",
+		)
+
+		return
+	}
+
+	// 2. send response
+	payload.MissingBranches = report
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,178 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+)
+
+func TestHandler_MissingBranchesReport(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "missingBranches")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "https://github.com/rebel-l/repo"}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	for _, ticketID := range []string{"JIRA-1", "JIRA-9"} {
+		branch := &branchstore.Branch{Name: "feature/" + ticketID, RepositoryID: repo.ID, TicketID: ticketID}
+		if err = branch.Create(context.Background(), db); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	server := jirafake.NewServer()
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", Status: "In Progress"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-2", Status: "In Progress"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-3", Status: "Done"})
+
+	query := `status = "In Progress"`
+	client := jira.New(&config.Jira{BaseURL: &server.URL})
+
+	if err = Init(svc, db, &config.Git{}, client, &config.Jira{MissingBranchQuery: &query}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		jql      string
+		category string
+		expected int
+		ticket   string
+	}{
+		{
+			name:     "configured query",
+			expected: http.StatusOK,
+			ticket:   "JIRA-2",
+		},
+		{
+			name:     "query from request",
+			jql:      "status = Done",
+			expected: http.StatusOK,
+			ticket:   "JIRA-3",
+		},
+		{
+			name:     "invalid query",
+			jql:      "unknown = field",
+			expected: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown category",
+			category: "reveiw",
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			query := url.Values{}
+			if testCase.jql != "" {
+				query.Set(queryKeyJQL, testCase.jql)
+			}
+
+			if testCase.category != "" {
+				query.Set(queryKeyCategory, testCase.category)
+			}
+
+			path := "/report/missingbranches?" + query.Encode()
+
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if w.Code != testCase.expected {
+				t.Errorf("expected code %d but got %d", testCase.expected, w.Code)
+			}
+
+			if testCase.expected != http.StatusOK {
+				if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+					t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+				}
+
+				return
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.MissingBranches == nil {
+				t.Fatalf("expected report but got: %s", w.Body.Bytes())
+			}
+
+			tickets := actual.MissingBranches.TicketsWithoutBranch
+			if len(tickets) != 1 || tickets[0].Key != testCase.ticket {
+				t.Errorf("expected only ticket %s without branch but got %#v", testCase.ticket, tickets)
+			}
+
+			branches := actual.MissingBranches.BranchesUnknownTicket
+			if len(branches) != 1 || branches[0].TicketID != "JIRA-9" {
+				t.Errorf("expected only branch of JIRA-9 with unknown ticket but got %#v", branches)
+			}
+		})
+	}
+
+	// 3. jira fails
+	server.SetCredentials("branma", "secret")
+
+	req, err := http.NewRequest(http.MethodGet, "/report/missingbranches", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	svc.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected code %d but got %d", http.StatusBadGateway, w.Code)
+	}
+}
+
+func TestHandler_MissingBranchesReport_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.missingBranchesReport(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
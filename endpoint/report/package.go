@@ -0,0 +1,2 @@
+// Package report provides the endpoints for the reports.
+package report
@@ -0,0 +1,20 @@
+package report
+
+import (
+	"github.com/rebel-l/branma_be/report/backmerge"
+	"github.com/rebel-l/branma_be/report/branchbase"
+	"github.com/rebel-l/branma_be/report/conflict"
+	"github.com/rebel-l/branma_be/report/epic"
+	"github.com/rebel-l/branma_be/report/fixversion"
+	"github.com/rebel-l/branma_be/report/missingbranch"
+)
+
+// Payload represents response payload for endpoint
+type Payload struct {
+	BackMerge       *backmerge.Report     `json:"backmerge,omitempty"`
+	Conflicts       *conflict.Report      `json:"conflicts,omitempty"`
+	Bases           *branchbase.Report    `json:"bases,omitempty"`
+	FixVersions     *fixversion.Report    `json:"fix_versions,omitempty"`
+	MissingBranches *missingbranch.Report `json:"missing_branches,omitempty"`
+	Epics           *epic.Report          `json:"epics,omitempty"`
+}
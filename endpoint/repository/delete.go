@@ -1,23 +1,32 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/gorilla/mux"
 	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorymapper"
+)
+
+const (
+	queryKeyCascade = "cascade"
 )
 
-// delete removes a repository identified by ID
+// delete removes a repository identified by ID, repositories having branches or releases are only deleted together
+// with them if the query parameter cascade is true
 func (h *Handler) delete(writer http.ResponseWriter, request *http.Request) {
 	response := smis.Response{}
 	payload := &Payload{}
 
 	// 0. validate request
 	if request == nil {
-		payload.Error = errRequestEmpty
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
 
 		return
 	}
@@ -26,26 +35,79 @@ func (h *Handler) delete(writer http.ResponseWriter, request *http.Request) {
 
 	idRaw, ok := mux.Vars(request)["id"]
 	if !ok {
-		payload.Error = errNoID
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errNoID)
 
 		return
 	}
 
 	id, err := strconv.Atoi(idRaw)
 	if err != nil {
-		payload.Error = "converting id to integer failed"
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "converting id to integer failed")
 
 		return
 	}
 
-	// 1. delete model
-	if err := h.mapper.Delete(request.Context(), id); err != nil {
+	cascade := false
+
+	if raw := request.URL.Query().Get(queryKeyCascade); raw != "" {
+		cascade, err = strconv.ParseBool(raw)
+		if err != nil {
+			problem.Writef(
+				writer, request, response.Log, http.StatusBadRequest,
+				"%s must be a boolean", queryKeyCascade,
+			)
+
+			return
+		}
+	}
+
+	// 1. delete model if the precondition is met
+	ifMatch := request.Header.Get(etag.HeaderKeyIfMatch)
+	if cascade {
+		err = h.mapper.DeleteCascadeIfMatch(request.Context(), id, ifMatch)
+	} else {
+		err = h.mapper.DeleteIfMatch(request.Context(), id, ifMatch)
+	}
+
+	switch {
+	case errors.Is(err, repositorymapper.ErrPreconditionFailed):
+		problem.Writef(
+			writer, request, response.Log, http.StatusPreconditionFailed,
+			"precondition failed for repository with id %d: %v", id, err,
+		)
+
+		return
+	case errors.Is(err, repositorymapper.ErrNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "repository with id %d not found", id)
+
+		return
+	case errors.Is(err, repositorymapper.ErrHasBranches):
+		problem.Write(
+			writer, request, response.Log, http.StatusConflict,
+			fmt.Sprintf(
+				"failed to delete repository for id: %d, %v: delete them first or use %s=true to delete them as well",
+				id, err, queryKeyCascade,
+			),
+		)
+
+		return
+	case errors.Is(err, repositorymapper.ErrHasReleases):
+		problem.Write(
+			writer, request, response.Log, http.StatusConflict,
+			fmt.Sprintf(
+				"failed to delete repository for id: %d, %v: use %s=true to delete them as well",
+				id, err, queryKeyCascade,
+			),
+		)
+
+		return
+	case err != nil:
 		response.Log.Error(err)
 
-		payload.Error = fmt.Sprintf("failed to delete repository for id: %d", id)
-		response.WriteJSON(writer, http.StatusInternalServerError, payload)
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to delete repository for id: %d", id,
+		)
 
 		return
 	}
@@ -2,13 +2,17 @@ package repository
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/problem"
 	"github.com/rebel-l/branma_be/repository/repositorymapper"
 	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
 
 	"github.com/rebel-l/smis"
 )
@@ -18,6 +22,7 @@ type tcDelete struct {
 	request         *http.Request
 	expectedCode    int
 	expectedPayload string
+	expectedProblem *problem.Problem
 }
 
 func getTestCasesDelete(t *testing.T) []tcDelete { // nolint: funlen
@@ -41,7 +46,7 @@ func getTestCasesDelete(t *testing.T) []tcDelete { // nolint: funlen
 	testCases = append(testCases, c)
 
 	// 2.
-	req, err = http.NewRequest(http.MethodDelete, "/repository/3", nil)
+	req, err = http.NewRequest(http.MethodDelete, "/repository/9", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -49,8 +54,8 @@ func getTestCasesDelete(t *testing.T) []tcDelete { // nolint: funlen
 	c = tcDelete{
 		name:            "repository does not exist",
 		request:         req,
-		expectedCode:    http.StatusOK,
-		expectedPayload: "{}",
+		expectedCode:    http.StatusNotFound,
+		expectedProblem: problem.New(http.StatusNotFound, "repository with id 9 not found"),
 	}
 
 	testCases = append(testCases, c)
@@ -65,7 +70,90 @@ func getTestCasesDelete(t *testing.T) []tcDelete { // nolint: funlen
 		name:            "id not integer",
 		request:         req,
 		expectedCode:    http.StatusBadRequest,
-		expectedPayload: `{"error":"converting id to integer failed"}`,
+		expectedProblem: problem.New(http.StatusBadRequest, "converting id to integer failed"),
+	}
+
+	testCases = append(testCases, c)
+
+	// 4.
+	req, err = http.NewRequest(http.MethodDelete, "/repository/2", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcDelete{
+		name:         "repository has branches",
+		request:      req,
+		expectedCode: http.StatusConflict,
+		expectedProblem: problem.New(
+			http.StatusConflict,
+			"failed to delete repository for id: 2, repository still has branches (1): "+
+				"delete them first or use cascade=true to delete them as well",
+		),
+	}
+
+	testCases = append(testCases, c)
+
+	// 5.
+	req, err = http.NewRequest(http.MethodDelete, "/repository/2?cascade=abc", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcDelete{
+		name:            "cascade not boolean",
+		request:         req,
+		expectedCode:    http.StatusBadRequest,
+		expectedProblem: problem.New(http.StatusBadRequest, "cascade must be a boolean"),
+	}
+
+	testCases = append(testCases, c)
+
+	// 6.
+	req, err = http.NewRequest(http.MethodDelete, "/repository/2?cascade=true", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcDelete{
+		name:            "cascade",
+		request:         req,
+		expectedCode:    http.StatusOK,
+		expectedPayload: "{}",
+	}
+
+	testCases = append(testCases, c)
+
+	// 7.
+	req, err = http.NewRequest(http.MethodDelete, "/repository/3", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcDelete{
+		name:         "repository has releases",
+		request:      req,
+		expectedCode: http.StatusConflict,
+		expectedProblem: problem.New(
+			http.StatusConflict,
+			"failed to delete repository for id: 3, repository still has releases (releases: 1, tickets: 1): "+
+				"use cascade=true to delete them as well",
+		),
+	}
+
+	testCases = append(testCases, c)
+
+	// 8.
+	req, err = http.NewRequest(http.MethodDelete, "/repository/3?cascade=true", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcDelete{
+		name:            "cascade releases",
+		request:         req,
+		expectedCode:    http.StatusOK,
+		expectedPayload: "{}",
 	}
 
 	testCases = append(testCases, c)
@@ -93,7 +181,33 @@ func TestHandler_Delete(t *testing.T) {
 
 	// 2. prepare test data
 	mapper := repositorymapper.New(db)
-	if _, err := mapper.Save(context.Background(), &repositorymodel.Repository{Name: "repo", URL: "url"}); err != nil {
+	for _, repo := range []*repositorymodel.Repository{
+		{Name: "repo", URL: "https://host/repo.git"},
+		{Name: "other", URL: "https://host/other.git"},
+		{Name: "released", URL: "https://host/released.git"},
+	} {
+		if _, err := mapper.Save(context.Background(), repo); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	b := &branchstore.Branch{Name: "feature/JIRA-1", RepositoryID: 2}
+	if err := b.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	release := &versionstore.Release{VersionID: version.ID, RepositoryID: 3, ReleasedAt: time.Now()}
+	if err := release.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	ticket := &versionstore.VersionTicket{VersionID: version.ID, RepositoryID: 3, TicketID: "JIRA-1"}
+	if err := ticket.Create(context.Background(), db); err != nil {
 		t.Fatalf("failed to prepare test data: %v", err)
 	}
 
@@ -103,6 +217,11 @@ func TestHandler_Delete(t *testing.T) {
 			w := httptest.NewRecorder()
 			svc.Router.ServeHTTP(w, testCase.request)
 
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
 			if testCase.expectedCode != w.Code {
 				t.Errorf("expected code %d but got %d", testCase.expectedCode, w.Code)
 			}
@@ -124,16 +243,7 @@ func TestHandler_Delete_RequestNil(t *testing.T) {
 	w := httptest.NewRecorder()
 	handler.delete(w, nil)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected code %d but got %d", http.StatusBadRequest, w.Code)
-	}
-
-	actual := &Payload{}
-	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
-		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
-	}
-
-	testPayload(t, &Payload{Error: "request is empty"}, actual)
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
 }
 
 func TestHandler_Delete_NoID(t *testing.T) {
@@ -148,14 +258,5 @@ func TestHandler_Delete_NoID(t *testing.T) {
 
 	handler.delete(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected code %d but got %d", http.StatusBadRequest, w.Code)
-	}
-
-	actual := &Payload{}
-	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
-		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
-	}
-
-	testPayload(t, &Payload{Error: "request is empty"}, actual)
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errNoID), w)
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorymapper"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
+)
+
+func TestHandler_ETag(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointETag")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := Init(svc, db); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	mapper := repositorymapper.New(db)
+	if _, err := mapper.Save(context.Background(), &repositorymodel.Repository{
+		Name: "repo", URL: "https://host/repo.git",
+	}); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	serve := func(method, path, body, ifMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ifMatch != "" {
+			req.Header.Set(etag.HeaderKeyIfMatch, ifMatch)
+		}
+
+		w := httptest.NewRecorder()
+		svc.Router.ServeHTTP(w, req)
+
+		return w
+	}
+
+	// 2. test
+	w := serve(http.MethodGet, "/repository/1", "", "")
+
+	tag := w.Header().Get(etag.HeaderKeyETag)
+	if w.Code != http.StatusOK || tag == "" {
+		t.Fatalf("expected entity tag on get but got code %d and tag '%s'", w.Code, tag)
+	}
+
+	w = serve(http.MethodPatch, "/repository/1", `{"name": "changed"}`, tag)
+	if w.Code != http.StatusOK || w.Header().Get(etag.HeaderKeyETag) == tag {
+		t.Fatalf("expected patch to change entity tag but got code %d and tag '%s'", w.Code, tag)
+	}
+
+	for _, method := range []string{http.MethodPatch, http.MethodDelete} {
+		w = serve(method, "/repository/1", `{"name": "outdated"}`, tag)
+		if w.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected code %d on %s with outdated entity tag but got %d", http.StatusPreconditionFailed,
+				method, w.Code)
+		}
+	}
+
+	w = serve(http.MethodPut, "/repository", `{"id": 1, "name": "outdated", "url": "https://host/repo.git"}`, tag)
+	test.CheckProblem(t, problem.New(
+		http.StatusPreconditionFailed,
+		"precondition failed for repository with id 1: repository was modified or doesn't exist",
+	), w)
+
+	w = serve(http.MethodPut, "/repository", `{"name": "new", "url": "https://host/repo.git"}`, "*")
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected code %d on creation with precondition but got %d", http.StatusPreconditionFailed, w.Code)
+	}
+
+	w = serve(http.MethodDelete, "/repository/1", "", "*")
+	if w.Code != http.StatusOK {
+		t.Errorf("expected code %d on delete with any entity tag but got %d", http.StatusOK, w.Code)
+	}
+}
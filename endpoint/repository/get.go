@@ -2,10 +2,11 @@ package repository
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
 	"github.com/rebel-l/branma_be/repository/repositorymapper"
 	"github.com/rebel-l/smis"
 
@@ -19,8 +20,7 @@ func (h *Handler) get(writer http.ResponseWriter, request *http.Request) {
 
 	// 0. validate request
 	if request == nil {
-		payload.Error = errRequestEmpty
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
 
 		return
 	}
@@ -29,16 +29,14 @@ func (h *Handler) get(writer http.ResponseWriter, request *http.Request) {
 
 	idRaw, ok := mux.Vars(request)["id"]
 	if !ok {
-		payload.Error = errNoID
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errNoID)
 
 		return
 	}
 
 	id, err := strconv.Atoi(idRaw)
 	if err != nil {
-		payload.Error = "converting id to integer failed"
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "converting id to integer failed")
 
 		return
 	}
@@ -46,20 +44,22 @@ func (h *Handler) get(writer http.ResponseWriter, request *http.Request) {
 	// 1. load model
 	model, err := h.mapper.Load(request.Context(), id)
 	if errors.Is(err, repositorymapper.ErrNotFound) {
-		payload.Error = fmt.Sprintf("repository with id %d not found", id)
-		response.WriteJSON(writer, http.StatusNotFound, payload)
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "repository with id %d not found", id)
 
 		return
 	} else if err != nil {
 		response.Log.Error(err)
 
-		payload.Error = fmt.Sprintf("failed to load repository for id: %d", id)
-		response.WriteJSON(writer, http.StatusInternalServerError, payload)
+		problem.Writef(
+			writer, request, response.Log, http.StatusInternalServerError,
+			"failed to load repository for id: %d", id,
+		)
 
 		return
 	}
 
 	// 2. send response
+	writer.Header().Set(etag.HeaderKeyETag, repositorymapper.ETag(model))
 	payload.Repository = model
 	response.WriteJSON(writer, http.StatusOK, payload)
 }
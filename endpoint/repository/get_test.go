@@ -9,8 +9,10 @@ import (
 
 	"github.com/rebel-l/smis"
 
+	"github.com/rebel-l/branma_be/problem"
 	"github.com/rebel-l/branma_be/repository/repositorymapper"
 	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
 )
 
 type tcGet struct {
@@ -18,6 +20,7 @@ type tcGet struct {
 	request         *http.Request
 	expectedCode    int
 	expectedPayload *Payload
+	expectedProblem *problem.Problem
 }
 
 func getTestCasesGet(t *testing.T) []tcGet { // nolint: funlen
@@ -39,7 +42,7 @@ func getTestCasesGet(t *testing.T) []tcGet { // nolint: funlen
 			Repository: &repositorymodel.Repository{
 				ID:   1,
 				Name: "repo",
-				URL:  "url",
+				URL:  "https://host/repo.git",
 			},
 		},
 	}
@@ -56,7 +59,7 @@ func getTestCasesGet(t *testing.T) []tcGet { // nolint: funlen
 		name:            "repository not found",
 		request:         req,
 		expectedCode:    http.StatusNotFound,
-		expectedPayload: &Payload{Error: "failed to load repository for id: 3"},
+		expectedProblem: problem.New(http.StatusNotFound, "repository with id 3 not found"),
 	}
 
 	testCases = append(testCases, c)
@@ -71,7 +74,7 @@ func getTestCasesGet(t *testing.T) []tcGet { // nolint: funlen
 		name:            "id not integer",
 		request:         req,
 		expectedCode:    http.StatusBadRequest,
-		expectedPayload: &Payload{Error: "converting id to integer failed"},
+		expectedProblem: problem.New(http.StatusBadRequest, "converting id to integer failed"),
 	}
 
 	testCases = append(testCases, c)
@@ -99,7 +102,9 @@ func TestHandler_Get(t *testing.T) {
 
 	// 2. prepare test data
 	mapper := repositorymapper.New(db)
-	if _, err := mapper.Save(context.Background(), &repositorymodel.Repository{Name: "repo", URL: "url"}); err != nil {
+	if _, err := mapper.Save(context.Background(), &repositorymodel.Repository{
+		Name: "repo", URL: "https://host/repo.git",
+	}); err != nil {
 		t.Fatalf("failed to prepare test data: %v", err)
 	}
 
@@ -109,6 +114,11 @@ func TestHandler_Get(t *testing.T) {
 			w := httptest.NewRecorder()
 			svc.Router.ServeHTTP(w, testCase.request)
 
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
 			if testCase.expectedCode != w.Code {
 				t.Errorf("expected code %d but got %d", testCase.expectedCode, w.Code)
 			}
@@ -133,16 +143,7 @@ func TestHandler_Get_RequestNil(t *testing.T) {
 	w := httptest.NewRecorder()
 	handler.get(w, nil)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected code %d but got %d", http.StatusBadRequest, w.Code)
-	}
-
-	actual := &Payload{}
-	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
-		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
-	}
-
-	testPayload(t, &Payload{Error: "request is empty"}, actual)
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
 }
 
 func TestHandler_Get_NoID(t *testing.T) {
@@ -157,14 +158,5 @@ func TestHandler_Get_NoID(t *testing.T) {
 
 	handler.get(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected code %d but got %d", http.StatusBadRequest, w.Code)
-	}
-
-	actual := &Payload{}
-	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
-		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
-	}
-
-	testPayload(t, &Payload{Error: "request is empty"}, actual)
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errNoID), w)
 }
@@ -43,10 +43,25 @@ func Init(svc *smis.Service, db *sqlx.DB) error {
 		return fmt.Errorf("failed to init put endpoint for repository: %w", err)
 	}
 
+	_, err = svc.RegisterEndpoint("/repository/{id}", http.MethodPatch, endpoint.patch)
+	if err != nil {
+		return fmt.Errorf("failed to init patch endpoint for repository: %w", err)
+	}
+
 	_, err = svc.RegisterEndpoint("/repository/{id}", http.MethodDelete, endpoint.delete)
 	if err != nil {
 		return fmt.Errorf("failed to init delete endpoint for repository: %w", err)
 	}
 
+	_, err = svc.RegisterEndpoint("/repositories/import", http.MethodPost, endpoint.importList)
+	if err != nil {
+		return fmt.Errorf("failed to init import endpoint for repository: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/repositories/export", http.MethodGet, endpoint.exportList)
+	if err != nil {
+		return fmt.Errorf("failed to init export endpoint for repository: %w", err)
+	}
+
 	return err
 }
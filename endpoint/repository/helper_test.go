@@ -71,10 +71,6 @@ func testPayload(t *testing.T, expected, actual *Payload) {
 		return
 	}
 
-	if expected.Error != actual.Error {
-		t.Errorf("expectedd error '%v' but got '%v'", expected.Error, actual.Error)
-	}
-
 	if expected.Repository.ID != actual.Repository.ID {
 		t.Errorf("expected ID %d but got %d", expected.Repository.ID, actual.Repository.ID)
 	}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorymapper"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestHandler_Patch(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointPatch")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := Init(svc, db); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	mapper := repositorymapper.New(db)
+	for _, repo := range []*repositorymodel.Repository{
+		{Name: "repo", URL: "https://host/repo.git"},
+		{Name: "other", URL: "https://host/repo.git"},
+	} {
+		if _, err := mapper.Save(context.Background(), repo); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		path            string
+		body            string
+		expectedCode    int
+		expectedPayload *Payload
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:            "success",
+			path:            "/repository/1",
+			body:            `{"url": "git@host:changed.git"}`,
+			expectedCode:    http.StatusOK,
+			expectedPayload: NewPayload(&repositorymodel.Repository{ID: 1, Name: "repo", URL: "git@host:changed.git"}),
+		},
+		{
+			name:         "invalid",
+			path:         "/repository/1",
+			body:         `{"url": null}`,
+			expectedCode: http.StatusUnprocessableEntity,
+			expectedProblem: &problem.Problem{
+				Type:   problem.TypeDefault,
+				Title:  http.StatusText(http.StatusUnprocessableEntity),
+				Status: http.StatusUnprocessableEntity,
+				Detail: "failed to patch repository: validation failed: url is required",
+				Errors: []*validation.FieldError{{Field: "url", Code: validation.CodeRequired, Message: "is required"}},
+			},
+		},
+		{
+			name:         "duplicate",
+			path:         "/repository/2",
+			body:         `{"name": "repo", "url": "git@host:changed.git"}`,
+			expectedCode: http.StatusConflict,
+			expectedProblem: &problem.Problem{
+				Type:       problem.TypeDefault,
+				Title:      http.StatusText(http.StatusConflict),
+				Status:     http.StatusConflict,
+				Detail:     "failed to patch repository: repository with same url and name already exists: id 1",
+				ExistingID: 1,
+			},
+		},
+		{
+			name:            "repository not found",
+			path:            "/repository/3",
+			body:            `{}`,
+			expectedCode:    http.StatusNotFound,
+			expectedProblem: problem.New(http.StatusNotFound, "repository with id 3 not found"),
+		},
+		{
+			name:            "id not integer",
+			path:            "/repository/abc",
+			body:            `{}`,
+			expectedCode:    http.StatusBadRequest,
+			expectedProblem: problem.New(http.StatusBadRequest, "converting id to integer failed"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPatch, testCase.path, strings.NewReader(testCase.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
+			if testCase.expectedCode != w.Code {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, w.Code)
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v", err)
+			}
+
+			testPayload(t, testCase.expectedPayload, actual)
+		})
+	}
+}
+
+func TestHandler_Patch_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.patch(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/rebel-l/smis"
 
+	"github.com/rebel-l/branma_be/etag"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorymapper"
 	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/validation"
 )
 
 // put creates or updates the repository
@@ -16,8 +21,7 @@ func (h *Handler) put(writer http.ResponseWriter, request *http.Request) {
 
 	// 0. validate request
 	if request == nil {
-		payload.Error = errRequestEmpty
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
 
 		return
 	}
@@ -25,8 +29,7 @@ func (h *Handler) put(writer http.ResponseWriter, request *http.Request) {
 	response.Log = h.svc.NewLogForRequestID(request.Context())
 
 	if request.Body == nil {
-		payload.Error = fmt.Sprint("request body is empty")
-		response.WriteJSON(writer, http.StatusBadRequest, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, fmt.Sprint("request body is empty"))
 
 		return
 	}
@@ -34,8 +37,7 @@ func (h *Handler) put(writer http.ResponseWriter, request *http.Request) {
 	// 1. decode payload
 	model := &repositorymodel.Repository{}
 	if err := model.DecodeJSON(request.Body); err != nil {
-		payload.Error = err.Error()
-		response.WriteJSON(writer, http.StatusInternalServerError, payload)
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
 
 		return
 	}
@@ -45,16 +47,49 @@ func (h *Handler) put(writer http.ResponseWriter, request *http.Request) {
 		code = http.StatusCreated
 	}
 
-	// 2. save model
-	model, err := h.mapper.Save(request.Context(), model)
-	if err != nil {
-		payload.Error = fmt.Sprintf("failed to save repository: %v", err)
-		response.WriteJSON(writer, http.StatusInternalServerError, payload)
+	// 2. save model if the precondition is met
+	id := model.ID
+	model, err := h.mapper.SaveIfMatch(request.Context(), model, request.Header.Get(etag.HeaderKeyIfMatch))
+
+	var (
+		duplicate *repositorymapper.DuplicateError
+		invalid   *validation.Error
+	)
+
+	switch {
+	case errors.Is(err, repositorymapper.ErrPreconditionFailed):
+		problem.Writef(
+			writer, request, response.Log, http.StatusPreconditionFailed,
+			"precondition failed for repository with id %d: %v", id, err,
+		)
+
+		return
+	case errors.Is(err, repositorymapper.ErrNotFound):
+		problem.Writef(writer, request, response.Log, http.StatusNotFound, "repository with id %d not found", id)
+
+		return
+	case errors.As(err, &invalid):
+		p := problem.New(http.StatusUnprocessableEntity, fmt.Sprintf("failed to save repository: %v", err))
+		p.Errors = invalid.Errors
+		problem.WriteProblem(writer, request, response.Log, p)
+
+		return
+	case errors.As(err, &duplicate):
+		p := problem.New(http.StatusConflict, fmt.Sprintf("failed to save repository: %v", err))
+		p.ExistingID = duplicate.ID
+		problem.WriteProblem(writer, request, response.Log, p)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to save repository")
 
 		return
 	}
 
 	// 3. send response
+	writer.Header().Set(etag.HeaderKeyETag, repositorymapper.ETag(model))
 	payload.Repository = model
 	response.WriteJSON(writer, code, payload)
 }
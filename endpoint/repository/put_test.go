@@ -9,7 +9,10 @@ import (
 
 	"github.com/rebel-l/smis"
 
+	"github.com/rebel-l/branma_be/problem"
 	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/validation"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -18,6 +21,7 @@ type tcPut struct {
 	name            string
 	request         *http.Request
 	expectedPayload *Payload
+	expectedProblem *problem.Problem
 	expectedStatus  int
 }
 
@@ -31,7 +35,7 @@ func getTestCasesPut(t *testing.T) []tcPut { // nolint:funlen
 		name:            "request nil",
 		request:         nil,
 		expectedStatus:  http.StatusBadRequest,
-		expectedPayload: &Payload{Error: "request is empty"},
+		expectedProblem: problem.New(http.StatusBadRequest, "request is empty"),
 	}
 	testCases = append(testCases, c)
 
@@ -45,14 +49,14 @@ func getTestCasesPut(t *testing.T) []tcPut { // nolint:funlen
 		name:            "request body nil",
 		request:         req,
 		expectedStatus:  http.StatusBadRequest,
-		expectedPayload: &Payload{Error: "request body is empty"},
+		expectedProblem: problem.New(http.StatusBadRequest, "request body is empty"),
 	}
 	testCases = append(testCases, c)
 
 	// 3.
 	body := `{
 		"name": "new",
-		"url": "new url"
+		"url": "https://host/new.git"
 	}`
 
 	req, err = http.NewRequest(http.MethodPut, "/repository", strings.NewReader(body))
@@ -66,7 +70,7 @@ func getTestCasesPut(t *testing.T) []tcPut { // nolint:funlen
 		expectedPayload: NewPayload(&repositorymodel.Repository{
 			ID:   1,
 			Name: "new",
-			URL:  "new url",
+			URL:  "https://host/new.git",
 		}),
 		expectedStatus: http.StatusCreated,
 	}
@@ -76,7 +80,7 @@ func getTestCasesPut(t *testing.T) []tcPut { // nolint:funlen
 	body = `{
 		"id": 1,
 		"name": "changed",
-		"url": "changed url"
+		"url": "git@host:changed.git"
 	}`
 
 	req, err = http.NewRequest(http.MethodPut, "/repository", strings.NewReader(body))
@@ -90,12 +94,107 @@ func getTestCasesPut(t *testing.T) []tcPut { // nolint:funlen
 		expectedPayload: NewPayload(&repositorymodel.Repository{
 			ID:   1,
 			Name: "changed",
-			URL:  "changed url",
+			URL:  "git@host:changed.git",
 		}),
 		expectedStatus: http.StatusOK,
 	}
 	testCases = append(testCases, c)
 
+	// 5.
+	body = `{
+		"name": "changed",
+		"url": "git@host:changed.git"
+	}`
+
+	req, err = http.NewRequest(http.MethodPut, "/repository", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	duplicate := problem.New(
+		http.StatusConflict,
+		"failed to save repository: repository with same url and name already exists: id 1",
+	)
+	duplicate.ExistingID = 1
+
+	c = tcPut{
+		name:            "duplicate repository",
+		request:         req,
+		expectedProblem: duplicate,
+		expectedStatus:  http.StatusConflict,
+	}
+	testCases = append(testCases, c)
+
+	// 6.
+	body = `{
+		"name": "` + strings.Repeat("a", 101) + `",
+		"url": "no url"
+	}`
+
+	req, err = http.NewRequest(http.MethodPut, "/repository", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invalid := problem.New(
+		http.StatusUnprocessableEntity,
+		"failed to save repository: validation failed: name must not be longer than 100 characters, "+
+			"url must be a git url like https://host/repo.git, git@host:repo.git or /path/to/repo",
+	)
+	invalid.Errors = []*validation.FieldError{
+		{Field: "name", Code: validation.CodeTooLong, Message: "must not be longer than 100 characters"},
+		{
+			Field:   "url",
+			Code:    validation.CodeInvalidFormat,
+			Message: "must be a git url like https://host/repo.git, git@host:repo.git or /path/to/repo",
+		},
+	}
+
+	c = tcPut{
+		name:            "invalid repository",
+		request:         req,
+		expectedProblem: invalid,
+		expectedStatus:  http.StatusUnprocessableEntity,
+	}
+	testCases = append(testCases, c)
+
+	// 7.
+	req, err = http.NewRequest(http.MethodPut, "/repository", strings.NewReader("no JSON"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcPut{
+		name:    "no JSON format",
+		request: req,
+		expectedProblem: problem.New(
+			http.StatusBadRequest,
+			"failed to decode JSON: invalid character 'o' in literal null (expecting 'u')",
+		),
+		expectedStatus: http.StatusBadRequest,
+	}
+	testCases = append(testCases, c)
+
+	// 8.
+	body = `{
+		"id": 99,
+		"name": "missing",
+		"url": "https://host/missing.git"
+	}`
+
+	req, err = http.NewRequest(http.MethodPut, "/repository", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c = tcPut{
+		name:            "update not existing repository",
+		request:         req,
+		expectedProblem: problem.New(http.StatusNotFound, "repository with id 99 not found"),
+		expectedStatus:  http.StatusNotFound,
+	}
+	testCases = append(testCases, c)
+
 	return testCases
 }
 
@@ -122,6 +221,11 @@ func Test_Put(t *testing.T) {
 			w := httptest.NewRecorder()
 			handler.ServeHTTP(w, testCase.request)
 
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
 			if testCase.expectedStatus != w.Code {
 				t.Errorf("expected code %d but got %d", testCase.expectedStatus, w.Code)
 			}
@@ -1,11 +1,14 @@
 package repository
 
-import "github.com/rebel-l/branma_be/repository/repositorymodel"
+import (
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+)
 
 // Payload represents response payload for endpoint
 type Payload struct {
 	Repository *repositorymodel.Repository `json:"repository,omitempty"`
-	Error      string                      `json:"error,omitempty"`
+	Import     *bulk.Result                `json:"import,omitempty"`
 }
 
 // NewPayload returns a new Payload struct
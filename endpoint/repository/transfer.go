@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+)
+
+// exportFileName is the name of the file of exports without extension
+const exportFileName = "repositories"
+
+// importList creates the repositories listed in JSON, CSV or YAML which don't exist yet, the result reports per row
+// what was done or why it failed
+func (h *Handler) importList(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	if request.Body == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "request body is empty")
+
+		return
+	}
+
+	format, err := bulk.NewFormat(request, smis.HeaderKeyContentType)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusUnsupportedMediaType, err.Error())
+
+		return
+	}
+
+	dryRun, err := bulk.DryRun(request)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// 1. decode rows
+	rows, err := bulk.Decode(format, request.Body)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	// 2. import rows
+	result, err := h.mapper.Import(request.Context(), rows, dryRun)
+	if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to import repositories")
+
+		return
+	}
+
+	// 3. send response
+	payload.Import = result
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
+
+// exportList returns all repositories in JSON, CSV or YAML, the result can be imported again
+func (h *Handler) exportList(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	format, err := bulk.NewFormat(request, bulk.HeaderKeyAccept)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusNotAcceptable, err.Error())
+
+		return
+	}
+
+	// 1. load models
+	models, err := h.mapper.List(request.Context())
+	if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to load repositories")
+
+		return
+	}
+
+	// 2. send response
+	list := make([]interface{}, 0, len(models))
+	for _, model := range models {
+		list = append(list, model)
+	}
+
+	if err = bulk.Write(writer, format, exportFileName, repositorymodel.Columns(), list); err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to export repositories")
+	}
+}
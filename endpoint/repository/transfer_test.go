@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorymapper"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestHandler_Import(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointImport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := Init(svc, db); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		query           string
+		contentType     string
+		body            string
+		expected        *bulk.Result
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:        "dry run",
+			query:       "?dry_run=true",
+			contentType: "text/csv",
+			body:        "name,url\nfirst,https://host/first.git\n,https://host/first.git\n",
+			expected: &bulk.Result{
+				DryRun:  true,
+				Created: 1,
+				Failed:  1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionCreated},
+					{
+						Row:    2,
+						Action: bulk.ActionFailed,
+						Detail: "validation failed: name is required",
+						Errors: []*validation.FieldError{{Field: "name", Code: validation.CodeRequired}},
+					},
+				},
+			},
+		},
+		{
+			name:  "import",
+			query: "?format=yaml",
+			body:  "- name: first\n  url: https://host/first.git\n- name: first\n  url: https://host/first.git\n",
+			expected: &bulk.Result{
+				Created:   1,
+				Unchanged: 1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionCreated, ID: 1},
+					{Row: 2, Action: bulk.ActionUnchanged, ID: 1},
+				},
+			},
+		},
+		{
+			name:        "unsupported format",
+			contentType: "text/html",
+			body:        "<html></html>",
+			expectedProblem: problem.New(
+				http.StatusUnsupportedMediaType,
+				"unknown format, use json, csv or yaml: Content-Type 'text/html'",
+			),
+		},
+		{
+			name:            "dry run not boolean",
+			query:           "?dry_run=maybe",
+			body:            "[]",
+			expectedProblem: problem.New(http.StatusBadRequest, "dry_run must be a boolean"),
+		},
+		{
+			name:            "no list",
+			body:            `{"name": "first"}`,
+			expectedProblem: problem.New(http.StatusBadRequest, "failed to decode rows: expected a list"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(
+				http.MethodPost, "/repositories/import"+testCase.query, strings.NewReader(testCase.body),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.contentType != "" {
+				req.Header.Set(smis.HeaderKeyContentType, testCase.contentType)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected code %d but got %d", http.StatusOK, w.Code)
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			test.CheckResult(t, testCase.expected, actual.Import)
+		})
+	}
+}
+
+func TestHandler_Export(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	svc, db := setup(t, "endpointExport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := Init(svc, db); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	expected := repositorymodel.Repositories{
+		{ID: 1, Name: "first", URL: "https://host/first.git"},
+		{ID: 2, Name: "second", URL: "git@host:second.git"},
+	}
+
+	mapper := repositorymapper.New(db)
+	for _, repo := range expected {
+		model := &repositorymodel.Repository{Name: repo.Name, URL: repo.URL}
+		if _, err := mapper.Save(context.Background(), model); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	// 2. test
+	testCases := []struct {
+		name            string
+		query           string
+		accept          string
+		expectedFormat  bulk.Format
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:           "default",
+			expectedFormat: bulk.FormatJSON,
+		},
+		{
+			name:           "csv",
+			query:          "?format=csv",
+			expectedFormat: bulk.FormatCSV,
+		},
+		{
+			name:           "yaml",
+			accept:         "application/x-yaml",
+			expectedFormat: bulk.FormatYAML,
+		},
+		{
+			name:   "not acceptable",
+			accept: "text/html",
+			expectedProblem: problem.New(
+				http.StatusNotAcceptable,
+				"unknown format, use json, csv or yaml: Accept 'text/html'",
+			),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "/repositories/export"+testCase.query, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Set(bulk.HeaderKeyAccept, testCase.accept)
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
+			if w.Code != http.StatusOK {
+				t.Errorf("expected code %d but got %d", http.StatusOK, w.Code)
+			}
+
+			if actual := w.Header().Get(smis.HeaderKeyContentType); actual != testCase.expectedFormat.ContentType() {
+				t.Errorf("expected content type '%s' but got '%s'", testCase.expectedFormat.ContentType(), actual)
+			}
+
+			rows, err := bulk.Decode(testCase.expectedFormat, bytes.NewReader(w.Body.Bytes()))
+			if err != nil {
+				t.Fatalf("failed to decode export: %v | %s", err, w.Body.Bytes())
+			}
+
+			if len(expected) != len(rows) {
+				t.Fatalf("expected %d repositories but got %d", len(expected), len(rows))
+			}
+
+			for i, row := range rows {
+				actual := &repositorymodel.Repository{}
+				if err := row.Decode(actual); err != nil {
+					t.Fatalf("failed to decode row %d: %v", row.Number, err)
+				}
+
+				e := expected[i]
+				if e.ID != actual.ID || e.Name != actual.Name || e.URL != actual.URL {
+					t.Errorf("expected repository %#v but got %#v", e, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_Transfer_RequestNil(t *testing.T) {
+	handler := Handler{}
+
+	for name, f := range map[string]http.HandlerFunc{"import": handler.importList, "export": handler.exportList} {
+		w := httptest.NewRecorder()
+		f(w, nil)
+
+		t.Run(name, func(t *testing.T) {
+			test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package ticket
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+const (
+	errRequestEmpty = "request is empty"
+)
+
+// Handler provides useful variables for the specific endpoint handlers
+type Handler struct {
+	svc    *smis.Service
+	syncer *ticketsync.Syncer
+	stats  jira.StatsProvider
+}
+
+// New returns a new handler, the stats are provided by the JIRA client used for the synchronisation
+func New(svc *smis.Service, syncer *ticketsync.Syncer, stats jira.StatsProvider) *Handler {
+	return &Handler{
+		svc:    svc,
+		syncer: syncer,
+		stats:  stats,
+	}
+}
+
+// Init initialises the endpoints for tickets. The syncer is shared with the scheduled synchronisation, so a
+// synchronisation triggered by the endpoint never runs in parallel to a scheduled one.
+func Init(svc *smis.Service, syncer *ticketsync.Syncer, stats jira.StatsProvider) error {
+	endpoint := New(svc, syncer, stats)
+
+	_, err := svc.RegisterEndpoint("/ticket/sync", http.MethodPost, endpoint.sync)
+	if err != nil {
+		return fmt.Errorf("failed to init sync endpoint for ticket: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/ticket/sync", http.MethodGet, endpoint.lastSync)
+	if err != nil {
+		return fmt.Errorf("failed to init last sync endpoint for ticket: %w", err)
+	}
+
+	_, err = svc.RegisterEndpoint("/ticket/jira/stats", http.MethodGet, endpoint.jiraStats)
+	if err != nil {
+		return fmt.Errorf("failed to init jira stats endpoint for ticket: %w", err)
+	}
+
+	return err
+}
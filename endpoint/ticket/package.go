@@ -0,0 +1,2 @@
+// Package ticket provides the endpoints to synchronise the tickets with JIRA.
+package ticket
@@ -0,0 +1,12 @@
+package ticket
+
+import (
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+// Payload represents response payload for endpoint
+type Payload struct {
+	Result *ticketsync.Result `json:"result,omitempty"`
+	Stats  *jira.Stats        `json:"stats,omitempty"`
+}
@@ -0,0 +1,37 @@
+package ticket
+
+import (
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/problem"
+)
+
+// jiraStats returns the number of requests sent to JIRA and the hit rate of the cache
+func (h *Handler) jiraStats(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	// 1. load stats
+	if h.stats == nil {
+		problem.Write(writer, request, response.Log, http.StatusNotFound, "jira is not configured")
+
+		return
+	}
+
+	stats := h.stats.Stats()
+
+	// 2. send response
+	payload.Stats = &stats
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,89 @@
+package ticket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/test"
+)
+
+func TestHandler_JiraStats(t *testing.T) {
+	// 1. setup
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := jirafake.NewServer()
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1"})
+
+	client := jira.New(&config.Jira{BaseURL: &server.URL}).WithCache(time.Hour)
+	if err = Init(svc, nil, client); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err = client.Issue(context.Background(), "JIRA-1"); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	// 2. test
+	req, err := http.NewRequest(http.MethodGet, "/ticket/jira/stats", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	svc.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected code %d but got %d", http.StatusOK, w.Code)
+	}
+
+	actual := &Payload{}
+	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+		t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+	}
+
+	if actual.Stats == nil || actual.Stats.Requests != 1 || actual.Stats.CacheHitRate != 0.5 {
+		t.Errorf("expected 1 request and a cache hit rate of 0.5 but got %#v | %s", actual.Stats, w.Body.Bytes())
+	}
+}
+
+func TestHandler_JiraStats_NotConfigured(t *testing.T) {
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler := New(svc, nil, nil)
+	w := httptest.NewRecorder()
+	handler.jiraStats(w, httptest.NewRequest(http.MethodGet, "/ticket/jira/stats", nil))
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected code %d but got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHandler_JiraStats_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.jiraStats(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
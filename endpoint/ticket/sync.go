@@ -0,0 +1,87 @@
+package ticket
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+// sync synchronises the tickets of all branches with JIRA
+func (h *Handler) sync(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	// 1. synchronise
+	res, err := h.syncer.Sync(request.Context())
+
+	switch {
+	case errors.Is(err, ticketsync.ErrRunning):
+		problem.Write(writer, request, response.Log, http.StatusConflict, err.Error())
+
+		return
+	case errors.Is(err, jira.ErrAuthentication):
+		response.Log.Error(err)
+
+		problem.Write(
+			writer, request, response.Log, http.StatusBadGateway,
+			"failed to synchronise tickets: "+err.Error(),
+		)
+
+		return
+	case err != nil:
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to synchronise tickets")
+
+		return
+	}
+
+	for ticketID, msg := range res.Errors {
+		response.Log.Warnf("failed to synchronise ticket %s: %s", ticketID, msg)
+	}
+
+	// 2. send response
+	payload.Result = res
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
+
+// lastSync returns the result of the last synchronisation of the tickets
+func (h *Handler) lastSync(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	// 1. load result
+	res := h.syncer.Last()
+	if res == nil {
+		problem.Write(writer, request, response.Log, http.StatusNotFound, "tickets were not synchronised yet")
+
+		return
+	}
+
+	// 2. send response
+	payload.Result = res
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
@@ -0,0 +1,145 @@
+package ticket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+const (
+	testCluster = "test_endpoint_ticket"
+)
+
+func TestHandler_Sync(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "endpointSync")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	branch := &branchstore.Branch{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: repo.ID}
+	if err = branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	server := jirafake.NewServer()
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", Summary: "summary", Status: "Done", Type: "Story"})
+
+	syncer := ticketsync.New(db, jira.New(&config.Jira{BaseURL: &server.URL}))
+	if err = Init(svc, syncer, nil); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	testCases := []struct {
+		name     string
+		method   string
+		expected int
+	}{
+		{
+			name:     "no synchronisation yet",
+			method:   http.MethodGet,
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "synchronise",
+			method:   http.MethodPost,
+			expected: http.StatusOK,
+		},
+		{
+			name:     "last synchronisation",
+			method:   http.MethodGet,
+			expected: http.StatusOK,
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		req, err := http.NewRequest(testCase.method, "/ticket/sync", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		svc.Router.ServeHTTP(w, req)
+
+		if w.Code != testCase.expected {
+			t.Errorf("%s: expected code %d but got %d", testCase.name, testCase.expected, w.Code)
+		}
+
+		if testCase.expected != http.StatusOK {
+			continue
+		}
+
+		actual := &Payload{}
+		if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+			t.Fatalf("%s: failed to decode json: %v | %s", testCase.name, err, w.Body.Bytes())
+		}
+
+		if actual.Result == nil || actual.Result.Tickets != 1 || actual.Result.TicketsUpdated != 1 {
+			t.Errorf("%s: unexpected result %#v | %s", testCase.name, actual.Result, w.Body.Bytes())
+		}
+	}
+
+	// 3. authentication failure
+	server.SetCredentials("branma", "secret")
+
+	req, err := http.NewRequest(http.MethodPost, "/ticket/sync", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	svc.Router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadGateway {
+		t.Errorf("expected code %d but got %d", http.StatusBadGateway, w.Code)
+	}
+}
+
+func TestHandler_Sync_RequestNil(t *testing.T) {
+	handler := Handler{}
+
+	for name, f := range map[string]http.HandlerFunc{"sync": handler.sync, "lastSync": handler.lastSync} {
+		w := httptest.NewRecorder()
+		f(w, nil)
+
+		t.Run(name, func(t *testing.T) {
+			test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+const (
+	errRequestEmpty = "request is empty"
+
+	// HeaderKeySecret is the header the shared secret is sent with
+	HeaderKeySecret = "X-Webhook-Secret"
+
+	// QueryKeySecret is the query parameter the shared secret can be sent with if enabled by the configuration
+	QueryKeySecret = "secret"
+)
+
+// Handler provides useful variables for the specific endpoint handlers
+type Handler struct {
+	svc           *smis.Service
+	syncer        *ticketsync.Syncer
+	secret        string
+	secretInQuery bool
+}
+
+// New returns a new handler
+func New(svc *smis.Service, syncer *ticketsync.Syncer, cfg *config.Jira) *Handler {
+	return &Handler{
+		svc:           svc,
+		syncer:        syncer,
+		secret:        cfg.GetWebhookSecret(),
+		secretInQuery: cfg.GetWebhookSecretInQuery(),
+	}
+}
+
+// Init initialises the webhook endpoints
+func Init(svc *smis.Service, syncer *ticketsync.Syncer, cfg *config.Jira) error {
+	endpoint := New(svc, syncer, cfg)
+
+	_, err := svc.RegisterEndpoint("/webhook/jira", http.MethodPost, endpoint.jira)
+	if err != nil {
+		return fmt.Errorf("failed to init jira endpoint for webhook: %w", err)
+	}
+
+	return err
+}
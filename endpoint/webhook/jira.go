@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+)
+
+// jira updates the ticket of an issue event sent by JIRA, events of unknown tickets or other types are ignored
+func (h *Handler) jira(writer http.ResponseWriter, request *http.Request) {
+	response := smis.Response{}
+	payload := &Payload{}
+
+	// 0. validate request
+	if request == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, errRequestEmpty)
+
+		return
+	}
+
+	response.Log = h.svc.NewLogForRequestID(request.Context())
+
+	if h.secret == "" {
+		problem.Write(
+			writer, request, response.Log, http.StatusForbidden,
+			"webhook is disabled as no secret is configured",
+		)
+
+		return
+	}
+
+	if !h.authorised(request) {
+		problem.Write(writer, request, response.Log, http.StatusUnauthorized, "secret is missing or wrong")
+
+		return
+	}
+
+	if request.Body == nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, "request body is empty")
+
+		return
+	}
+
+	// 1. decode event
+	event, err := jira.DecodeWebhook(request.Body)
+	if err != nil {
+		problem.Write(writer, request, response.Log, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	payload.Event = event.Type
+	if event.Issue != nil {
+		payload.Ticket = event.Issue.Key
+	}
+
+	// 2. apply event
+	applied, err := h.syncer.Apply(request.Context(), event)
+	if err != nil {
+		response.Log.Error(err)
+
+		problem.Write(writer, request, response.Log, http.StatusInternalServerError, "failed to update ticket")
+
+		return
+	}
+
+	// 3. send response
+	payload.Ignored = !applied
+	response.WriteJSON(writer, http.StatusOK, payload)
+}
+
+// authorised returns true if the request contains the shared secret in the header. The query parameter is only
+// accepted if enabled, as urls are written to access logs.
+func (h *Handler) authorised(request *http.Request) bool {
+	secret := request.Header.Get(HeaderKeySecret)
+	if secret == "" && h.secretInQuery {
+		secret = request.URL.Query().Get(QueryKeySecret)
+	}
+
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(h.secret)) == 1
+}
@@ -0,0 +1,213 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rebel-l/smis"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/problem"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+const (
+	testCluster = "test_endpoint_webhook"
+)
+
+func TestHandler_Jira(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "endpointJira")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	svc, err := smis.NewService(&http.Server{}, mux.NewRouter(), logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = (&ticketstore.Ticket{Key: "JIRA-1", Summary: "old"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	secret := "let me in"
+	syncer := ticketsync.New(db, jira.New(&config.Jira{}))
+
+	if err = Init(svc, syncer, &config.Jira{WebhookSecret: &secret}); err != nil {
+		t.Fatalf("failed to init routes: %v", err)
+	}
+
+	updated := `{
+		"webhookEvent": "jira:issue_updated",
+		"issue": {"key": "JIRA-1", "fields": {"summary": "new", "status": {"name": "Done"}, "issuetype": {"name": "Bug"}}}
+	}`
+
+	testCases := []struct {
+		name            string
+		query           string
+		header          string
+		body            string
+		expectedCode    int
+		expectedPayload *Payload
+		expectedProblem *problem.Problem
+	}{
+		{
+			name:            "secret missing",
+			body:            updated,
+			expectedCode:    http.StatusUnauthorized,
+			expectedProblem: problem.New(http.StatusUnauthorized, "secret is missing or wrong"),
+		},
+		{
+			name:            "secret wrong",
+			header:          "wrong",
+			body:            updated,
+			expectedCode:    http.StatusUnauthorized,
+			expectedProblem: problem.New(http.StatusUnauthorized, "secret is missing or wrong"),
+		},
+		{
+			name:            "secret in query disabled",
+			query:           "?secret=let+me+in",
+			body:            updated,
+			expectedCode:    http.StatusUnauthorized,
+			expectedProblem: problem.New(http.StatusUnauthorized, "secret is missing or wrong"),
+		},
+		{
+			name:         "malformed payload",
+			header:       secret,
+			body:         "no JSON",
+			expectedCode: http.StatusBadRequest,
+			expectedProblem: problem.New(
+				http.StatusBadRequest,
+				"invalid jira webhook payload: invalid character 'o' in literal null (expecting 'u')",
+			),
+		},
+		{
+			name:            "unknown ticket",
+			header:          secret,
+			body:            `{"webhookEvent": "jira:issue_updated", "issue": {"key": "JIRA-2", "fields": {}}}`,
+			expectedCode:    http.StatusOK,
+			expectedPayload: &Payload{Event: jira.EventIssueUpdated, Ticket: "JIRA-2", Ignored: true},
+		},
+		{
+			name:            "ticket updated",
+			header:          secret,
+			body:            updated,
+			expectedCode:    http.StatusOK,
+			expectedPayload: &Payload{Event: jira.EventIssueUpdated, Ticket: "JIRA-1"},
+		},
+	}
+
+	// 2. test
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/webhook/jira"+testCase.query, strings.NewReader(testCase.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if testCase.header != "" {
+				req.Header.Set(HeaderKeySecret, testCase.header)
+			}
+
+			w := httptest.NewRecorder()
+			svc.Router.ServeHTTP(w, req)
+
+			if testCase.expectedProblem != nil {
+				test.CheckProblem(t, testCase.expectedProblem, w)
+				return
+			}
+
+			if w.Code != testCase.expectedCode {
+				t.Errorf("expected code %d but got %d", testCase.expectedCode, w.Code)
+			}
+
+			actual := &Payload{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if *testCase.expectedPayload != *actual {
+				t.Errorf("expected payload %#v but got %#v", testCase.expectedPayload, actual)
+			}
+		})
+	}
+
+	ticket := &ticketstore.Ticket{Key: "JIRA-1"}
+	if err = ticket.ReadByKey(context.Background(), db); err != nil {
+		t.Fatalf("failed to load ticket: %v", err)
+	}
+
+	if ticket.Summary != "new" || ticket.Status != "Done" || ticket.Type != "Bug" {
+		t.Errorf("expected ticket to be updated but got %#v", ticket)
+	}
+}
+
+func TestHandler_Jira_SecretInQuery(t *testing.T) {
+	secret := "let me in"
+	inQuery := true
+	handler := New(
+		&smis.Service{Log: logrus.New()},
+		nil,
+		&config.Jira{WebhookSecret: &secret, WebhookSecretInQuery: &inQuery},
+	)
+
+	for query, expected := range map[string]int{
+		"?secret=let+me+in": http.StatusBadRequest,
+		"?secret=wrong":     http.StatusUnauthorized,
+	} {
+		req, err := http.NewRequest(http.MethodPost, "/webhook/jira"+query, strings.NewReader("no JSON"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		w := httptest.NewRecorder()
+		handler.jira(w, req)
+
+		if w.Code != expected {
+			t.Errorf("expected code %d for query '%s' but got %d", expected, query, w.Code)
+		}
+	}
+}
+
+func TestHandler_Jira_Disabled(t *testing.T) {
+	handler := New(&smis.Service{Log: logrus.New()}, nil, &config.Jira{})
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodPost, "/webhook/jira", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler.jira(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected code %d but got %d", http.StatusForbidden, w.Code)
+	}
+}
+
+func TestHandler_Jira_RequestNil(t *testing.T) {
+	handler := Handler{}
+	w := httptest.NewRecorder()
+	handler.jira(w, nil)
+
+	test.CheckProblem(t, problem.New(http.StatusBadRequest, errRequestEmpty), w)
+}
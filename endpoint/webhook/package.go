@@ -0,0 +1,2 @@
+// Package webhook provides the endpoints receiving events from external systems like JIRA.
+package webhook
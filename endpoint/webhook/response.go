@@ -0,0 +1,8 @@
+package webhook
+
+// Payload represents response payload for endpoint
+type Payload struct {
+	Event   string `json:"event,omitempty"`
+	Ticket  string `json:"ticket,omitempty"`
+	Ignored bool   `json:"ignored,omitempty"`
+}
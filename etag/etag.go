@@ -0,0 +1,40 @@
+package etag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderKeyETag is the header containing the entity tag of the resource in the response
+	HeaderKeyETag = "ETag"
+
+	// HeaderKeyIfMatch is the header containing the entity tags the client expects the resource to have
+	HeaderKeyIfMatch = "If-Match"
+
+	// Any matches every existing resource in the If-Match header
+	Any = "*"
+)
+
+// New returns the strong entity tag of a resource modified at the given time
+func New(modifiedAt time.Time) string {
+	return fmt.Sprintf(`"%x"`, modifiedAt.UnixNano())
+}
+
+// Match returns true if the value of the If-Match header contains the entity tag or is Any. Weak entity tags never
+// match, as If-Match uses the strong comparison. An empty entity tag means the resource doesn't exist.
+func Match(ifMatch, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == Any || candidate == tag {
+			return true
+		}
+	}
+
+	return false
+}
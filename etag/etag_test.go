@@ -0,0 +1,51 @@
+package etag_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/etag"
+)
+
+func TestNew(t *testing.T) {
+	modifiedAt := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tag := etag.New(modifiedAt)
+	if tag != `"15f82c5210b58000"` {
+		t.Errorf("expected quoted hexadecimal time but got %s", tag)
+	}
+
+	if etag.New(modifiedAt.Add(time.Second)) == tag {
+		t.Error("expected entity tag to change with the modification time")
+	}
+
+	if etag.New(modifiedAt.In(time.FixedZone("CET", 3600))) != tag {
+		t.Error("expected entity tag to be independent of the time zone")
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tag := `"abc"`
+
+	testCases := []struct {
+		name     string
+		ifMatch  string
+		tag      string
+		expected bool
+	}{
+		{name: "equal", ifMatch: `"abc"`, tag: tag, expected: true},
+		{name: "different", ifMatch: `"abd"`, tag: tag},
+		{name: "list", ifMatch: `"xyz", "abc"`, tag: tag, expected: true},
+		{name: "any", ifMatch: `*`, tag: tag, expected: true},
+		{name: "weak", ifMatch: `W/"abc"`, tag: tag},
+		{name: "resource missing", ifMatch: `*`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := etag.Match(testCase.ifMatch, testCase.tag); actual != testCase.expected {
+				t.Errorf("expected %t but got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+// Package etag provides the entity tags of resources and the evaluation of the If-Match header (RFC 7232)
+package etag
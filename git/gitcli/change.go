@@ -0,0 +1,176 @@
+package gitcli
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	diffOldFile = "--- "
+	diffNewFile = "+++ "
+	diffNoFile  = "/dev/null"
+	diffHunk    = "@@ "
+)
+
+var hunkPattern = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// LineRange represents consecutive lines of a file, starting and ending with the given line numbers
+type LineRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Overlaps returns true if both ranges share at least one line
+func (l LineRange) Overlaps(other LineRange) bool {
+	return l.Start <= other.End && other.Start <= l.End
+}
+
+// FileChange represents the changes of a file. The lines refer to the file before the change, so changes of different
+// heads against the same base can be compared. Added lines are represented by the line they were inserted after.
+type FileChange struct {
+	Path  string
+	Lines []LineRange
+}
+
+// Overlaps returns true if both changes touch at least one common line
+func (f *FileChange) Overlaps(other *FileChange) bool {
+	for _, l := range f.Lines {
+		for _, o := range other.Lines {
+			if l.Overlaps(o) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hunk represents a part of a diff, the lines starting at oldStart are replaced by the lines starting at newStart
+type hunk struct {
+	oldStart int
+	oldCount int
+	newStart int
+	newCount int
+}
+
+// lines returns the range of lines before the change, pure insertions are located after the start line
+func (h hunk) lines() LineRange {
+	if h.oldCount == 0 {
+		return LineRange{Start: h.oldStart, End: h.oldStart}
+	}
+
+	return LineRange{Start: h.oldStart, End: h.oldStart + h.oldCount - 1}
+}
+
+// onto maps the line of the file before the hunks onto the file after them. Lines replaced by a hunk are mapped to the
+// first or last line replacing them.
+func onto(line int, hunks []hunk, last bool) int {
+	offset := 0
+
+	for _, h := range hunks {
+		replaced := h.lines()
+
+		switch {
+		case h.oldCount > 0 && line >= replaced.Start && line <= replaced.End:
+			if last && h.newCount > 0 {
+				return h.newStart + h.newCount - 1
+			}
+
+			return h.newStart
+		case line > replaced.End:
+			offset += h.newCount - h.oldCount
+		}
+	}
+
+	return line + offset
+}
+
+// fileDiff represents the hunks of a file in a diff
+type fileDiff struct {
+	path  string
+	hunks []hunk
+}
+
+func parseChanges(out string) ([]*FileChange, error) {
+	diffs, err := parseDiff(out)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*FileChange, 0, len(diffs))
+
+	for _, d := range diffs {
+		c := &FileChange{Path: d.path}
+		for _, h := range d.hunks {
+			c.Lines = append(c.Lines, h.lines())
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+func parseDiff(out string) ([]*fileDiff, error) {
+	var (
+		diffs   []*fileDiff
+		current *fileDiff
+		oldPath string
+	)
+
+	for _, l := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(l, diffOldFile):
+			oldPath = strings.TrimPrefix(strings.TrimPrefix(l, diffOldFile), "a/")
+		case strings.HasPrefix(l, diffNewFile):
+			path := strings.TrimPrefix(strings.TrimPrefix(l, diffNewFile), "b/")
+			if path == diffNoFile {
+				path = oldPath
+			}
+
+			current = &fileDiff{path: path}
+			diffs = append(diffs, current)
+		case strings.HasPrefix(l, diffHunk):
+			if current == nil {
+				return nil, fmt.Errorf("%w: hunk without file: %s", ErrUnexpectedOutput, l)
+			}
+
+			h, err := parseHunk(l)
+			if err != nil {
+				return nil, err
+			}
+
+			current.hunks = append(current.hunks, h)
+		}
+	}
+
+	return diffs, nil
+}
+
+func parseHunk(line string) (hunk, error) {
+	matches := hunkPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return hunk{}, fmt.Errorf("%w: %s", ErrUnexpectedOutput, line)
+	}
+
+	var numbers [4]int
+
+	for i, m := range matches[1:] {
+		if m == "" {
+			// the count is omitted for a single line
+			numbers[i] = 1
+			continue
+		}
+
+		n, err := strconv.Atoi(m)
+		if err != nil {
+			return hunk{}, fmt.Errorf("%w: %v", ErrUnexpectedOutput, err)
+		}
+
+		numbers[i] = n
+	}
+
+	return hunk{oldStart: numbers[0], oldCount: numbers[1], newStart: numbers[2], newCount: numbers[3]}, nil
+}
@@ -0,0 +1,41 @@
+package gitcli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	commitFormat = "--format=%H%x09%an%x09%aI%x09%s"
+	commitFields = 4
+)
+
+// Commit represents a commit of a git repository
+type Commit struct {
+	Hash    string
+	PatchID string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+func parseCommits(out string) ([]*Commit, error) {
+	var commits []*Commit
+
+	for _, l := range lines(out) {
+		fields := strings.SplitN(l, fieldSeparator, commitFields)
+		if len(fields) != commitFields {
+			return nil, fmt.Errorf("%w: %s", ErrUnexpectedOutput, l)
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnexpectedOutput, err)
+		}
+
+		commits = append(commits, &Commit{Hash: fields[0], Author: fields[1], Date: date, Subject: fields[3]})
+	}
+
+	return commits, nil
+}
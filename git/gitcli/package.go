@@ -0,0 +1,2 @@
+// Package gitcli provides read access to git repositories by using the git command line client
+package gitcli
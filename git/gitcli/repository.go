@@ -0,0 +1,305 @@
+package gitcli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rebel-l/go-utils/osutils"
+)
+
+const (
+	gitCommand = "git"
+	refsHeads  = "refs/heads"
+	refsTags   = "refs/tags"
+
+	fieldSeparator = "\t"
+	tagFormat      = "--format=%(refname:lstrip=2)%09%(objectname)%09%(*objectname)%09%(creatordate:iso-strict)"
+	tagFields      = 4
+)
+
+var (
+	// ErrCommandFailed occurs if the git command returns with an error
+	ErrCommandFailed = errors.New("git command failed")
+
+	// ErrUnexpectedOutput occurs if the output of a git command couldn't be parsed
+	ErrUnexpectedOutput = errors.New("unexpected output of git command")
+
+	// ErrNotCloned occurs if an operation is executed on a repository which was not cloned yet
+	ErrNotCloned = errors.New("repository is not cloned yet, fetch it first")
+)
+
+// Repository represents a local mirror of a remote git repository
+type Repository struct {
+	url  string
+	path string
+}
+
+// New returns a repository for the given remote url which is mirrored into the given path
+func New(url, path string) *Repository {
+	return &Repository{url: url, path: path}
+}
+
+// Path returns the path of the local mirror for the repository with the given ID
+func Path(workPath string, repositoryID int) string {
+	return filepath.Join(workPath, strconv.Itoa(repositoryID))
+}
+
+// Fetch clones the remote repository if it doesn't exist locally, otherwise it updates all references
+func (r *Repository) Fetch(ctx context.Context) error {
+	if !r.isCloned() {
+		_, err := run(ctx, "", "clone", "--mirror", "--quiet", r.url, r.path)
+		return err
+	}
+
+	_, err := r.git(ctx, "fetch", "--prune", "--quiet", "origin")
+
+	return err
+}
+
+// Branches returns the names of all branches
+func (r *Repository) Branches(ctx context.Context) ([]string, error) {
+	return r.refs(ctx, refsHeads)
+}
+
+// MergedBranches returns the names of all branches which are reachable from the given reference
+func (r *Repository) MergedBranches(ctx context.Context, ref string) ([]string, error) {
+	return r.refs(ctx, "--merged", ref, refsHeads)
+}
+
+// Tags returns all tags with the commit they point to
+func (r *Repository) Tags(ctx context.Context) ([]*Tag, error) {
+	out, err := r.git(ctx, "for-each-ref", tagFormat, refsTags)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []*Tag
+
+	for _, l := range lines(out) {
+		fields := strings.Split(l, fieldSeparator)
+		if len(fields) != tagFields {
+			return nil, fmt.Errorf("%w: %s", ErrUnexpectedOutput, l)
+		}
+
+		date, err := time.Parse(time.RFC3339, fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnexpectedOutput, err)
+		}
+
+		// annotated tags point to a tag object, the commit is the dereferenced object
+		commit := fields[2]
+		if commit == "" {
+			commit = fields[1]
+		}
+
+		tags = append(tags, &Tag{Name: fields[0], Commit: commit, Date: date})
+	}
+
+	return tags, nil
+}
+
+// MissingCommits returns the commits reachable from head but not from upstream. Commits which were cherry-picked
+// into upstream are recognised by their patch ID and are not returned. Merge commits are ignored.
+func (r *Repository) MissingCommits(ctx context.Context, upstream, head string) ([]*Commit, error) {
+	revisions := upstream + "..." + head
+
+	out, err := r.git(ctx, "log", "--right-only", "--cherry-pick", "--no-merges", commitFormat, revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	commits, err := parseCommits(out)
+	if err != nil || len(commits) == 0 {
+		return commits, err
+	}
+
+	patches, err := r.git(ctx, "log", "--right-only", "--cherry-pick", "--no-merges", "-p", revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	patchIDs, err := r.patchIDs(ctx, patches)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range commits {
+		c.PatchID = patchIDs[c.Hash]
+	}
+
+	return commits, nil
+}
+
+// Ahead returns the number of commits reachable from head but not from base, zero means head is merged into base
+func (r *Repository) Ahead(ctx context.Context, base, head string) (int, error) {
+	out, err := r.git(ctx, "rev-list", "--count", base+".."+head)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(out))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrUnexpectedOutput, err)
+	}
+
+	return count, nil
+}
+
+// Changes returns the files and lines changed on head since it was forked from base, which is the diff against
+// their merge base
+func (r *Repository) Changes(ctx context.Context, base, head string) ([]*FileChange, error) {
+	out, err := r.git(ctx, "diff", "--unified=0", "--no-color", "--no-renames", "--no-ext-diff", base+"..."+head)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseChanges(out)
+}
+
+// ChangesOnto returns the files changed on head since it was forked from base like Changes, but the lines refer to
+// the tip of base. Only the changes of head are returned, the lines changed on base after the fork just move them. So
+// the changes of heads forked from base at different commits can be compared with each other.
+func (r *Repository) ChangesOnto(ctx context.Context, base, head string) ([]*FileChange, error) {
+	out, err := r.git(ctx, "merge-base", base, head)
+	if err != nil {
+		return nil, err
+	}
+
+	forkedAt := strings.TrimSpace(out)
+
+	forked, err := r.diff(ctx, forkedAt, head)
+	if err != nil || len(forked) == 0 {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(forked))
+	for _, d := range forked {
+		paths = append(paths, d.path)
+	}
+
+	moved, err := r.diff(ctx, forkedAt, base, paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	hunks := make(map[string][]hunk, len(moved))
+	for _, d := range moved {
+		hunks[d.path] = d.hunks
+	}
+
+	changes := make([]*FileChange, 0, len(forked))
+
+	for _, d := range forked {
+		c := &FileChange{Path: d.path}
+
+		for _, h := range d.hunks {
+			l := h.lines()
+			c.Lines = append(c.Lines, LineRange{
+				Start: onto(l.Start, hunks[d.path], false),
+				End:   onto(l.End, hunks[d.path], true),
+			})
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// diff returns the hunks of the files changed between the commits, optionally restricted to the given paths
+func (r *Repository) diff(ctx context.Context, from, to string, paths ...string) ([]*fileDiff, error) {
+	args := []string{"diff", "--unified=0", "--no-color", "--no-renames", "--no-ext-diff", from, to, "--"}
+
+	out, err := r.git(ctx, append(args, paths...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDiff(out)
+}
+
+// patchIDs returns the patch IDs of the given patches mapped by commit hash
+func (r *Repository) patchIDs(ctx context.Context, patches string) (map[string]string, error) {
+	out, err := runWithInput(ctx, r.path, patches, "patch-id", "--stable")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]string)
+
+	for _, l := range lines(out) {
+		fields := strings.Fields(l)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("%w: %s", ErrUnexpectedOutput, l)
+		}
+
+		ids[fields[1]] = fields[0]
+	}
+
+	return ids, nil
+}
+
+func (r *Repository) refs(ctx context.Context, args ...string) ([]string, error) {
+	args = append([]string{"for-each-ref", "--format=%(refname:lstrip=2)"}, args...)
+
+	out, err := r.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return lines(out), nil
+}
+
+func (r *Repository) git(ctx context.Context, args ...string) (string, error) {
+	if !r.isCloned() {
+		return "", ErrNotCloned
+	}
+
+	return run(ctx, r.path, args...)
+}
+
+func (r *Repository) isCloned() bool {
+	return r != nil && osutils.FileOrPathExists(r.path)
+}
+
+func run(ctx context.Context, dir string, args ...string) (string, error) {
+	return runWithInput(ctx, dir, "", args...)
+}
+
+func runWithInput(ctx context.Context, dir, input string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, gitCommand, args...) // nolint: gosec
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: git %s: %v: %s", ErrCommandFailed, args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+func lines(out string) []string {
+	var res []string
+
+	for _, l := range strings.Split(out, "\n") {
+		l = strings.TrimSpace(l)
+		if l == "" {
+			continue
+		}
+
+		res = append(res, l)
+	}
+
+	return res
+}
@@ -0,0 +1,455 @@
+package gitcli_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/git/gitcli"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/go-utils/slice"
+)
+
+const (
+	testCluster = "test_gitcli"
+)
+
+func setup(t *testing.T, name string) (*test.GitRemote, *gitcli.Repository) {
+	t.Helper()
+
+	remote := test.NewGitRemote(t, testCluster, name)
+	repo := gitcli.New(remote.Path, filepath.Join(filepath.Dir(remote.Path), "mirror"))
+
+	return remote, repo
+}
+
+func TestRepository_Fetch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	remote, repo := setup(t, "fetch")
+
+	// 1. clone
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on clone but got: %v", err)
+	}
+
+	// 2. update
+	remote.Branch("feature/JIRA-1", "master")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on update but got: %v", err)
+	}
+
+	branches, err := repo.Branches(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	expected := slice.StringSlice{"feature/JIRA-1", "master"}
+	if !expected.IsEqual(branches) {
+		t.Errorf("expected branches %v but got %v", expected, branches)
+	}
+}
+
+func TestRepository_Fetch_Error(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	repo := gitcli.New(filepath.Join(".", "not_existing"), filepath.Join(".", "..", "..", "storage", testCluster, "error"))
+
+	err := repo.Fetch(context.Background())
+	if !errors.Is(err, gitcli.ErrCommandFailed) {
+		t.Errorf("expected error '%v' but got '%v'", gitcli.ErrCommandFailed, err)
+	}
+}
+
+func TestRepository_Branches_NotCloned(t *testing.T) {
+	repo := gitcli.New("url", filepath.Join(".", "not_existing"))
+
+	_, err := repo.Branches(context.Background())
+	if !errors.Is(err, gitcli.ErrNotCloned) {
+		t.Errorf("expected error '%v' but got '%v'", gitcli.ErrNotCloned, err)
+	}
+}
+
+func TestRepository_MergedBranches(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "merged")
+
+	remote.Branch("release/1.0.0", "master")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 first feature")
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Commit("feature/JIRA-2", "b.txt", "JIRA-2 second feature")
+	remote.Merge("release/1.0.0", "feature/JIRA-1")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	branches, err := repo.MergedBranches(context.Background(), "release/1.0.0")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	expected := slice.StringSlice{"feature/JIRA-1", "master", "release/1.0.0"}
+	if !expected.IsEqual(branches) {
+		t.Errorf("expected branches %v but got %v", expected, branches)
+	}
+}
+
+func TestRepository_Tags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "tags")
+
+	annotated := remote.Commit("master", "a.txt", "first release")
+	remote.Tag("v1.0.0", "master")
+
+	lightweight := remote.Commit("master", "b.txt", "second release")
+	remote.Git("tag", "v1.1.0", "master")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	tags, err := repo.Tags(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(tags) != 2 {
+		t.Fatalf("expected 2 tags but got %d", len(tags))
+	}
+
+	expected := []struct {
+		name   string
+		commit string
+	}{
+		{name: "v1.0.0", commit: annotated},
+		{name: "v1.1.0", commit: lightweight},
+	}
+
+	for i, e := range expected {
+		if tags[i].Name != e.name {
+			t.Errorf("expected tag name '%s' but got '%s'", e.name, tags[i].Name)
+		}
+
+		if tags[i].Commit != e.commit {
+			t.Errorf("expected tag %s to point to commit '%s' but got '%s'", e.name, e.commit, tags[i].Commit)
+		}
+
+		if tags[i].Date.IsZero() {
+			t.Errorf("expected tag %s to have a date", e.name)
+		}
+
+		if tags[i].Ref() != "refs/tags/"+e.name {
+			t.Errorf("expected reference 'refs/tags/%s' but got '%s'", e.name, tags[i].Ref())
+		}
+	}
+}
+
+func TestRepository_MissingCommits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "missing")
+
+	remote.Branch("release/1.0.0", "master")
+	missing := remote.Commit("release/1.0.0", "a.txt", "JIRA-1 hotfix not merged back")
+	picked := remote.Commit("release/1.0.0", "b.txt", "JIRA-2 hotfix cherry picked")
+	remote.Git("checkout", "--quiet", "master")
+	remote.Git("cherry-pick", picked)
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	commits, err := repo.MissingCommits(context.Background(), "master", "release/1.0.0")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(commits) != 1 {
+		t.Fatalf("expected 1 missing commit but got %d", len(commits))
+	}
+
+	c := commits[0]
+	if c.Hash != missing || c.Subject != "JIRA-1 hotfix not merged back" || c.Author != "branma" {
+		t.Errorf("expected commit '%s' but got %#v", missing, c)
+	}
+
+	if c.PatchID == "" || c.Date.IsZero() {
+		t.Errorf("expected patch ID and date to be set but got %#v", c)
+	}
+
+	// 3. nothing missing
+	commits, err = repo.MissingCommits(context.Background(), "release/1.0.0", "master")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(commits) != 0 {
+		t.Errorf("expected no missing commits but got %d", len(commits))
+	}
+}
+
+func TestRepository_Changes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "changes")
+
+	remote.Commit("master", "a.txt", "line 1")
+	remote.Commit("master", "a.txt", "line 2")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "line 3")
+	remote.Commit("feature/JIRA-1", "dir/b.txt", "line 1")
+	remote.Commit("master", "c.txt", "changed on master after fork")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	changes, err := repo.Changes(context.Background(), "master", "feature/JIRA-1")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changed files but got %d", len(changes))
+	}
+
+	if changes[0].Path != "a.txt" || len(changes[0].Lines) != 1 ||
+		changes[0].Lines[0] != (gitcli.LineRange{Start: 2, End: 2}) {
+		t.Errorf("expected a line appended after line 2 of a.txt but got %#v", changes[0])
+	}
+
+	if changes[1].Path != "dir/b.txt" || len(changes[1].Lines) != 1 {
+		t.Errorf("expected dir/b.txt to be changed but got %#v", changes[1])
+	}
+
+	// 3. nothing changed
+	changes, err = repo.Changes(context.Background(), "feature/JIRA-1", "master")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].Path != "c.txt" {
+		t.Errorf("expected only c.txt to be changed on master but got %#v", changes)
+	}
+}
+
+func TestRepository_ChangesOnto(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "changesOnto")
+
+	remote.Write("master", "a.txt", "1\n2\n3\n", "add a.txt")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Write("feature/JIRA-1", "a.txt", "1\n2\nthree\n", "JIRA-1 changes line 3")
+	remote.Write("master", "a.txt", "0\n1\n2\n3\n", "line inserted on master after fork")
+	remote.Commit("master", "c.txt", "changed on master after fork")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	changes, err := repo.ChangesOnto(context.Background(), "master", "feature/JIRA-1")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(changes) != 1 || changes[0].Path != "a.txt" {
+		t.Fatalf("expected only a.txt to be changed but got %#v", changes)
+	}
+
+	if len(changes[0].Lines) != 1 || changes[0].Lines[0] != (gitcli.LineRange{Start: 4, End: 4}) {
+		t.Errorf("expected only line 4 of a.txt on master to be changed but got %#v", changes[0].Lines)
+	}
+
+	// 3. nothing changed
+	changes, err = repo.ChangesOnto(context.Background(), "feature/JIRA-1", "feature/JIRA-1")
+	if err != nil || len(changes) != 0 {
+		t.Errorf("expected no changes but got %#v and error: %v", changes, err)
+	}
+}
+
+func TestRepository_ChangesOnto_BaseMoved(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "changesOntoBaseMoved")
+
+	content := func(changed map[int]string) string {
+		var b strings.Builder
+
+		for i := 1; i <= 60; i++ {
+			line, ok := changed[i]
+			if !ok {
+				line = strconv.Itoa(i)
+			}
+
+			b.WriteString(line + "\n")
+		}
+
+		return b.String()
+	}
+
+	remote.Write("master", "a.txt", content(nil), "add a.txt")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Write("feature/JIRA-1", "a.txt", content(map[int]string{10: "ten"}), "JIRA-1 changes line 10")
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Write("feature/JIRA-2", "a.txt", content(map[int]string{50: "fifty"}), "JIRA-2 changes line 50")
+	remote.Write("master", "a.txt", content(map[int]string{30: "thirty"}), "line 30 changed on master after fork")
+	remote.Write("master", "b.txt", "b\n", "add b.txt on master after fork")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	first, err := repo.ChangesOnto(context.Background(), "master", "feature/JIRA-1")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	second, err := repo.ChangesOnto(context.Background(), "master", "feature/JIRA-2")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(first) != 1 || len(first[0].Lines) != 1 || first[0].Lines[0] != (gitcli.LineRange{Start: 10, End: 10}) {
+		t.Fatalf("expected only line 10 of a.txt to be changed but got %#v", first)
+	}
+
+	if len(second) != 1 || len(second[0].Lines) != 1 || second[0].Lines[0] != (gitcli.LineRange{Start: 50, End: 50}) {
+		t.Fatalf("expected only line 50 of a.txt to be changed but got %#v", second)
+	}
+
+	if first[0].Overlaps(second[0]) {
+		t.Error("expected the changes of the branches not to overlap")
+	}
+
+	// 3. lines inserted on base after the fork move the changes
+	remote.Write("master", "a.txt", "new\nlines\n"+content(map[int]string{30: "thirty"}), "lines inserted on master")
+
+	if err = repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	second, err = repo.ChangesOnto(context.Background(), "master", "feature/JIRA-2")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(second) != 1 || len(second[0].Lines) != 1 || second[0].Lines[0] != (gitcli.LineRange{Start: 52, End: 52}) {
+		t.Errorf("expected only line 52 of a.txt on master to be changed but got %#v", second)
+	}
+}
+
+func TestFileChange_Overlaps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        []gitcli.LineRange
+		b        []gitcli.LineRange
+		expected bool
+	}{
+		{
+			name:     "same line",
+			a:        []gitcli.LineRange{{Start: 3, End: 3}},
+			b:        []gitcli.LineRange{{Start: 3, End: 3}},
+			expected: true,
+		},
+		{
+			name:     "enclosed",
+			a:        []gitcli.LineRange{{Start: 1, End: 10}},
+			b:        []gitcli.LineRange{{Start: 20, End: 30}, {Start: 4, End: 5}},
+			expected: true,
+		},
+		{
+			name: "different lines",
+			a:    []gitcli.LineRange{{Start: 1, End: 2}},
+			b:    []gitcli.LineRange{{Start: 3, End: 4}},
+		},
+		{
+			name: "no lines",
+			a:    []gitcli.LineRange{{Start: 1, End: 2}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			a := &gitcli.FileChange{Path: "a.txt", Lines: testCase.a}
+			b := &gitcli.FileChange{Path: "a.txt", Lines: testCase.b}
+
+			if a.Overlaps(b) != testCase.expected || b.Overlaps(a) != testCase.expected {
+				t.Errorf("expected overlap to be %t", testCase.expected)
+			}
+		})
+	}
+}
+
+func TestRepository_Ahead(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	remote, repo := setup(t, "ahead")
+
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 first commit")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 second commit")
+
+	if err := repo.Fetch(context.Background()); err != nil {
+		t.Fatalf("expected no error on fetch but got: %v", err)
+	}
+
+	// 2. test
+	ahead, err := repo.Ahead(context.Background(), "master", "feature/JIRA-1")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if ahead != 2 {
+		t.Errorf("expected branch to be 2 commits ahead but got %d", ahead)
+	}
+
+	ahead, err = repo.Ahead(context.Background(), "feature/JIRA-1", "master")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if ahead != 0 {
+		t.Errorf("expected master to be merged into branch but got %d commits ahead", ahead)
+	}
+}
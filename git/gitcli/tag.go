@@ -0,0 +1,15 @@
+package gitcli
+
+import "time"
+
+// Tag represents a tag of a git repository
+type Tag struct {
+	Name   string
+	Commit string
+	Date   time.Time
+}
+
+// Ref returns the full reference of the tag, which is unambiguous even if a branch has the same name
+func (t *Tag) Ref() string {
+	return refsTags + "/" + t.Name
+}
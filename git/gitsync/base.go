@@ -0,0 +1,108 @@
+package gitsync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/git/gitcli"
+	"github.com/rebel-l/branma_be/version/versionname"
+)
+
+// syncBases detects the branch each open branch was most likely cut from. Candidates are the main branch and the
+// release branches. The base is the candidate the branch has the fewest own commits against. As soon as a branch is
+// merged into any candidate the comparison isn't meaningful anymore, so the base detected before is kept.
+func (s *Syncer) syncBases(
+	ctx context.Context,
+	gitRepo *gitcli.Repository,
+	branches map[string]*branchstore.Branch,
+	res *Result,
+) error {
+	candidates := s.baseCandidates(branches)
+
+	for name, b := range branches {
+		if b.Closed || s.isBaseCandidate(name) {
+			continue
+		}
+
+		base, err := s.detectBase(ctx, gitRepo, name, candidates)
+		if err != nil {
+			return err
+		}
+
+		if base == "" || base == b.BaseBranch {
+			continue
+		}
+
+		b.BaseBranch = base
+		if err := b.Update(ctx, s.db); err != nil {
+			return fmt.Errorf("failed to store base of branch %s: %w", name, err)
+		}
+
+		res.BasesDetected++
+	}
+
+	return nil
+}
+
+// baseCandidates returns the main branch followed by the release branches from oldest to newest version, so in case
+// of a tie the main branch or the older release is preferred
+func (s *Syncer) baseCandidates(branches map[string]*branchstore.Branch) []string {
+	var releases []string
+
+	for name, b := range branches {
+		if !b.Closed && versionname.FromBranch(s.releasePrefix, name) != "" {
+			releases = append(releases, name)
+		}
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return versionname.Compare(
+			versionname.FromBranch(s.releasePrefix, releases[i]),
+			versionname.FromBranch(s.releasePrefix, releases[j]),
+		) < 0
+	})
+
+	var candidates []string
+
+	if b, ok := branches[s.mainBranch]; ok && !b.Closed {
+		candidates = append(candidates, s.mainBranch)
+	}
+
+	return append(candidates, releases...)
+}
+
+func (s *Syncer) isBaseCandidate(name string) bool {
+	return name == s.mainBranch || versionname.FromBranch(s.releasePrefix, name) != ""
+}
+
+func (s *Syncer) detectBase(
+	ctx context.Context,
+	gitRepo *gitcli.Repository,
+	name string,
+	candidates []string,
+) (string, error) {
+	var (
+		base string
+		min  int
+	)
+
+	for _, c := range candidates {
+		ahead, err := gitRepo.Ahead(ctx, c, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to compare branch %s with %s: %w", name, c, err)
+		}
+
+		if ahead == 0 {
+			return "", nil
+		}
+
+		if base == "" || ahead < min {
+			base = c
+			min = ahead
+		}
+	}
+
+	return base, nil
+}
@@ -0,0 +1,2 @@
+// Package gitsync synchronises the branches of the registered git repositories into the database
+package gitsync
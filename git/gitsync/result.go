@@ -0,0 +1,46 @@
+package gitsync
+
+import (
+	"time"
+
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+)
+
+// Result provides the statistics of a synchronisation run, Error is set if the run failed as a whole
+type Result struct {
+	StartedAt             time.Time          `json:"started_at"`
+	FinishedAt            time.Time          `json:"finished_at"`
+	Repositories          int                `json:"repositories"`
+	BranchesCreated       int                `json:"branches_created"`
+	BranchesClosed        int                `json:"branches_closed"`
+	BranchesReopened      int                `json:"branches_reopened"`
+	VersionsCreated       int                `json:"versions_created"`
+	VersionsReleased      int                `json:"versions_released"`
+	BranchVersionsCreated int                `json:"branch_versions_created"`
+	BasesDetected         int                `json:"bases_detected"`
+	TicketActions         int                `json:"ticket_actions"`
+	Errors                []*RepositoryError `json:"errors,omitempty"`
+	WriteBackErrors       []string           `json:"write_back_errors,omitempty"`
+	Error                 string             `json:"error,omitempty"`
+}
+
+// RepositoryError provides the failure of the synchronisation of a repository. Repositories are identified by ID, as
+// their names are only unique per url.
+type RepositoryError struct {
+	RepositoryID int    `json:"repository_id"`
+	Repository   string `json:"repository"`
+	Error        string `json:"error"`
+}
+
+func (r *Result) addError(repo *repositorystore.Repository, err error) {
+	r.Errors = append(r.Errors, &RepositoryError{RepositoryID: repo.ID, Repository: repo.Name, Error: err.Error()})
+}
+
+// addWriteBack counts the actions done by the write back, failures are collected without stopping the synchronisation
+func (r *Result) addWriteBack(actions int, err error) {
+	r.TicketActions += actions
+
+	if err != nil {
+		r.WriteBackErrors = append(r.WriteBackErrors, err.Error())
+	}
+}
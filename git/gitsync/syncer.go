@@ -0,0 +1,483 @@
+package gitsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitcli"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/ticket/ticketkey"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionname"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+var (
+	// ErrRunning occurs if a synchronisation is started while another one is still running
+	ErrRunning = errors.New("synchronisation is already running")
+
+	// ErrLoadRepositories occurs if the repositories to synchronise couldn't be loaded
+	ErrLoadRepositories = errors.New("failed to load repositories")
+
+	// ErrTagPattern occurs if the configured pattern for release tags is not a valid regular expression
+	ErrTagPattern = errors.New("invalid pattern for release tags")
+)
+
+// WriteBack writes the merges into release branches and the releases detected back to the tickets, the number of
+// changes done is returned
+type WriteBack interface {
+	// Merged is called for every branch merged into the release branch of the version until it is released
+	Merged(
+		ctx context.Context,
+		repo *repositorystore.Repository,
+		b *branchstore.Branch,
+		version *versionstore.Version,
+	) (int, error)
+
+	// Released is called for every ticket of the repository contained in the version once it is released
+	Released(
+		ctx context.Context,
+		repo *repositorystore.Repository,
+		version *versionstore.Version,
+		ticketKey string,
+	) (int, error)
+}
+
+// Syncer synchronises the branches of all repositories from git into the database
+type Syncer struct {
+	db            *sqlx.DB
+	writeBack     WriteBack
+	workPath      string
+	mainBranch    string
+	releasePrefix string
+	tagPattern    *regexp.Regexp
+	mutex         sync.Mutex
+	running       bool
+	last          *Result
+}
+
+// New returns a new syncer
+func New(db *sqlx.DB, cfg *config.Git) (*Syncer, error) {
+	tagPattern, err := regexp.Compile(cfg.GetTagPattern())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTagPattern, err)
+	}
+
+	return &Syncer{
+		db:            db,
+		workPath:      cfg.GetWorkPath(),
+		mainBranch:    cfg.GetMainBranch(),
+		releasePrefix: versionname.ReleaseBranchPrefix(cfg),
+		tagPattern:    tagPattern,
+	}, nil
+}
+
+// WithWriteBack sets the write back informed about merges and releases
+func (s *Syncer) WithWriteBack(w WriteBack) *Syncer {
+	s.writeBack = w
+
+	return s
+}
+
+// Sync fetches all repositories and updates branches, versions and the assignment of branches to versions. Versions
+// are released per repository as soon as a tag matching the tag pattern appears. The base of each branch is detected.
+// The first completed synchronisation of a repository only records the releases of the tags existing so far, they are
+// not written back to the tickets. Failures of single repositories don't stop the synchronisation, they are reported in
+// the result.
+func (s *Syncer) Sync(ctx context.Context) (*Result, error) {
+	if err := s.start(); err != nil {
+		return nil, err
+	}
+	defer s.stop()
+
+	return s.sync(ctx)
+}
+
+// Start runs the synchronisation like Sync in the background, so it isn't bound to the request triggering it. The
+// result is provided by Last once it finished, errors are logged. ErrRunning is returned if a synchronisation is still
+// running.
+func (s *Syncer) Start(ctx context.Context, log logrus.FieldLogger) error {
+	if err := s.start(); err != nil {
+		return err
+	}
+
+	go func() {
+		defer s.stop()
+
+		res, err := s.sync(ctx)
+		if err != nil {
+			log.Errorf("git synchronisation failed: %v", err)
+
+			return
+		}
+
+		for _, e := range res.Errors {
+			log.Errorf("failed to synchronise repository %s (%d): %s", e.Repository, e.RepositoryID, e.Error)
+		}
+	}()
+
+	return nil
+}
+
+// Running returns true while a synchronisation is running
+func (s *Syncer) Running() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.running
+}
+
+// Last returns the result of the last synchronisation run, it is nil if it never ran
+func (s *Syncer) Last() *Result {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.last
+}
+
+func (s *Syncer) sync(ctx context.Context) (*Result, error) {
+	res := &Result{StartedAt: time.Now()}
+
+	defer func() {
+		res.FinishedAt = time.Now()
+		s.setLast(res)
+	}()
+
+	var repos repositorystore.Repositories
+	if err := repos.ReadAll(ctx, s.db); err != nil {
+		res.Error = ErrLoadRepositories.Error()
+
+		return nil, fmt.Errorf("%w: %v", ErrLoadRepositories, err)
+	}
+
+	for _, repo := range repos {
+		res.Repositories++
+
+		if err := s.syncRepository(ctx, repo, res); err != nil {
+			res.addError(repo, err)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *Syncer) start() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return ErrRunning
+	}
+
+	s.running = true
+
+	return nil
+}
+
+func (s *Syncer) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.running = false
+}
+
+func (s *Syncer) setLast(res *Result) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.last = res
+}
+
+func (s *Syncer) syncRepository(ctx context.Context, repo *repositorystore.Repository, res *Result) error {
+	gitRepo := gitcli.New(repo.URL, gitcli.Path(s.workPath, repo.ID))
+	if err := gitRepo.Fetch(ctx); err != nil {
+		return err
+	}
+
+	names, err := gitRepo.Branches(ctx)
+	if err != nil {
+		return err
+	}
+
+	synced, err := s.isSynced(ctx, repo.ID)
+	if err != nil {
+		return err
+	}
+
+	branches, err := s.syncBranches(ctx, repo.ID, names, res)
+	if err != nil {
+		return err
+	}
+
+	if err = s.syncReleases(ctx, repo, gitRepo, branches, res); err != nil {
+		return err
+	}
+
+	if err = s.syncTags(ctx, repo, gitRepo, branches, !synced, res); err != nil {
+		return err
+	}
+
+	if err = s.syncBases(ctx, gitRepo, branches, res); err != nil {
+		return err
+	}
+
+	if synced {
+		return nil
+	}
+
+	synchronisation := &repositorystore.Sync{RepositoryID: repo.ID, SyncedAt: time.Now()}
+	if err = synchronisation.Create(ctx, s.db); err != nil {
+		return fmt.Errorf("failed to mark repository as synchronised: %w", err)
+	}
+
+	return nil
+}
+
+// isSynced returns true if the repository was completely synchronised before
+func (s *Syncer) isSynced(ctx context.Context, repositoryID int) (bool, error) {
+	synchronisation := &repositorystore.Sync{RepositoryID: repositoryID}
+
+	err := synchronisation.ReadByRepository(ctx, s.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to load synchronisation of repository: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *Syncer) syncBranches(
+	ctx context.Context,
+	repositoryID int,
+	names []string,
+	res *Result,
+) (map[string]*branchstore.Branch, error) {
+	var existing branchstore.Branches
+	if err := existing.ReadByRepository(ctx, s.db, repositoryID); err != nil {
+		return nil, err
+	}
+
+	branches := make(map[string]*branchstore.Branch)
+	for _, b := range existing {
+		branches[b.Name] = b
+	}
+
+	found := make(map[string]bool)
+
+	for _, name := range names {
+		found[name] = true
+
+		b, ok := branches[name]
+		if !ok {
+			b = &branchstore.Branch{
+				Name:         name,
+				RepositoryID: repositoryID,
+				TicketID:     ticketkey.Find(name),
+			}
+
+			if err := b.Create(ctx, s.db); err != nil {
+				return nil, fmt.Errorf("failed to create branch %s: %w", name, err)
+			}
+
+			if b.TicketID != "" {
+				if err := (&ticketstore.Ticket{Key: b.TicketID}).ReadOrCreate(ctx, s.db); err != nil {
+					return nil, fmt.Errorf("failed to create ticket of branch %s: %w", name, err)
+				}
+			}
+
+			branches[name] = b
+			res.BranchesCreated++
+
+			continue
+		}
+
+		if b.Closed {
+			b.Closed = false
+			if err := b.Update(ctx, s.db); err != nil {
+				return nil, fmt.Errorf("failed to reopen branch %s: %w", name, err)
+			}
+
+			res.BranchesReopened++
+		}
+	}
+
+	for name, b := range branches {
+		if found[name] || b.Closed {
+			continue
+		}
+
+		b.Closed = true
+		if err := b.Update(ctx, s.db); err != nil {
+			return nil, fmt.Errorf("failed to close branch %s: %w", name, err)
+		}
+
+		res.BranchesClosed++
+	}
+
+	return branches, nil
+}
+
+type release struct {
+	version string
+	branch  *branchstore.Branch
+}
+
+func (s *Syncer) syncReleases(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	gitRepo *gitcli.Repository,
+	branches map[string]*branchstore.Branch,
+	res *Result,
+) error {
+	var releases []release
+
+	for name, b := range branches {
+		if b.Closed {
+			continue
+		}
+
+		if v := versionname.FromBranch(s.releasePrefix, name); v != "" {
+			releases = append(releases, release{version: v, branch: b})
+		}
+	}
+
+	// older releases first, so a branch is assigned to the first version containing it
+	sort.Slice(releases, func(i, j int) bool {
+		return versionname.Compare(releases[i].version, releases[j].version) < 0
+	})
+
+	for _, r := range releases {
+		version, err := s.syncVersion(ctx, r, res)
+		if err != nil {
+			return err
+		}
+
+		released, err := s.isReleased(ctx, version, repo.ID)
+		if err != nil {
+			return err
+		}
+
+		if released {
+			continue
+		}
+
+		merged, err := gitRepo.MergedBranches(ctx, r.branch.Name)
+		if err != nil {
+			return err
+		}
+
+		for _, name := range merged {
+			b, ok := branches[name]
+			if !ok || b.TicketID == "" || versionname.FromBranch(s.releasePrefix, name) != "" {
+				continue
+			}
+
+			assigned, err := s.assignVersion(ctx, b, version, res)
+			if err != nil {
+				return err
+			}
+
+			if assigned && s.writeBack != nil {
+				res.addWriteBack(s.writeBack.Merged(ctx, repo, b, version))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) syncVersion(ctx context.Context, r release, res *Result) (*versionstore.Version, error) {
+	version := &versionstore.Version{Version: r.version}
+
+	err := version.ReadByVersion(ctx, s.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		version.BranchID = &r.branch.ID
+		if err = version.Create(ctx, s.db); err != nil {
+			return nil, fmt.Errorf("failed to create version %s: %w", r.version, err)
+		}
+
+		res.VersionsCreated++
+
+		return version, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to load version %s: %w", r.version, err)
+	}
+
+	if version.BranchID != nil && *version.BranchID == r.branch.ID {
+		return version, nil
+	}
+
+	linked, err := s.linkedToOtherRepository(ctx, version, r.branch.RepositoryID)
+	if err != nil || linked {
+		return version, err
+	}
+
+	version.BranchID = &r.branch.ID
+	if err = version.Update(ctx, s.db); err != nil {
+		return nil, fmt.Errorf("failed to link version %s to release branch: %w", r.version, err)
+	}
+
+	return version, nil
+}
+
+// linkedToOtherRepository returns true if the version is linked to an open release branch of another repository. Such
+// a link is kept, so repositories sharing a release branch name don't take the version from each other on every sync.
+func (s *Syncer) linkedToOtherRepository(
+	ctx context.Context,
+	version *versionstore.Version,
+	repositoryID int,
+) (bool, error) {
+	if version.BranchID == nil {
+		return false, nil
+	}
+
+	linked := &branchstore.Branch{ID: *version.BranchID}
+
+	err := linked.Read(ctx, s.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to load release branch of version %s: %w", version.Version, err)
+	}
+
+	return linked.RepositoryID != repositoryID && !linked.Closed, nil
+}
+
+// assignVersion assigns the branch to the version if it isn't assigned to any version yet, it returns true if the
+// branch is assigned to the given version
+func (s *Syncer) assignVersion(
+	ctx context.Context,
+	b *branchstore.Branch,
+	version *versionstore.Version,
+	res *Result,
+) (bool, error) {
+	var assigned versionstore.BranchVersions
+	if err := assigned.ReadByBranch(ctx, s.db, b.ID); err != nil {
+		return false, err
+	}
+
+	if len(assigned) > 0 {
+		return assigned[0].VersionID == version.ID, nil
+	}
+
+	bv := &versionstore.BranchVersion{BranchID: b.ID, VersionID: version.ID}
+	if err := bv.Create(ctx, s.db); err != nil {
+		return false, fmt.Errorf("failed to assign branch %s to version %s: %w", b.Name, version.Version, err)
+	}
+
+	res.BranchVersionsCreated++
+
+	return true, nil
+}
@@ -0,0 +1,692 @@
+package gitsync_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitsync"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	testCluster = "test_gitsync"
+)
+
+func setup(t *testing.T, name string) (*sqlx.DB, *test.GitRemote, *gitsync.Syncer) {
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+	remote := test.NewGitRemote(t, testCluster, name)
+
+	repo := &repositorystore.Repository{Name: "repo", URL: remote.Path}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	workPath := filepath.Join(filepath.Dir(remote.Path), "work")
+	syncer, err := gitsync.New(db, &config.Git{WorkPath: &workPath})
+	if err != nil {
+		t.Fatalf("failed to create syncer: %v", err)
+	}
+
+	return db, remote, syncer
+}
+
+func TestSyncer_Sync(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "sync")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 first feature")
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Commit("feature/JIRA-2", "b.txt", "JIRA-2 second feature")
+	remote.Branch("feature/JIRA-3", "master")
+	remote.Commit("feature/JIRA-3", "c.txt", "JIRA-3 third feature")
+	remote.Merge("master", "feature/JIRA-1")
+	remote.Branch("release/1.0.0", "master")
+	remote.Merge("master", "feature/JIRA-2")
+	remote.Branch("release/1.1.0", "master")
+
+	// 2. first sync
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testResult(t, &gitsync.Result{
+		Repositories:          1,
+		BranchesCreated:       6,
+		VersionsCreated:       2,
+		BranchVersionsCreated: 2,
+		BasesDetected:         1,
+	}, res)
+
+	testBranchVersion(t, db, "feature/JIRA-1", "1.0.0")
+	testBranchVersion(t, db, "feature/JIRA-2", "1.1.0")
+	testBranchVersion(t, db, "feature/JIRA-3", "")
+	testBase(t, db, "feature/JIRA-2", "")
+	testBase(t, db, "feature/JIRA-3", "master")
+
+	version := &versionstore.Version{Version: "1.1.0"}
+	if err = version.ReadByVersion(context.Background(), db); err != nil {
+		t.Fatalf("failed to load version: %v", err)
+	}
+
+	release := &branchstore.Branch{Name: "release/1.1.0", RepositoryID: 1}
+	if err = release.ReadByName(context.Background(), db); err != nil {
+		t.Fatalf("failed to load release branch: %v", err)
+	}
+
+	if version.BranchID == nil || *version.BranchID != release.ID {
+		t.Errorf("expected version to be linked to release branch %d but got %v", release.ID, version.BranchID)
+	}
+
+	// 3. second sync
+	remote.Merge("release/1.1.0", "feature/JIRA-3")
+	remote.DeleteBranch("feature/JIRA-1")
+
+	res, err = syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testResult(t, &gitsync.Result{
+		Repositories:          1,
+		BranchesClosed:        1,
+		BranchVersionsCreated: 1,
+	}, res)
+
+	testBranchVersion(t, db, "feature/JIRA-3", "1.1.0")
+
+	closed := &branchstore.Branch{Name: "feature/JIRA-1", RepositoryID: 1}
+	if err = closed.ReadByName(context.Background(), db); err != nil {
+		t.Fatalf("failed to load branch: %v", err)
+	}
+
+	if !closed.Closed {
+		t.Error("expected deleted branch to be closed")
+	}
+
+	if closed.TicketID != "JIRA-1" {
+		t.Errorf("expected ticket ID 'JIRA-1' but got '%s'", closed.TicketID)
+	}
+
+	if err = (&ticketstore.Ticket{Key: "JIRA-1"}).ReadByKey(context.Background(), db); err != nil {
+		t.Errorf("expected ticket of branch to be created but got: %v", err)
+	}
+}
+
+func TestSyncer_Sync_Tags(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "syncTags")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	remote.Branch("release/1.0.0", "master")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 first feature")
+	remote.Merge("release/1.0.0", "feature/JIRA-1")
+
+	if _, err := syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	remote.Tag("v1.0.0", "release/1.0.0")
+	remote.Tag("v2.0.0", "master")
+	remote.Tag("not-a-release", "master")
+	commit := remote.Git("rev-parse", "release/1.0.0")
+
+	// 2. release
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.VersionsReleased != 2 || res.VersionsCreated != 1 || len(res.Errors) > 0 {
+		t.Errorf("expected 2 released and 1 created version but got %#v", res)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err = version.ReadByVersion(context.Background(), db); err != nil {
+		t.Fatalf("failed to load version: %v", err)
+	}
+
+	if !version.IsReleased() || version.ReleaseCommit != commit {
+		t.Errorf("expected version to be released with commit '%s' but got %v / '%s'",
+			commit, version.ReleasedAt, version.ReleaseCommit)
+	}
+
+	testVersionTickets(t, db, version.ID, 1, []string{"JIRA-1"})
+
+	// 3. changes after release don't rewrite the history
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Commit("feature/JIRA-2", "b.txt", "JIRA-2 second feature")
+	remote.Merge("release/1.0.0", "feature/JIRA-2")
+
+	res, err = syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.VersionsReleased != 0 || res.BranchVersionsCreated != 0 {
+		t.Errorf("expected released version not to change but got %#v", res)
+	}
+
+	testVersionTickets(t, db, version.ID, 1, []string{"JIRA-1"})
+	testBranchVersion(t, db, "feature/JIRA-2", "")
+
+	// 4. next release contains only the tickets added since the previous release
+	remote.Branch("feature/JIRA-3", "master")
+	remote.Commit("feature/JIRA-3", "c.txt", "JIRA-3 third feature")
+	remote.Merge("master", "release/1.0.0")
+	remote.Merge("master", "feature/JIRA-3")
+	remote.Tag("v1.1.0", "master")
+
+	if res, err = syncer.Sync(context.Background()); err != nil || res.VersionsReleased != 1 {
+		t.Fatalf("expected one released version but got %#v and error: %v", res, err)
+	}
+
+	next := &versionstore.Version{Version: "1.1.0"}
+	if err = next.ReadByVersion(context.Background(), db); err != nil {
+		t.Fatalf("failed to load version: %v", err)
+	}
+
+	testVersionTickets(t, db, next.ID, 1, []string{"JIRA-2", "JIRA-3"})
+
+	// 5. same tag in another repository is released there too
+	other := test.NewGitRemote(t, testCluster, "syncTagsOther")
+	other.Branch("feature/JIRA-4", "master")
+	other.Commit("feature/JIRA-4", "d.txt", "JIRA-4 fourth feature")
+	other.Merge("master", "feature/JIRA-4")
+	other.Tag("v1.0.0", "master")
+
+	repo := &repositorystore.Repository{Name: "other", URL: other.Path}
+	if err = repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if res, err = syncer.Sync(context.Background()); err != nil || res.VersionsReleased != 1 {
+		t.Fatalf("expected one released version but got %#v and error: %v", res, err)
+	}
+
+	testVersionTickets(t, db, version.ID, repo.ID, []string{"JIRA-4"})
+	testVersionTickets(t, db, version.ID, 1, []string{"JIRA-1"})
+}
+
+func TestSyncer_Sync_SharedReleaseBranch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "syncSharedRelease")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	other := test.NewGitRemote(t, testCluster, "syncSharedReleaseOther")
+
+	repo := &repositorystore.Repository{Name: "other", URL: other.Path}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	remote.Branch("release/1.0.0", "master")
+	other.Branch("release/1.0.0", "master")
+
+	release := &branchstore.Branch{Name: "release/1.0.0", RepositoryID: 1}
+
+	// 2. test
+	for i := 1; i <= 2; i++ {
+		if _, err := syncer.Sync(context.Background()); err != nil {
+			t.Fatalf("expected no error on sync %d but got: %v", i, err)
+		}
+
+		if err := release.ReadByName(context.Background(), db); err != nil {
+			t.Fatalf("failed to load release branch: %v", err)
+		}
+
+		version := &versionstore.Version{Version: "1.0.0"}
+		if err := version.ReadByVersion(context.Background(), db); err != nil {
+			t.Fatalf("failed to load version: %v", err)
+		}
+
+		if version.BranchID == nil || *version.BranchID != release.ID {
+			t.Errorf("expected version to stay linked to release branch %d on sync %d but got %v",
+				release.ID, i, version.BranchID)
+		}
+	}
+}
+
+// writeBackRecorder records the tickets written back by event
+type writeBackRecorder struct {
+	merged   []string
+	released []string
+	err      error
+}
+
+func (w *writeBackRecorder) Merged(
+	_ context.Context,
+	_ *repositorystore.Repository,
+	b *branchstore.Branch,
+	version *versionstore.Version,
+) (int, error) {
+	w.merged = append(w.merged, b.TicketID+"@"+version.Version)
+
+	return 1, w.err
+}
+
+func (w *writeBackRecorder) Released(
+	_ context.Context,
+	_ *repositorystore.Repository,
+	version *versionstore.Version,
+	ticketKey string,
+) (int, error) {
+	w.released = append(w.released, ticketKey+"@"+version.Version)
+
+	return 1, nil
+}
+
+func TestSyncer_Sync_WriteBack(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "syncWriteBack")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	recorder := &writeBackRecorder{err: errors.New("jira is down")}
+	syncer.WithWriteBack(recorder)
+
+	remote.Branch("release/1.0.0", "master")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 first feature")
+	remote.Merge("release/1.0.0", "feature/JIRA-1")
+
+	// 2. merged, failures don't stop the synchronisation
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.TicketActions != 1 || len(res.WriteBackErrors) != 1 || len(res.Errors) > 0 {
+		t.Errorf("expected 1 ticket action and 1 write back error but got %#v", res)
+	}
+
+	// 3. merged is repeated until the version is released
+	recorder.err = nil
+	remote.Tag("v1.0.0", "release/1.0.0")
+
+	res, err = syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.TicketActions != 2 || len(res.WriteBackErrors) != 0 {
+		t.Errorf("expected 2 ticket actions but got %#v", res)
+	}
+
+	if len(recorder.merged) != 2 || recorder.merged[1] != "JIRA-1@1.0.0" {
+		t.Errorf("expected JIRA-1 to be merged twice into 1.0.0 but got %v", recorder.merged)
+	}
+
+	if len(recorder.released) != 1 || recorder.released[0] != "JIRA-1@1.0.0" {
+		t.Errorf("expected JIRA-1 to be released with 1.0.0 but got %v", recorder.released)
+	}
+
+	// 4. nothing to write back after release
+	if res, err = syncer.Sync(context.Background()); err != nil || res.TicketActions != 0 {
+		t.Errorf("expected no ticket actions after release but got %#v: %v", res, err)
+	}
+}
+
+func TestSyncer_Sync_WriteBack_ExistingTags(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "syncWriteBackExistingTags")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	recorder := &writeBackRecorder{}
+	syncer.WithWriteBack(recorder)
+
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 first feature")
+	remote.Merge("master", "feature/JIRA-1")
+	remote.Tag("v1.0.0", "master")
+
+	// 2. tags existing before the first sync are released without writing them back
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.VersionsReleased != 1 || res.TicketActions != 0 || len(res.Errors) > 0 {
+		t.Errorf("expected 1 version released without ticket actions but got %#v", res)
+	}
+
+	if len(recorder.released) != 0 {
+		t.Errorf("expected no tickets written back for existing tags but got %v", recorder.released)
+	}
+
+	testVersionTickets(t, db, 1, 1, []string{"JIRA-1"})
+
+	// 3. tags appearing later are written back
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Commit("feature/JIRA-2", "b.txt", "JIRA-2 second feature")
+	remote.Merge("master", "feature/JIRA-2")
+	remote.Tag("v1.1.0", "master")
+
+	if res, err = syncer.Sync(context.Background()); err != nil || res.VersionsReleased != 1 || res.TicketActions != 1 {
+		t.Errorf("expected 1 version released with 1 ticket action but got %#v: %v", res, err)
+	}
+
+	if len(recorder.released) != 1 || recorder.released[0] != "JIRA-2@1.1.0" {
+		t.Errorf("expected JIRA-2 to be released with 1.1.0 but got %v", recorder.released)
+	}
+}
+
+func TestSyncer_Sync_Bases(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "syncBases")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	remote.Branch("release/1.0.0", "master")
+	remote.Commit("release/1.0.0", "a.txt", "stabilisation of release")
+	remote.Commit("master", "b.txt", "development of next release")
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "c.txt", "JIRA-1 feature")
+	remote.Branch("hotfix/JIRA-2", "release/1.0.0")
+	remote.Commit("hotfix/JIRA-2", "d.txt", "JIRA-2 hotfix")
+
+	// 2. detect
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.BasesDetected != 2 {
+		t.Errorf("expected 2 detected bases but got %d", res.BasesDetected)
+	}
+
+	testBase(t, db, "feature/JIRA-1", "master")
+	testBase(t, db, "hotfix/JIRA-2", "release/1.0.0")
+	testBase(t, db, "release/1.0.0", "")
+
+	// 3. merged branches keep their base
+	remote.Merge("release/1.0.0", "hotfix/JIRA-2")
+	remote.Merge("master", "release/1.0.0")
+
+	res, err = syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.BasesDetected != 0 {
+		t.Errorf("expected no detected bases but got %d", res.BasesDetected)
+	}
+
+	testBase(t, db, "hotfix/JIRA-2", "release/1.0.0")
+}
+
+func TestSyncer_Sync_RepositoryError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, _, syncer := setup(t, "syncError")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	// same name as the working repository, names are only unique per url
+	repo := &repositorystore.Repository{Name: "repo", URL: filepath.Join(".", "not_existing")}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.Repositories != 2 {
+		t.Errorf("expected 2 repositories but got %d", res.Repositories)
+	}
+
+	if len(res.Errors) != 1 || res.Errors[0].RepositoryID != repo.ID || res.Errors[0].Repository != "repo" ||
+		res.Errors[0].Error == "" {
+		t.Errorf("expected error only for repository %d but got %#v", repo.ID, res.Errors)
+	}
+}
+
+func TestSyncer_Sync_LoadRepositoriesError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	db, _, syncer := setup(t, "syncLoadError")
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("unable to close database connection: %v", err)
+	}
+
+	_, err := syncer.Sync(context.Background())
+	if !errors.Is(err, gitsync.ErrLoadRepositories) {
+		t.Errorf("expected error '%v' but got '%v'", gitsync.ErrLoadRepositories, err)
+	}
+
+	if last := syncer.Last(); last == nil || last.Error != gitsync.ErrLoadRepositories.Error() || syncer.Running() {
+		t.Errorf("expected failed run to be the last one but got %#v", last)
+	}
+}
+
+func TestSyncer_Start(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, remote, syncer := setup(t, "syncStart")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	remote.Branch("feature/JIRA-1", "master")
+
+	if syncer.Last() != nil {
+		t.Fatal("expected no result before the first run")
+	}
+
+	// 2. test
+	if err := syncer.Start(context.Background(), logrus.New()); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	for deadline := time.Now().Add(10 * time.Second); syncer.Running(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("synchronisation didn't finish in time")
+		}
+	}
+
+	last := syncer.Last()
+	if last == nil || last.Repositories != 1 || last.BranchesCreated != 2 || last.FinishedAt.Before(last.StartedAt) {
+		t.Errorf("expected result of the background run but got %#v", last)
+	}
+}
+
+func TestNew_InvalidTagPattern(t *testing.T) {
+	pattern := "v(("
+
+	_, err := gitsync.New(nil, &config.Git{TagPattern: &pattern})
+	if !errors.Is(err, gitsync.ErrTagPattern) {
+		t.Errorf("expected error '%v' but got '%v'", gitsync.ErrTagPattern, err)
+	}
+}
+
+func testResult(t *testing.T, expected, actual *gitsync.Result) {
+	t.Helper()
+
+	if len(actual.Errors) > 0 {
+		t.Errorf("expected no errors but got %v", actual.Errors)
+	}
+
+	if expected.Repositories != actual.Repositories {
+		t.Errorf("expected %d repositories but got %d", expected.Repositories, actual.Repositories)
+	}
+
+	if expected.BranchesCreated != actual.BranchesCreated {
+		t.Errorf("expected %d created branches but got %d", expected.BranchesCreated, actual.BranchesCreated)
+	}
+
+	if expected.BranchesClosed != actual.BranchesClosed {
+		t.Errorf("expected %d closed branches but got %d", expected.BranchesClosed, actual.BranchesClosed)
+	}
+
+	if expected.VersionsCreated != actual.VersionsCreated {
+		t.Errorf("expected %d created versions but got %d", expected.VersionsCreated, actual.VersionsCreated)
+	}
+
+	if expected.BranchVersionsCreated != actual.BranchVersionsCreated {
+		t.Errorf(
+			"expected %d created branch versions but got %d",
+			expected.BranchVersionsCreated,
+			actual.BranchVersionsCreated,
+		)
+	}
+
+	if expected.BasesDetected != actual.BasesDetected {
+		t.Errorf("expected %d detected bases but got %d", expected.BasesDetected, actual.BasesDetected)
+	}
+}
+
+func testBase(t *testing.T, db *sqlx.DB, branch, expected string) {
+	t.Helper()
+
+	b := &branchstore.Branch{Name: branch, RepositoryID: 1}
+	if err := b.ReadByName(context.Background(), db); err != nil {
+		t.Fatalf("failed to load branch %s: %v", branch, err)
+	}
+
+	if b.BaseBranch != expected {
+		t.Errorf("expected branch %s to be based on '%s' but got '%s'", branch, expected, b.BaseBranch)
+	}
+}
+
+func testBranchVersion(t *testing.T, db *sqlx.DB, branch, expected string) {
+	t.Helper()
+
+	b := &branchstore.Branch{Name: branch, RepositoryID: 1}
+	if err := b.ReadByName(context.Background(), db); err != nil {
+		t.Fatalf("failed to load branch %s: %v", branch, err)
+	}
+
+	var assigned versionstore.BranchVersions
+	if err := assigned.ReadByBranch(context.Background(), db, b.ID); err != nil {
+		t.Fatalf("failed to load versions of branch %s: %v", branch, err)
+	}
+
+	if expected == "" {
+		if len(assigned) > 0 {
+			t.Errorf("expected branch %s not to be assigned to a version but got %d", branch, len(assigned))
+		}
+
+		return
+	}
+
+	if len(assigned) != 1 {
+		t.Fatalf("expected branch %s to be assigned to exactly one version but got %d", branch, len(assigned))
+	}
+
+	v := &versionstore.Version{ID: assigned[0].VersionID}
+	if err := v.Read(context.Background(), db); err != nil {
+		t.Fatalf("failed to load version: %v", err)
+	}
+
+	if v.Version != expected {
+		t.Errorf("expected branch %s to be assigned to version '%s' but got '%s'", branch, expected, v.Version)
+	}
+}
+
+func testVersionTickets(t *testing.T, db *sqlx.DB, versionID, repositoryID int, expected []string) {
+	t.Helper()
+
+	var tickets versionstore.VersionTickets
+	if err := tickets.ReadByRelease(context.Background(), db, versionID, repositoryID); err != nil {
+		t.Fatalf("failed to load tickets of version: %v", err)
+	}
+
+	actual := tickets.TicketIDs()
+	if len(expected) != len(actual) {
+		t.Fatalf("expected tickets %v but got %v", expected, actual)
+	}
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("expected tickets %v but got %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package gitsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/git/gitcli"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/version/versionname"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+// releaseTag is a tag matching the tag pattern with the version it releases
+type releaseTag struct {
+	version string
+	tag     *gitcli.Tag
+}
+
+// syncTags marks the versions of release tags as released in the repository and snapshots their tickets. The release
+// is tracked per repository, so every repository tagging the same version gets its own release. Releases which are
+// already known are not touched anymore, so later changes on branches don't rewrite the release history. The version
+// itself is released with its first release in any repository. On seeding the releases are recorded without writing
+// them back, so the tags existing before the first completed synchronisation don't release their tickets again.
+func (s *Syncer) syncTags(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	gitRepo *gitcli.Repository,
+	branches map[string]*branchstore.Branch,
+	seeding bool,
+	res *Result,
+) error {
+	tags, err := gitRepo.Tags(ctx)
+	if err != nil {
+		return err
+	}
+
+	var releaseTags []releaseTag
+
+	for _, tag := range tags {
+		if v := versionname.FromTag(s.tagPattern, tag.Name); v != "" {
+			releaseTags = append(releaseTags, releaseTag{version: v, tag: tag})
+		}
+	}
+
+	// older releases first, so the previous release of each tag is known
+	sort.SliceStable(releaseTags, func(i, j int) bool {
+		return versionname.Compare(releaseTags[i].version, releaseTags[j].version) < 0
+	})
+
+	for i, rt := range releaseTags {
+		var previous *gitcli.Tag
+		if i > 0 {
+			previous = releaseTags[i-1].tag
+		}
+
+		if err = s.syncTag(ctx, repo, gitRepo, rt, previous, branches, seeding, res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) syncTag(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	gitRepo *gitcli.Repository,
+	rt releaseTag,
+	previous *gitcli.Tag,
+	branches map[string]*branchstore.Branch,
+	seeding bool,
+	res *Result,
+) error {
+	version := &versionstore.Version{Version: rt.version}
+
+	err := version.ReadByVersion(ctx, s.db)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to load version %s: %w", rt.version, err)
+	}
+
+	released, err := s.isReleased(ctx, version, repo.ID)
+	if err != nil || released {
+		return err
+	}
+
+	if version.ID == 0 {
+		if err = version.Create(ctx, s.db); err != nil {
+			return fmt.Errorf("failed to create version %s: %w", rt.version, err)
+		}
+
+		res.VersionsCreated++
+	}
+
+	if err = s.snapshotTickets(ctx, repo, gitRepo, rt.tag, previous, version, branches); err != nil {
+		return err
+	}
+
+	release := &versionstore.Release{
+		VersionID:     version.ID,
+		RepositoryID:  repo.ID,
+		ReleasedAt:    rt.tag.Date,
+		ReleaseCommit: rt.tag.Commit,
+	}
+
+	if err = release.Create(ctx, s.db); err != nil {
+		return fmt.Errorf("failed to release version %s in repository %s: %w", rt.version, repo.Name, err)
+	}
+
+	if !version.IsReleased() {
+		version.ReleasedAt = &rt.tag.Date
+		version.ReleaseCommit = rt.tag.Commit
+
+		if err = version.Update(ctx, s.db); err != nil {
+			return fmt.Errorf("failed to mark version %s as released: %w", rt.version, err)
+		}
+	}
+
+	res.VersionsReleased++
+
+	if s.writeBack != nil && !seeding {
+		return s.writeBackReleased(ctx, repo, version, res)
+	}
+
+	return nil
+}
+
+// isReleased returns true if the version was already released in the repository
+func (s *Syncer) isReleased(ctx context.Context, version *versionstore.Version, repositoryID int) (bool, error) {
+	if version.ID == 0 {
+		return false, nil
+	}
+
+	release := &versionstore.Release{VersionID: version.ID, RepositoryID: repositoryID}
+
+	err := release.ReadByRepository(ctx, s.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to load release of version %s: %w", version.Version, err)
+	}
+
+	return true, nil
+}
+
+// writeBackReleased writes the release back to the tickets of the version released in the repository
+func (s *Syncer) writeBackReleased(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	version *versionstore.Version,
+	res *Result,
+) error {
+	var tickets versionstore.VersionTickets
+	if err := tickets.ReadByRelease(ctx, s.db, version.ID, repo.ID); err != nil {
+		return err
+	}
+
+	for _, id := range tickets.TicketIDs() {
+		res.addWriteBack(s.writeBack.Released(ctx, repo, version, id))
+	}
+
+	return nil
+}
+
+// snapshotTickets stores the tickets released by the tag in the repository. These are the tickets of the branches
+// merged into the tag but not into the previous release tag and of the branches of the repository assigned to the
+// version, so tickets of earlier releases are not repeated.
+func (s *Syncer) snapshotTickets(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	gitRepo *gitcli.Repository,
+	tag, previous *gitcli.Tag,
+	version *versionstore.Version,
+	branches map[string]*branchstore.Branch,
+) error {
+	earlier := make(map[string]bool)
+
+	if previous != nil {
+		names, err := gitRepo.MergedBranches(ctx, previous.Ref())
+		if err != nil {
+			return err
+		}
+
+		for _, name := range names {
+			earlier[name] = true
+		}
+	}
+
+	merged, err := gitRepo.MergedBranches(ctx, tag.Ref())
+	if err != nil {
+		return err
+	}
+
+	tickets := make(map[string]bool)
+
+	for _, name := range merged {
+		if b, ok := branches[name]; ok && b.TicketID != "" && !earlier[name] {
+			tickets[b.TicketID] = true
+		}
+	}
+
+	var assigned branchstore.Branches
+	if err = assigned.ReadByVersion(ctx, s.db, version.ID); err != nil {
+		return err
+	}
+
+	for _, b := range assigned {
+		if b.TicketID != "" && b.RepositoryID == repo.ID {
+			tickets[b.TicketID] = true
+		}
+	}
+
+	var existing versionstore.VersionTickets
+	if err = existing.ReadByRelease(ctx, s.db, version.ID, repo.ID); err != nil {
+		return err
+	}
+
+	for _, id := range existing.TicketIDs() {
+		delete(tickets, id)
+	}
+
+	ids := make([]string, 0, len(tickets))
+	for id := range tickets {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		vt := &versionstore.VersionTicket{VersionID: version.ID, RepositoryID: repo.ID, TicketID: id}
+		if err = vt.Create(ctx, s.db); err != nil {
+			return fmt.Errorf("failed to add ticket %s to version %s: %w", id, version.Version, err)
+		}
+	}
+
+	return nil
+}
@@ -13,4 +13,5 @@ require (
 	github.com/rebel-l/smis v0.2.1
 	github.com/sirupsen/logrus v1.4.2
 	google.golang.org/appengine v1.4.0 // indirect
+	gopkg.in/yaml.v2 v2.2.8
 )
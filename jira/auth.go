@@ -0,0 +1,80 @@
+package jira
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rebel-l/branma_be/config"
+)
+
+// authenticator adds the credentials to a request
+type authenticator func(req *http.Request)
+
+// newAuthenticator returns the authenticator for the scheme configured, it is nil if no credentials are configured
+func newAuthenticator(cfg *config.Jira) (authenticator, error) {
+	switch cfg.GetAuthType() {
+	case "":
+		return nil, nil
+	case config.AuthBasic:
+		username := cfg.GetUsername()
+		password := cfg.GetPassword()
+
+		return func(req *http.Request) {
+			req.SetBasicAuth(username, password)
+		}, nil
+	case config.AuthToken:
+		username := cfg.GetUsername()
+		if username == "" {
+			return nil, fmt.Errorf("%w: username is required for api tokens", ErrCredentials)
+		}
+
+		token, err := readToken(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(req *http.Request) {
+			req.SetBasicAuth(username, token)
+		}, nil
+	case config.AuthBearer:
+		token, err := readToken(cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(req *http.Request) {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown auth type '%s'", ErrCredentials, cfg.GetAuthType())
+	}
+}
+
+// readToken returns the token from the environment variable or, if it is not set, from the file configured
+func readToken(cfg *config.Jira) (string, error) {
+	if name := cfg.GetTokenEnv(); name != "" {
+		if token := strings.TrimSpace(os.Getenv(name)); token != "" {
+			return token, nil
+		}
+	}
+
+	if cfg.GetTokenFile() == "" {
+		return "", fmt.Errorf("%w: no token found in environment variable '%s'", ErrCredentials, cfg.GetTokenEnv())
+	}
+
+	data, err := ioutil.ReadFile(filepath.Clean(cfg.GetTokenFile()))
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to read token file: %v", ErrCredentials, err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("%w: token file '%s' is empty", ErrCredentials, cfg.GetTokenFile())
+	}
+
+	return token, nil
+}
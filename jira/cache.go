@@ -0,0 +1,100 @@
+package jira
+
+import (
+	"sync"
+	"time"
+)
+
+// cacheEntry is an issue cached until it expires
+type cacheEntry struct {
+	issue   Issue
+	expires time.Time
+}
+
+// cache holds issues by key for a fixed time to live, expired entries are removed at most once per time to live
+type cache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	mutex   sync.Mutex
+	entries map[string]*cacheEntry
+	pruneAt time.Time
+}
+
+// newCache returns a cache for the given time to live, it is nil if the time to live is not positive
+func newCache(ttl time.Duration) *cache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &cache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// get returns a copy of the issue if it is cached and not expired
+func (c *cache) get(key string) (*Issue, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !c.now().Before(e.expires) {
+		delete(c.entries, key)
+
+		return nil, false
+	}
+
+	i := e.issue
+	i.FixVersions = append([]string(nil), e.issue.FixVersions...)
+
+	return &i, true
+}
+
+// set caches a copy of the issues, expired entries are removed if the last removal is older than the time to live
+func (c *cache) set(issues ...*Issue) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+
+	if !now.Before(c.pruneAt) {
+		for key, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, key)
+			}
+		}
+
+		c.pruneAt = now.Add(c.ttl)
+	}
+
+	for _, issue := range issues {
+		e := &cacheEntry{issue: *issue, expires: now.Add(c.ttl)}
+		e.issue.FixVersions = append([]string(nil), issue.FixVersions...)
+		c.entries[issue.Key] = e
+	}
+}
+
+// delete removes the issue from the cache, e.g. after it was changed
+func (c *cache) delete(key string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.entries, key)
+}
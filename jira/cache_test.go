@@ -0,0 +1,49 @@
+package jira
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache_Prune(t *testing.T) {
+	now := time.Now()
+	c := newCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.set(&Issue{Key: "JIRA-1"})
+
+	now = now.Add(30 * time.Second)
+	c.set(&Issue{Key: "JIRA-2"})
+
+	// JIRA-1 expired and the last removal is as old as the time to live
+	now = now.Add(40 * time.Second)
+	c.set(&Issue{Key: "JIRA-3"})
+
+	if len(c.entries) != 2 {
+		t.Errorf("expected expired JIRA-1 to be removed but got %d entries", len(c.entries))
+	}
+
+	// JIRA-2 expired, but the last removal is younger than the time to live
+	now = now.Add(30 * time.Second)
+	c.set(&Issue{Key: "JIRA-4"})
+
+	if len(c.entries) != 3 {
+		t.Errorf("expected expired JIRA-2 to be kept until the next removal but got %d entries", len(c.entries))
+	}
+
+	if _, ok := c.get("JIRA-2"); ok {
+		t.Errorf("expected expired JIRA-2 not to be served")
+	}
+
+	// JIRA-3 expired
+	now = now.Add(30 * time.Second)
+	c.set(&Issue{Key: "JIRA-5"})
+
+	if len(c.entries) != 2 {
+		t.Errorf("expected expired entries to be removed but got %d entries", len(c.entries))
+	}
+
+	if _, ok := c.get("JIRA-4"); !ok {
+		t.Errorf("expected JIRA-4 to be cached")
+	}
+}
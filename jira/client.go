@@ -0,0 +1,400 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rebel-l/branma_be/config"
+)
+
+const (
+	// DefaultPageSize defines the number of issues requested per page of a search and per batch of issues looked up
+	// by key
+	DefaultPageSize = 50
+
+	// DefaultBackoff defines the time waited before the first retry of a rate limited request without Retry-After
+	// header, it is doubled with every retry
+	DefaultBackoff = time.Second
+
+	pathBrowse = "/browse/"
+	pathIssue  = "/rest/api/2/issue/"
+	pathSearch = "/rest/api/2/search"
+
+	fields  = "summary,status,issuetype,parent,assignee,priority,fixVersions"
+	timeout = 30 * time.Second
+
+	maxErrorBody = 512
+	maxBackoff   = time.Minute
+)
+
+// Client fetches issues from JIRA
+type Client interface {
+	// Issue returns the issue with the given key
+	Issue(ctx context.Context, key string) (*Issue, error)
+
+	// Search returns all issues matching the JQL query, the pages of the result are fetched one after the other
+	Search(ctx context.Context, jql string) ([]*Issue, error)
+
+	// Issues returns the issues with the given keys, keys of issues which don't exist are ignored
+	Issues(ctx context.Context, keys []string) ([]*Issue, error)
+
+	// Invalidate removes the issue from the cache, so it is fetched again the next time, e.g. after JIRA reported a
+	// change by webhook
+	Invalidate(key string)
+}
+
+// REST is a client using the REST API of JIRA. Requests rate limited by JIRA are retried with exponential backoff and
+// issues are cached if a time to live is configured.
+type REST struct {
+	counters   counters
+	baseURL    string
+	auth       authenticator
+	cfgErr     error
+	pageSize   int
+	maxRetries int
+	backoff    time.Duration
+	cache      *cache
+	httpClient *http.Client
+}
+
+// New returns a client for the JIRA configured. The auth scheme is chosen by the configuration, if the credentials
+// can't be resolved every request fails with ErrCredentials. An invalid cache ttl fails every request with
+// ErrInvalidConfig.
+func New(cfg *config.Jira) *REST {
+	auth, err := newAuthenticator(cfg)
+
+	var ttl time.Duration
+
+	if cfg.GetCacheTTL() != "" {
+		var ttlErr error
+
+		ttl, ttlErr = time.ParseDuration(cfg.GetCacheTTL())
+		if ttlErr != nil && err == nil {
+			err = fmt.Errorf("%w: cache ttl %s", ErrInvalidConfig, cfg.GetCacheTTL())
+		}
+	}
+
+	return &REST{
+		baseURL:    strings.TrimSuffix(cfg.GetBaseURL(), "/"),
+		auth:       auth,
+		cfgErr:     err,
+		pageSize:   DefaultPageSize,
+		maxRetries: cfg.GetMaxRetries(),
+		backoff:    DefaultBackoff,
+		cache:      newCache(ttl),
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+// WithRetries sets how often rate limited requests are retried and the time waited before the first retry if JIRA
+// doesn't send a Retry-After header
+func (r *REST) WithRetries(maxRetries int, backoff time.Duration) *REST {
+	if maxRetries >= 0 {
+		r.maxRetries = maxRetries
+	}
+
+	if backoff > 0 {
+		r.backoff = backoff
+	}
+
+	return r
+}
+
+// WithCache sets how long issues are cached, a time to live which is not positive disables the cache
+func (r *REST) WithCache(ttl time.Duration) *REST {
+	r.cache = newCache(ttl)
+
+	return r
+}
+
+// Stats returns the number of requests sent and the lookups served by the cache
+func (r *REST) Stats() Stats {
+	return r.counters.stats()
+}
+
+// WithPageSize sets the number of issues requested per page of a search
+func (r *REST) WithPageSize(size int) *REST {
+	if size > 0 {
+		r.pageSize = size
+	}
+
+	return r
+}
+
+// Issue returns the issue with the given key, it is served by the cache if possible
+func (r *REST) Issue(ctx context.Context, key string) (*Issue, error) {
+	if issue, ok := r.lookup(key); ok {
+		return issue, nil
+	}
+
+	query := url.Values{}
+	query.Set("fields", fields)
+
+	res := &restIssue{}
+	if err := r.get(ctx, pathIssue+url.PathEscape(key), query, res); err != nil {
+		return nil, err
+	}
+
+	issue := res.issue()
+	r.cache.set(issue)
+
+	return issue, nil
+}
+
+// Issues returns the issues with the given keys, keys of issues which don't exist are ignored. Issues not cached are
+// searched in batches of the page size with `key in (...)`.
+func (r *REST) Issues(ctx context.Context, keys []string) ([]*Issue, error) {
+	issues := []*Issue{}
+
+	var missing []string
+
+	for _, key := range keys {
+		if issue, ok := r.lookup(key); ok {
+			issues = append(issues, issue)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+
+	for start := 0; start < len(missing); start += r.pageSize {
+		end := start + r.pageSize
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		found, err := r.Search(ctx, "key in ("+strings.Join(missing[start:end], ", ")+")")
+		if err != nil {
+			return nil, err
+		}
+
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
+
+// Invalidate removes the issue from the cache, so it is fetched again the next time
+func (r *REST) Invalidate(key string) {
+	r.cache.delete(key)
+}
+
+// lookup returns the issue from the cache and counts the hit or miss
+func (r *REST) lookup(key string) (*Issue, bool) {
+	issue, ok := r.cache.get(key)
+	if ok {
+		atomic.AddInt64(&r.counters.cacheHits, 1)
+	} else {
+		atomic.AddInt64(&r.counters.cacheMisses, 1)
+	}
+
+	return issue, ok
+}
+
+// Link returns the url of the issue in the web interface of JIRA, it is empty if no base url is configured
+func (r *REST) Link(key string) string {
+	if r.baseURL == "" || key == "" {
+		return ""
+	}
+
+	return r.baseURL + pathBrowse + url.PathEscape(key)
+}
+
+// Search returns all issues matching the JQL query, the pages of the result are fetched one after the other. Unknown
+// values like keys of issues which don't exist only cause warnings, so they don't fail the whole query.
+func (r *REST) Search(ctx context.Context, jql string) ([]*Issue, error) {
+	issues := []*Issue{}
+
+	for {
+		query := url.Values{}
+		query.Set("jql", jql)
+		query.Set("validateQuery", "warn")
+		query.Set("fields", fields)
+		query.Set("startAt", strconv.Itoa(len(issues)))
+		query.Set("maxResults", strconv.Itoa(r.pageSize))
+
+		res := &searchResult{}
+		if err := r.get(ctx, pathSearch, query, res); err != nil {
+			return nil, err
+		}
+
+		page := make([]*Issue, 0, len(res.Issues))
+		for _, i := range res.Issues {
+			page = append(page, i.issue())
+		}
+
+		r.cache.set(page...)
+		issues = append(issues, page...)
+
+		if len(res.Issues) == 0 || len(issues) >= res.Total {
+			return issues, nil
+		}
+	}
+}
+
+func (r *REST) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	return r.do(ctx, http.MethodGet, path+"?"+query.Encode(), nil, v)
+}
+
+// do sends the request with the body encoded as JSON and decodes the response into v, if v is nil the response body
+// is ignored. Rate limited requests are retried after the time requested by JIRA or with exponential backoff.
+func (r *REST) do(ctx context.Context, method, path string, body, v interface{}) error {
+	if r.baseURL == "" {
+		return ErrNotConfigured
+	}
+
+	if r.cfgErr != nil {
+		return r.cfgErr
+	}
+
+	var payload []byte
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		payload = b
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := r.send(ctx, method, path, payload, v)
+
+		var rateLimitErr *RateLimitError
+		if !errors.As(err, &rateLimitErr) {
+			return err
+		}
+
+		atomic.AddInt64(&r.counters.rateLimited, 1)
+
+		if attempt >= r.maxRetries {
+			return err
+		}
+
+		if err = r.wait(ctx, attempt, rateLimitErr.RetryAfter); err != nil {
+			return err
+		}
+
+		atomic.AddInt64(&r.counters.retries, 1)
+	}
+}
+
+// wait blocks for the time requested by JIRA or, if not given, the backoff doubled with every attempt
+func (r *REST) wait(ctx context.Context, attempt int, retryAfter time.Duration) error {
+	d := retryAfter
+	if d <= 0 {
+		d = r.backoff << uint(attempt)
+		if d <= 0 || d > maxBackoff {
+			d = maxBackoff
+		}
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// send sends a single request
+func (r *REST) send(ctx context.Context, method, path string, payload []byte, v interface{}) error {
+	var reader io.Reader
+	if payload != nil {
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, r.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if r.auth != nil {
+		r.auth(req)
+	}
+
+	atomic.AddInt64(&r.counters.requests, 1)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to jira failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err = checkResponse(resp); err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnexpectedResponse, err)
+	}
+
+	return nil
+}
+
+func checkResponse(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusNoContent:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrAuthentication
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return &RateLimitError{RetryAfter: retryAfter(resp.Header.Get("Retry-After"))}
+	case http.StatusBadRequest:
+		return fmt.Errorf("%w: %s", ErrInvalidQuery, errorBody(resp.Body))
+	default:
+		return fmt.Errorf("%w: status %d: %s", ErrUnexpectedResponse, resp.StatusCode, errorBody(resp.Body))
+	}
+}
+
+// retryAfter parses the header Retry-After, which is either given in seconds or as date
+func retryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil && date.After(time.Now()) {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+func errorBody(body io.Reader) string {
+	b, _ := ioutil.ReadAll(io.LimitReader(body, maxErrorBody))
+
+	return strings.TrimSpace(string(b))
+}
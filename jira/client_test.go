@@ -0,0 +1,437 @@
+package jira_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+)
+
+var _ jira.Client = &jira.REST{}
+
+func setup(t *testing.T) (*jirafake.Server, *jira.REST) {
+	t.Helper()
+
+	server := jirafake.NewServer()
+	server.SetCredentials("branma", "secret")
+
+	username := "branma"
+	password := "secret"
+
+	client := jira.New(&config.Jira{BaseURL: &server.URL, Username: &username, Password: &password})
+
+	return server, client
+}
+
+func TestREST_Issue(t *testing.T) {
+	server, client := setup(t)
+	defer server.Close()
+
+	expected := &jira.Issue{
+		Key:            "JIRA-2",
+		Summary:        "a nice summary",
+		Status:         "In Progress",
+		StatusCategory: "indeterminate",
+		Type:           "Bug",
+		Parent:         "JIRA-1",
+		Assignee:       "Jane Doe",
+		Priority:       "High",
+		FixVersions:    []string{"1.0.0", "1.1.0"},
+	}
+	server.AddIssue(expected)
+
+	// 1. success
+	actual, err := client.Issue(context.Background(), "JIRA-2")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testIssue(t, expected, actual)
+
+	// 2. not found
+	_, err = client.Issue(context.Background(), "JIRA-3")
+	if !errors.Is(err, jira.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrNotFound, err)
+	}
+}
+
+func TestREST_Auth(t *testing.T) { // nolint:funlen
+	server := jirafake.NewServer()
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1"})
+
+	dir, err := ioutil.TempDir("", "jira")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(tokenFile, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err = ioutil.WriteFile(emptyFile, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tokenEnv := "BRANMA_TEST_JIRA_TOKEN"
+	if err = os.Setenv(tokenEnv, "env-token"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = os.Unsetenv(tokenEnv)
+	}()
+
+	unsetEnv := "BRANMA_TEST_JIRA_TOKEN_UNSET"
+	missingFile := filepath.Join(dir, "missing")
+	username := "branma@example.com"
+	password := "secret"
+	unknown := "digest"
+
+	testCases := []struct {
+		name        string
+		cfg         *config.Jira
+		username    string
+		password    string
+		token       string
+		expectedErr error
+	}{
+		{
+			name:     "password",
+			cfg:      &config.Jira{Username: &username, Password: &password},
+			username: username,
+			password: password,
+		},
+		{
+			name:     "api token from env",
+			cfg:      &config.Jira{Username: &username, TokenEnv: &tokenEnv, TokenFile: &tokenFile},
+			username: username,
+			password: "env-token",
+		},
+		{
+			name:     "api token from file if env is not set",
+			cfg:      &config.Jira{Username: &username, TokenEnv: &unsetEnv, TokenFile: &tokenFile},
+			username: username,
+			password: "file-token",
+		},
+		{
+			name:  "bearer token",
+			cfg:   &config.Jira{TokenFile: &tokenFile},
+			token: "file-token",
+		},
+		{
+			name:        "wrong bearer token",
+			cfg:         &config.Jira{TokenEnv: &tokenEnv},
+			token:       "file-token",
+			expectedErr: jira.ErrAuthentication,
+		},
+		{
+			name:        "api token without username",
+			cfg:         &config.Jira{AuthType: strPtr(config.AuthToken), TokenEnv: &tokenEnv},
+			expectedErr: jira.ErrCredentials,
+		},
+		{
+			name:        "env not set",
+			cfg:         &config.Jira{TokenEnv: &unsetEnv},
+			expectedErr: jira.ErrCredentials,
+		},
+		{
+			name:        "file missing",
+			cfg:         &config.Jira{TokenFile: &missingFile},
+			expectedErr: jira.ErrCredentials,
+		},
+		{
+			name:        "file empty",
+			cfg:         &config.Jira{TokenFile: &emptyFile},
+			expectedErr: jira.ErrCredentials,
+		},
+		{
+			name:        "unknown auth type",
+			cfg:         &config.Jira{AuthType: &unknown, Username: &username},
+			expectedErr: jira.ErrCredentials,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			server.SetCredentials(testCase.username, testCase.password)
+			server.SetToken(testCase.token)
+
+			testCase.cfg.BaseURL = &server.URL
+
+			_, err := jira.New(testCase.cfg).Issue(context.Background(), "JIRA-1")
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestREST_Issue_Errors(t *testing.T) {
+	server, _ := setup(t)
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1"})
+
+	// 1. authentication
+	username := "branma"
+	password := "wrong"
+	client := jira.New(&config.Jira{BaseURL: &server.URL, Username: &username, Password: &password})
+
+	_, err := client.Issue(context.Background(), "JIRA-1")
+	if !errors.Is(err, jira.ErrAuthentication) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrAuthentication, err)
+	}
+
+	// 2. rate limit
+	password = "secret"
+	client = jira.New(&config.Jira{BaseURL: &server.URL, Username: &username, Password: &password}).WithRetries(0, 0)
+
+	server.RateLimit(1, 3*time.Second)
+
+	_, err = client.Issue(context.Background(), "JIRA-1")
+	if !errors.Is(err, jira.ErrRateLimited) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrRateLimited, err)
+	}
+
+	var rateLimit *jira.RateLimitError
+	if !errors.As(err, &rateLimit) || rateLimit.RetryAfter != 3*time.Second {
+		t.Errorf("expected to retry after 3s but got '%v'", err)
+	}
+
+	// 3. not configured
+	_, err = jira.New(&config.Jira{}).Issue(context.Background(), "JIRA-1")
+	if !errors.Is(err, jira.ErrNotConfigured) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrNotConfigured, err)
+	}
+}
+
+func TestREST_Retries(t *testing.T) {
+	server, client := setup(t)
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1"})
+
+	client.WithRetries(2, time.Millisecond)
+
+	// 1. retried until successful
+	server.RateLimit(2, 0)
+
+	if _, err := client.Issue(context.Background(), "JIRA-1"); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	stats := client.Stats()
+	if stats.Requests != 3 || stats.RateLimited != 2 || stats.Retries != 2 {
+		t.Errorf("expected 3 requests with 2 retries but got %#v", stats)
+	}
+
+	// 2. retries exhausted
+	server.RateLimit(3, 0)
+
+	if _, err := client.Issue(context.Background(), "JIRA-1"); !errors.Is(err, jira.ErrRateLimited) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrRateLimited, err)
+	}
+
+	// 3. canceled while waiting
+	server.RateLimit(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.Issue(ctx, "JIRA-1"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected error '%v' but got '%v'", context.DeadlineExceeded, err)
+	}
+}
+
+func TestREST_Cache(t *testing.T) { // nolint:funlen
+	server, client := setup(t)
+	defer server.Close()
+
+	client.WithCache(time.Hour).WithPageSize(2)
+
+	for _, key := range []string{"JIRA-1", "JIRA-2", "JIRA-3", "JIRA-4"} {
+		server.AddIssue(&jira.Issue{Key: key, Status: "Open"})
+	}
+
+	// 1. issue is cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.Issue(context.Background(), "JIRA-1"); err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+	}
+
+	if server.Requests() != 1 {
+		t.Errorf("expected 1 request but got %d", server.Requests())
+	}
+
+	// 2. only issues not cached are searched in batches
+	issues, err := client.Issues(context.Background(), []string{"JIRA-1", "JIRA-2", "JIRA-3", "JIRA-4", "JIRA-9"})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(issues) != 4 || server.Requests() != 3 {
+		t.Errorf("expected 4 issues with 2 requests but got %d issues with %d requests",
+			len(issues), server.Requests()-1)
+	}
+
+	stats := client.Stats()
+	if stats.CacheHits != 2 || stats.CacheMisses != 5 || stats.Requests != 3 {
+		t.Errorf("expected 2 cache hits and 5 misses but got %#v", stats)
+	}
+
+	if _, err = client.Issues(context.Background(), []string{"JIRA-2", "JIRA-3"}); err != nil || server.Requests() != 3 {
+		t.Errorf("expected issues found by search to be cached but got %d requests: %v", server.Requests(), err)
+	}
+
+	// 3. changes remove the issue from the cache
+	if err = client.AddFixVersion(context.Background(), "JIRA-1", "1.0.0"); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	issue, err := client.Issue(context.Background(), "JIRA-1")
+	if err != nil || len(issue.FixVersions) != 1 {
+		t.Errorf("expected changed issue to be fetched again but got %#v: %v", issue, err)
+	}
+
+	requests := server.Requests()
+	client.Invalidate("JIRA-2")
+
+	if _, err = client.Issues(context.Background(), []string{"JIRA-2", "JIRA-3"}); err != nil ||
+		server.Requests() != requests+1 {
+		t.Errorf("expected invalidated issue to be fetched again but got %d requests: %v",
+			server.Requests()-requests, err)
+	}
+
+	// 4. invalid ttl
+	ttl := "soon"
+	client = jira.New(&config.Jira{BaseURL: &server.URL, CacheTTL: &ttl})
+
+	if _, err = client.Issue(context.Background(), "JIRA-1"); !errors.Is(err, jira.ErrInvalidConfig) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrInvalidConfig, err)
+	}
+}
+
+func TestREST_Search(t *testing.T) { // nolint:funlen
+	server, client := setup(t)
+	defer server.Close()
+
+	client.WithPageSize(2)
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", Status: "In Progress", Type: "Story"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-2", Status: "In Progress", Type: "Bug"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-3", Status: "In Progress", Type: "Story"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-4", Status: "Done", Type: "Story"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-5", Status: "In Progress", Type: "Story", FixVersions: []string{"1.0.0"}})
+	server.AddIssue(&jira.Issue{Key: "OTHER-1", Status: "In Progress", Type: "Story"})
+
+	testCases := []struct {
+		name        string
+		jql         string
+		expected    []string
+		requests    int
+		expectedErr error
+	}{
+		{
+			name:     "pagination",
+			jql:      `project = JIRA AND status = "In Progress" ORDER BY key`,
+			expected: []string{"JIRA-1", "JIRA-2", "JIRA-3", "JIRA-5"},
+			requests: 2,
+		},
+		{
+			name:     "keys",
+			jql:      "key in (JIRA-1, JIRA-4, JIRA-9) AND type != Bug",
+			expected: []string{"JIRA-1", "JIRA-4"},
+			requests: 1,
+		},
+		{
+			name:     "fix version",
+			jql:      `fixVersion = "1.0.0"`,
+			expected: []string{"JIRA-5"},
+			requests: 1,
+		},
+		{
+			name:     "no match",
+			jql:      "project = NONE",
+			expected: []string{},
+			requests: 1,
+		},
+		{
+			name:        "invalid",
+			jql:         "unknown = 1",
+			expectedErr: jira.ErrInvalidQuery,
+			requests:    1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			before := server.Requests()
+
+			issues, err := client.Search(context.Background(), testCase.jql)
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+			}
+
+			if requests := server.Requests() - before; requests != testCase.requests {
+				t.Errorf("expected %d requests but got %d", testCase.requests, requests)
+			}
+
+			if testCase.expectedErr != nil {
+				return
+			}
+
+			if len(issues) != len(testCase.expected) {
+				t.Fatalf("expected %d issues but got %d", len(testCase.expected), len(issues))
+			}
+
+			for i, key := range testCase.expected {
+				if issues[i].Key != key {
+					t.Errorf("expected issue '%s' at position %d but got '%s'", key, i, issues[i].Key)
+				}
+			}
+		})
+	}
+}
+
+func testIssue(t *testing.T, expected, actual *jira.Issue) {
+	t.Helper()
+
+	if expected.Key != actual.Key || expected.Summary != actual.Summary || expected.Status != actual.Status ||
+		expected.StatusCategory != actual.StatusCategory || expected.Type != actual.Type ||
+		expected.Parent != actual.Parent || expected.Assignee != actual.Assignee ||
+		expected.Priority != actual.Priority {
+		t.Errorf("expected issue %#v but got %#v", expected, actual)
+	}
+
+	if len(expected.FixVersions) != len(actual.FixVersions) {
+		t.Fatalf("expected fix versions %v but got %v", expected.FixVersions, actual.FixVersions)
+	}
+
+	for i := range expected.FixVersions {
+		if expected.FixVersions[i] != actual.FixVersions[i] {
+			t.Errorf("expected fix versions %v but got %v", expected.FixVersions, actual.FixVersions)
+		}
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,55 @@
+package jira
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrNotConfigured occurs if no base url for JIRA is configured
+	ErrNotConfigured = errors.New("jira is not configured")
+
+	// ErrCredentials occurs if the credentials configured are incomplete or the token can't be read
+	ErrCredentials = errors.New("jira credentials are not configured properly")
+
+	// ErrInvalidConfig occurs if a setting of JIRA like the cache ttl can't be parsed
+	ErrInvalidConfig = errors.New("jira configuration is invalid")
+
+	// ErrAuthentication occurs if JIRA rejects the credentials or the user is not allowed to access the resource
+	ErrAuthentication = errors.New("jira authentication failed")
+
+	// ErrNotFound occurs if the requested issue doesn't exist or is not visible for the user
+	ErrNotFound = errors.New("jira issue not found")
+
+	// ErrRateLimited occurs if JIRA rejects requests due to too many requests in a period of time
+	ErrRateLimited = errors.New("jira rate limit exceeded")
+
+	// ErrInvalidQuery occurs if JIRA rejects a JQL query
+	ErrInvalidQuery = errors.New("invalid jira query")
+
+	// ErrTransitionNotFound occurs if the issue can't be moved by a transition or to a status with the given name
+	ErrTransitionNotFound = errors.New("jira transition not found")
+
+	// ErrUnexpectedResponse occurs if JIRA responds with an unexpected status code or body
+	ErrUnexpectedResponse = errors.New("unexpected response from jira")
+)
+
+// RateLimitError provides the time to wait before the next request as requested by JIRA. It matches ErrRateLimited.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+// Error returns the message of the error
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter == 0 {
+		return ErrRateLimited.Error()
+	}
+
+	return fmt.Sprintf("%s, retry after %s", ErrRateLimited.Error(), e.RetryAfter)
+}
+
+// Is returns true if target is ErrRateLimited, so the error can be checked with errors.Is
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
@@ -0,0 +1,194 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rebel-l/branma_be/config"
+)
+
+// Linker builds links to issues in the web interface of JIRA
+type Linker interface {
+	// Link returns the url of the issue with the given key, it is empty if no JIRA is responsible for the issue
+	Link(key string) string
+}
+
+// instance is a JIRA instance with its name used in errors
+type instance struct {
+	name   string
+	client *REST
+}
+
+// Instances is a client for several JIRA instances, it routes issue keys by their project to the instance tracking
+// the project. Projects not assigned to any instance are routed to the first instance without project keys.
+type Instances struct {
+	instances []*instance
+	projects  map[string]*instance
+	fallback  *instance
+}
+
+// NewInstances returns a client for the JIRA instances configured
+func NewInstances(cfgs []*config.Jira) *Instances {
+	i := &Instances{projects: make(map[string]*instance)}
+
+	for _, cfg := range cfgs {
+		inst := &instance{name: cfg.GetName(), client: New(cfg)}
+		if inst.name == "" {
+			inst.name = cfg.GetBaseURL()
+		}
+
+		i.instances = append(i.instances, inst)
+
+		if len(cfg.GetProjectKeys()) == 0 && i.fallback == nil {
+			i.fallback = inst
+		}
+
+		for _, project := range cfg.GetProjectKeys() {
+			if _, ok := i.projects[project]; !ok {
+				i.projects[project] = inst
+			}
+		}
+	}
+
+	return i
+}
+
+// Issue returns the issue with the given key from the instance tracking its project
+func (i *Instances) Issue(ctx context.Context, key string) (*Issue, error) {
+	client, err := i.client(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.Issue(ctx, key)
+}
+
+// Search returns all issues matching the JQL query in any instance. Issues are only returned by the instance tracking
+// their project, so an issue key is never returned twice.
+func (i *Instances) Search(ctx context.Context, jql string) ([]*Issue, error) {
+	if len(i.instances) == 0 {
+		return nil, ErrNotConfigured
+	}
+
+	issues := []*Issue{}
+
+	for _, inst := range i.instances {
+		found, err := inst.client.Search(ctx, jql)
+		if err != nil {
+			return nil, fmt.Errorf("jira instance %s: %w", inst.name, err)
+		}
+
+		for _, issue := range found {
+			if i.route(issue.Key) == inst {
+				issues = append(issues, issue)
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// Issues returns the issues with the given keys from the instances tracking their projects, keys not routed to any
+// instance are ignored like keys of issues which don't exist
+func (i *Instances) Issues(ctx context.Context, keys []string) ([]*Issue, error) {
+	byInstance := make(map[*instance][]string)
+
+	for _, key := range keys {
+		if inst := i.route(key); inst != nil {
+			byInstance[inst] = append(byInstance[inst], key)
+		}
+	}
+
+	issues := []*Issue{}
+
+	for _, inst := range i.instances {
+		if len(byInstance[inst]) == 0 {
+			continue
+		}
+
+		found, err := inst.client.Issues(ctx, byInstance[inst])
+		if err != nil {
+			return nil, fmt.Errorf("jira instance %s: %w", inst.name, err)
+		}
+
+		issues = append(issues, found...)
+	}
+
+	return issues, nil
+}
+
+// Invalidate removes the issue from the cache of the instance tracking its project
+func (i *Instances) Invalidate(key string) {
+	if inst := i.route(key); inst != nil {
+		inst.client.Invalidate(key)
+	}
+}
+
+// Stats returns the sum of the stats of all instances
+func (i *Instances) Stats() Stats {
+	var stats Stats
+
+	for _, inst := range i.instances {
+		stats = stats.add(inst.client.Stats())
+	}
+
+	return stats
+}
+
+// AddComment adds a comment to the issue in the instance tracking its project
+func (i *Instances) AddComment(ctx context.Context, key, text string) error {
+	client, err := i.client(key)
+	if err != nil {
+		return err
+	}
+
+	return client.AddComment(ctx, key, text)
+}
+
+// AddFixVersion adds the version to the fix versions of the issue in the instance tracking its project
+func (i *Instances) AddFixVersion(ctx context.Context, key, version string) error {
+	client, err := i.client(key)
+	if err != nil {
+		return err
+	}
+
+	return client.AddFixVersion(ctx, key, version)
+}
+
+// Transition moves the issue in the instance tracking its project
+func (i *Instances) Transition(ctx context.Context, key, name string) error {
+	client, err := i.client(key)
+	if err != nil {
+		return err
+	}
+
+	return client.Transition(ctx, key, name)
+}
+
+// Link returns the url of the issue in the instance tracking its project
+func (i *Instances) Link(key string) string {
+	inst := i.route(key)
+	if inst == nil {
+		return ""
+	}
+
+	return inst.client.Link(key)
+}
+
+// client returns the client of the instance tracking the project of the issue
+func (i *Instances) client(key string) (*REST, error) {
+	inst := i.route(key)
+	if inst == nil {
+		return nil, fmt.Errorf("%w: no instance for issue %s", ErrNotConfigured, key)
+	}
+
+	return inst.client, nil
+}
+
+func (i *Instances) route(key string) *instance {
+	if inst, ok := i.projects[(&Issue{Key: key}).Project()]; ok {
+		return inst
+	}
+
+	return i.fallback
+}
@@ -0,0 +1,165 @@
+package jira_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+)
+
+var (
+	_ jira.Client = &jira.Instances{}
+	_ jira.Linker = &jira.Instances{}
+)
+
+func setupInstances(t *testing.T) (*jirafake.Server, *jirafake.Server, *jira.Instances) {
+	t.Helper()
+
+	cloud := jirafake.NewServer()
+	cloud.AddIssue(&jira.Issue{Key: "NEW-1", Status: "In Progress"})
+	cloud.AddIssue(&jira.Issue{Key: "OTHER-1", Status: "In Progress"})
+
+	legacy := jirafake.NewServer()
+	legacy.AddIssue(&jira.Issue{Key: "OLD-1", Status: "In Progress"})
+	legacy.AddIssue(&jira.Issue{Key: "NEW-1", Status: "Done"})
+
+	cloudName := "cloud"
+	legacyName := "legacy"
+
+	client := jira.NewInstances([]*config.Jira{
+		{Name: &cloudName, BaseURL: &cloud.URL},
+		{Name: &legacyName, BaseURL: &legacy.URL, ProjectKeys: []string{"OLD"}},
+	})
+
+	return cloud, legacy, client
+}
+
+func TestInstances_Issue(t *testing.T) {
+	cloud, legacy, client := setupInstances(t)
+	defer cloud.Close()
+	defer legacy.Close()
+
+	// 1. project of instance
+	issue, err := client.Issue(context.Background(), "OLD-1")
+	if err != nil || issue.Key != "OLD-1" {
+		t.Errorf("expected issue OLD-1 from legacy instance but got %#v, %v", issue, err)
+	}
+
+	// 2. project of no instance goes to fallback
+	issue, err = client.Issue(context.Background(), "NEW-1")
+	if err != nil || issue.Status != "In Progress" {
+		t.Errorf("expected issue NEW-1 from cloud instance but got %#v, %v", issue, err)
+	}
+
+	// 3. no fallback
+	legacyName := "legacy"
+	client = jira.NewInstances([]*config.Jira{{Name: &legacyName, BaseURL: &legacy.URL, ProjectKeys: []string{"OLD"}}})
+
+	_, err = client.Issue(context.Background(), "NEW-1")
+	if !errors.Is(err, jira.ErrNotConfigured) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrNotConfigured, err)
+	}
+}
+
+func TestInstances_Search(t *testing.T) {
+	cloud, legacy, client := setupInstances(t)
+	defer cloud.Close()
+	defer legacy.Close()
+
+	// 1. issues from the instances tracking them
+	issues, err := client.Search(context.Background(), `status = "In Progress"`)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues but got %d", len(issues))
+	}
+
+	for _, issue := range issues {
+		if issue.Status != "In Progress" {
+			t.Errorf("expected issue %s to be in progress but got '%s'", issue.Key, issue.Status)
+		}
+	}
+
+	// 2. issue of another instance is ignored
+	issues, err = client.Search(context.Background(), `status = Done`)
+	if err != nil || len(issues) != 0 {
+		t.Errorf("expected no issues but got %d, %v", len(issues), err)
+	}
+
+	// 3. no instances
+	_, err = jira.NewInstances(nil).Search(context.Background(), "status = Done")
+	if !errors.Is(err, jira.ErrNotConfigured) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrNotConfigured, err)
+	}
+
+	// 4. instance fails
+	legacy.SetCredentials("branma", "secret")
+
+	_, err = client.Search(context.Background(), `status = Done`)
+	if !errors.Is(err, jira.ErrAuthentication) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrAuthentication, err)
+	}
+}
+
+func TestInstances_Issues(t *testing.T) {
+	cloud, legacy, client := setupInstances(t)
+	defer cloud.Close()
+	defer legacy.Close()
+
+	// 1. keys are looked up in the instances tracking them
+	issues, err := client.Issues(context.Background(), []string{"NEW-1", "OLD-1", "OLD-2"})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(issues) != 2 || issues[0].Key != "NEW-1" || issues[0].Status != "In Progress" || issues[1].Key != "OLD-1" {
+		t.Errorf("expected NEW-1 from cloud and OLD-1 from legacy instance but got %#v", issues)
+	}
+
+	stats := client.Stats()
+	if stats.Requests != 2 || stats.CacheMisses != 3 || stats.CacheHitRate != 0 {
+		t.Errorf("expected 2 requests and 3 cache misses but got %#v", stats)
+	}
+
+	// 2. instance fails
+	legacy.SetCredentials("branma", "secret")
+
+	_, err = client.Issues(context.Background(), []string{"OLD-1"})
+	if !errors.Is(err, jira.ErrAuthentication) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrAuthentication, err)
+	}
+}
+
+func TestInstances_Link(t *testing.T) {
+	cloud := "https://example.atlassian.net/"
+	legacy := "https://jira.example.com"
+
+	client := jira.NewInstances([]*config.Jira{
+		{BaseURL: &legacy, ProjectKeys: []string{"OLD"}},
+		{BaseURL: &cloud},
+	})
+
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{key: "OLD-1", expected: "https://jira.example.com/browse/OLD-1"},
+		{key: "NEW-1", expected: "https://example.atlassian.net/browse/NEW-1"},
+		{key: ""},
+	}
+
+	for _, testCase := range testCases {
+		if actual := client.Link(testCase.key); actual != testCase.expected {
+			t.Errorf("expected link '%s' for key '%s' but got '%s'", testCase.expected, testCase.key, actual)
+		}
+	}
+
+	if actual := jira.NewInstances(nil).Link("OLD-1"); actual != "" {
+		t.Errorf("expected no link without instances but got '%s'", actual)
+	}
+}
@@ -0,0 +1,119 @@
+package jira
+
+import "github.com/rebel-l/branma_be/config"
+
+// categories maps the status categories of JIRA to the categories of tickets
+var categories = map[string]string{
+	"new":           config.CategoryTodo,
+	"indeterminate": config.CategoryInProgress,
+	"done":          config.CategoryDone,
+}
+
+// Issue represents a ticket of JIRA with the fields relevant for branches
+type Issue struct {
+	Key            string   `json:"key"`
+	Summary        string   `json:"summary"`
+	Status         string   `json:"status"`
+	StatusCategory string   `json:"status_category"`
+	Type           string   `json:"type"`
+	Parent         string   `json:"parent"`
+	Assignee       string   `json:"assignee"`
+	Priority       string   `json:"priority"`
+	FixVersions    []string `json:"fix_versions"`
+}
+
+// Category returns the category of the issue, the statuses configured take precedence over the status category of
+// JIRA, because JIRA doesn't know a category for review. Ticket providers besides JIRA may deliver the category of
+// tickets directly.
+func (i *Issue) Category(cfg *config.Jira) string {
+	if category := cfg.GetStatusCategory(i.Status); category != "" {
+		return category
+	}
+
+	if category, ok := categories[i.StatusCategory]; ok {
+		return category
+	}
+
+	if config.IsCategory(i.StatusCategory) {
+		return i.StatusCategory
+	}
+
+	return ""
+}
+
+// Project returns the key of the project the issue belongs to
+func (i *Issue) Project() string {
+	for pos := len(i.Key) - 1; pos >= 0; pos-- {
+		if i.Key[pos] == '-' {
+			return i.Key[:pos]
+		}
+	}
+
+	return i.Key
+}
+
+// restIssue represents an issue as it is returned by the REST API
+type restIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary string `json:"summary"`
+		Status  struct {
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+		Parent *struct {
+			Key string `json:"key"`
+		} `json:"parent"`
+		Assignee *struct {
+			DisplayName string `json:"displayName"`
+		} `json:"assignee"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		FixVersions []struct {
+			Name string `json:"name"`
+		} `json:"fixVersions"`
+	} `json:"fields"`
+}
+
+func (r *restIssue) issue() *Issue {
+	issue := &Issue{
+		Key:            r.Key,
+		Summary:        r.Fields.Summary,
+		Status:         r.Fields.Status.Name,
+		StatusCategory: r.Fields.Status.StatusCategory.Key,
+		Type:           r.Fields.IssueType.Name,
+		FixVersions:    []string{},
+	}
+
+	if r.Fields.Parent != nil {
+		issue.Parent = r.Fields.Parent.Key
+	}
+
+	if r.Fields.Assignee != nil {
+		issue.Assignee = r.Fields.Assignee.DisplayName
+	}
+
+	if r.Fields.Priority != nil {
+		issue.Priority = r.Fields.Priority.Name
+	}
+
+	for _, v := range r.Fields.FixVersions {
+		issue.FixVersions = append(issue.FixVersions, v.Name)
+	}
+
+	return issue
+}
+
+// searchResult represents a page of issues returned by the search of the REST API
+type searchResult struct {
+	StartAt    int          `json:"startAt"`
+	MaxResults int          `json:"maxResults"`
+	Total      int          `json:"total"`
+	Issues     []*restIssue `json:"issues"`
+}
@@ -0,0 +1,68 @@
+package jira_test
+
+import (
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+)
+
+func TestIssue_Project(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{key: "JIRA-1", expected: "JIRA"},
+		{key: "MY-PROJ-12", expected: "MY-PROJ"},
+		{key: "NOKEY", expected: "NOKEY"},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.key, func(t *testing.T) {
+			issue := &jira.Issue{Key: testCase.key}
+			if actual := issue.Project(); actual != testCase.expected {
+				t.Errorf("expected project '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIssue_Category(t *testing.T) {
+	cfg := &config.Jira{StatusCategories: map[string]string{"Code Review": config.CategoryReview}}
+
+	testCases := []struct {
+		name     string
+		issue    *jira.Issue
+		expected string
+	}{
+		{
+			name:     "status configured",
+			issue:    &jira.Issue{Status: "Code Review", StatusCategory: "indeterminate"},
+			expected: config.CategoryReview,
+		},
+		{
+			name:     "status category of jira",
+			issue:    &jira.Issue{Status: "In Progress", StatusCategory: "indeterminate"},
+			expected: config.CategoryInProgress,
+		},
+		{
+			name:     "category of provider",
+			issue:    &jira.Issue{Status: "open", StatusCategory: config.CategoryTodo},
+			expected: config.CategoryTodo,
+		},
+		{
+			name:  "unknown",
+			issue: &jira.Issue{Status: "Blocked", StatusCategory: "undefined"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			if actual := testCase.issue.Category(cfg); actual != testCase.expected {
+				t.Errorf("expected category '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
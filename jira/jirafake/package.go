@@ -0,0 +1,3 @@
+// Package jirafake provides a fake JIRA server implementing the parts of the REST API used by branma, so the JIRA
+// integration can be tested offline
+package jirafake
@@ -0,0 +1,184 @@
+package jirafake
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rebel-l/branma_be/jira"
+)
+
+// errQuery occurs if the fake server doesn't understand a query
+var errQuery = errors.New("the query is not supported")
+
+// fields provides the values of the fields which can be used in queries
+var fields = map[string]func(issue *jira.Issue) []string{
+	"key":            func(issue *jira.Issue) []string { return []string{issue.Key} },
+	"issuekey":       func(issue *jira.Issue) []string { return []string{issue.Key} },
+	"project":        func(issue *jira.Issue) []string { return []string{issue.Project()} },
+	"status":         func(issue *jira.Issue) []string { return []string{issue.Status} },
+	"statuscategory": func(issue *jira.Issue) []string { return []string{issue.StatusCategory} },
+	"type":           func(issue *jira.Issue) []string { return []string{issue.Type} },
+	"issuetype":      func(issue *jira.Issue) []string { return []string{issue.Type} },
+	"parent":         func(issue *jira.Issue) []string { return []string{issue.Parent} },
+	"fixversion":     func(issue *jira.Issue) []string { return issue.FixVersions },
+}
+
+// clause represents a condition like `status = "In Progress"` or `key in (A-1, A-2)`
+type clause struct {
+	field  string
+	negate bool
+	values []string
+}
+
+// query represents the supported subset of JQL: clauses combined with AND, optionally followed by ORDER BY which is
+// ignored as issues are always ordered by key
+type query []clause
+
+func parseQuery(jql string) (query, error) {
+	tokens, err := tokenize(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	var q query
+
+	for pos := 0; pos < len(tokens); {
+		if strings.EqualFold(tokens[pos], "order") {
+			break
+		}
+
+		if len(q) > 0 {
+			if !strings.EqualFold(tokens[pos], "and") {
+				return nil, fmt.Errorf("%w: expected AND but got %s", errQuery, tokens[pos])
+			}
+
+			pos++
+		}
+
+		c, next, err := parseClause(tokens, pos)
+		if err != nil {
+			return nil, err
+		}
+
+		q = append(q, c)
+		pos = next
+	}
+
+	return q, nil
+}
+
+func parseClause(tokens []string, pos int) (clause, int, error) {
+	if pos+2 >= len(tokens) {
+		return clause{}, 0, fmt.Errorf("%w: incomplete clause", errQuery)
+	}
+
+	c := clause{field: strings.ToLower(tokens[pos])}
+	if _, ok := fields[c.field]; !ok {
+		return clause{}, 0, fmt.Errorf("%w: field %s", errQuery, tokens[pos])
+	}
+
+	operator := strings.ToLower(tokens[pos+1])
+	pos += 2
+
+	switch operator {
+	case "=", "!=":
+		c.negate = operator == "!="
+		c.values = []string{tokens[pos]}
+
+		return c, pos + 1, nil
+	case "in":
+		if tokens[pos] != "(" {
+			return clause{}, 0, fmt.Errorf("%w: expected ( but got %s", errQuery, tokens[pos])
+		}
+
+		for pos++; pos < len(tokens); pos++ {
+			switch tokens[pos] {
+			case ",":
+				continue
+			case ")":
+				return c, pos + 1, nil
+			default:
+				c.values = append(c.values, tokens[pos])
+			}
+		}
+
+		return clause{}, 0, fmt.Errorf("%w: missing )", errQuery)
+	default:
+		return clause{}, 0, fmt.Errorf("%w: operator %s", errQuery, operator)
+	}
+}
+
+func tokenize(jql string) ([]string, error) {
+	var (
+		tokens []string
+		word   strings.Builder
+	)
+
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+
+	runes := []rune(jql)
+
+	for pos := 0; pos < len(runes); pos++ {
+		r := runes[pos]
+
+		switch {
+		case r == '"' || r == '\'':
+			flush()
+
+			end := pos + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, fmt.Errorf("%w: unterminated string", errQuery)
+			}
+
+			tokens = append(tokens, string(runes[pos+1:end]))
+			pos = end
+		case r == ' ' || r == '\t' || r == '\n':
+			flush()
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			flush()
+			tokens = append(tokens, string(r))
+		case r == '!' && pos+1 < len(runes) && runes[pos+1] == '=':
+			flush()
+			tokens = append(tokens, "!=")
+			pos++
+		default:
+			word.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return tokens, nil
+}
+
+func (q query) matches(issue *jira.Issue) bool {
+	for _, c := range q {
+		if c.matches(issue) == c.negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c clause) matches(issue *jira.Issue) bool {
+	for _, a := range fields[c.field](issue) {
+		for _, v := range c.values {
+			if strings.EqualFold(a, v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,428 @@
+package jirafake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rebel-l/branma_be/jira"
+)
+
+const (
+	pathIssue  = "/rest/api/2/issue/"
+	pathSearch = "/rest/api/2/search"
+
+	defaultMaxResults = 50
+)
+
+// Server is a fake JIRA server, it must be closed after usage
+type Server struct {
+	*httptest.Server
+
+	mutex       sync.Mutex
+	issues      map[string]*jira.Issue
+	comments    map[string][]string
+	transitions map[string]string
+	username    string
+	password    string
+	token       string
+	rateLimited int
+	retryAfter  time.Duration
+	requests    int
+}
+
+// NewServer starts a fake JIRA server without issues accepting any credentials
+func NewServer() *Server {
+	s := &Server{
+		issues:      make(map[string]*jira.Issue),
+		comments:    make(map[string][]string),
+		transitions: make(map[string]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+
+	return s
+}
+
+// SetCredentials restricts the access to requests with the given basic auth credentials
+func (s *Server) SetCredentials(username, password string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.username = username
+	s.password = password
+}
+
+// SetToken restricts the access to requests with the given bearer token
+func (s *Server) SetToken(token string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.token = token
+}
+
+// AddIssue adds or replaces an issue
+func (s *Server) AddIssue(issue *jira.Issue) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	i := *issue
+	s.issues[i.Key] = &i
+}
+
+// DeleteIssue removes an issue
+func (s *Server) DeleteIssue(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.issues, key)
+}
+
+// Issue returns a copy of the issue with the given key, it is nil if the issue doesn't exist
+func (s *Server) Issue(key string) *jira.Issue {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	issue, ok := s.issues[key]
+	if !ok {
+		return nil
+	}
+
+	i := *issue
+	i.FixVersions = append([]string(nil), issue.FixVersions...)
+
+	return &i
+}
+
+// Comments returns the comments added to the issue with the given key
+func (s *Server) Comments(key string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]string(nil), s.comments[key]...)
+}
+
+// SetTransitions sets the transitions available for all issues, they are mapped by name to the status they move
+// the issue to
+func (s *Server) SetTransitions(transitions map[string]string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.transitions = transitions
+}
+
+// RateLimit lets the next requests fail with status 429 and the given Retry-After header
+func (s *Server) RateLimit(requests int, retryAfter time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.rateLimited = requests
+	s.retryAfter = retryAfter
+}
+
+// Requests returns the number of requests received
+func (s *Server) Requests() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.requests
+}
+
+func (s *Server) serve(writer http.ResponseWriter, request *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests++
+
+	if s.rateLimited > 0 {
+		s.rateLimited--
+		writer.Header().Set("Retry-After", strconv.Itoa(int(s.retryAfter.Seconds())))
+		writer.WriteHeader(http.StatusTooManyRequests)
+
+		return
+	}
+
+	if !s.authorized(request) {
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	switch {
+	case request.Method == http.MethodGet && request.URL.Path == pathSearch:
+		s.search(writer, request)
+	case strings.HasPrefix(request.URL.Path, pathIssue):
+		s.serveIssue(writer, request)
+	default:
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// authorized checks the bearer token or basic auth credentials if the server is restricted to them
+func (s *Server) authorized(request *http.Request) bool {
+	if s.token != "" {
+		return request.Header.Get("Authorization") == "Bearer "+s.token
+	}
+
+	if s.username != "" {
+		username, password, ok := request.BasicAuth()
+
+		return ok && username == s.username && password == s.password
+	}
+
+	return true
+}
+
+// serveIssue dispatches the requests of a single issue by the part of the path after the key
+func (s *Server) serveIssue(writer http.ResponseWriter, request *http.Request) {
+	path := strings.SplitN(strings.TrimPrefix(request.URL.Path, pathIssue), "/", 2)
+	key, sub := path[0], ""
+
+	if len(path) > 1 {
+		sub = path[1]
+	}
+
+	if _, ok := s.issues[key]; !ok {
+		writeError(writer, http.StatusNotFound, "Issue Does Not Exist")
+
+		return
+	}
+
+	switch {
+	case request.Method == http.MethodGet && sub == "":
+		s.issue(writer, key)
+	case request.Method == http.MethodPut && sub == "":
+		s.update(writer, request, key)
+	case request.Method == http.MethodPost && sub == "comment":
+		s.comment(writer, request, key)
+	case request.Method == http.MethodGet && sub == "transitions":
+		s.listTransitions(writer)
+	case request.Method == http.MethodPost && sub == "transitions":
+		s.transition(writer, request, key)
+	default:
+		writer.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (s *Server) issue(writer http.ResponseWriter, key string) {
+	issue, ok := s.issues[key]
+	if !ok {
+		writeError(writer, http.StatusNotFound, "Issue Does Not Exist")
+
+		return
+	}
+
+	writeJSON(writer, restIssue(issue))
+}
+
+func (s *Server) update(writer http.ResponseWriter, request *http.Request, key string) {
+	body := struct {
+		Update struct {
+			FixVersions []struct {
+				Add *struct {
+					Name string `json:"name"`
+				} `json:"add"`
+			} `json:"fixVersions"`
+		} `json:"update"`
+	}{}
+
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	issue := s.issues[key]
+
+	for _, op := range body.Update.FixVersions {
+		if op.Add != nil && !contains(issue.FixVersions, op.Add.Name) {
+			issue.FixVersions = append(issue.FixVersions, op.Add.Name)
+		}
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) comment(writer http.ResponseWriter, request *http.Request, key string) {
+	body := struct {
+		Body string `json:"body"`
+	}{}
+
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil || body.Body == "" {
+		writeError(writer, http.StatusBadRequest, "Comment body can not be empty!")
+
+		return
+	}
+
+	s.comments[key] = append(s.comments[key], body.Body)
+
+	writer.WriteHeader(http.StatusCreated)
+}
+
+// transitionIDs returns the names of the transitions ordered by name, the position is the ID of the transition
+func (s *Server) transitionIDs() []string {
+	names := make([]string, 0, len(s.transitions))
+	for name := range s.transitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (s *Server) listTransitions(writer http.ResponseWriter) {
+	transitions := []interface{}{}
+
+	for id, name := range s.transitionIDs() {
+		transitions = append(transitions, map[string]interface{}{
+			"id":   strconv.Itoa(id),
+			"name": name,
+			"to":   map[string]string{"name": s.transitions[name]},
+		})
+	}
+
+	writeJSON(writer, map[string]interface{}{"transitions": transitions})
+}
+
+func (s *Server) transition(writer http.ResponseWriter, request *http.Request, key string) {
+	body := struct {
+		Transition struct {
+			ID string `json:"id"`
+		} `json:"transition"`
+	}{}
+
+	if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	names := s.transitionIDs()
+
+	id, err := strconv.Atoi(body.Transition.ID)
+	if err != nil || id < 0 || id >= len(names) {
+		writeError(writer, http.StatusBadRequest, "Transition id is not valid for this issue.")
+
+		return
+	}
+
+	s.issues[key].Status = s.transitions[names[id]]
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) search(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	matches, err := s.match(query.Get("jql"))
+	if err != nil {
+		writeError(writer, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	startAt, _ := strconv.Atoi(query.Get("startAt"))
+
+	maxResults, err := strconv.Atoi(query.Get("maxResults"))
+	if err != nil || maxResults <= 0 {
+		maxResults = defaultMaxResults
+	}
+
+	page := []interface{}{}
+
+	for i := startAt; i < len(matches) && i < startAt+maxResults; i++ {
+		page = append(page, restIssue(matches[i]))
+	}
+
+	writeJSON(writer, map[string]interface{}{
+		"startAt":    startAt,
+		"maxResults": maxResults,
+		"total":      len(matches),
+		"issues":     page,
+	})
+}
+
+// match returns the issues matching the query ordered by key
+func (s *Server) match(jql string) ([]*jira.Issue, error) {
+	q, err := parseQuery(jql)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*jira.Issue
+
+	for _, issue := range s.issues {
+		if q.matches(issue) {
+			matches = append(matches, issue)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Key < matches[j].Key
+	})
+
+	return matches, nil
+}
+
+// restIssue returns the representation of the issue in the REST API
+func restIssue(issue *jira.Issue) map[string]interface{} {
+	fixVersions := []map[string]string{}
+	for _, v := range issue.FixVersions {
+		fixVersions = append(fixVersions, map[string]string{"name": v})
+	}
+
+	fields := map[string]interface{}{
+		"summary": issue.Summary,
+		"status": map[string]interface{}{
+			"name":           issue.Status,
+			"statusCategory": map[string]string{"key": issue.StatusCategory},
+		},
+		"issuetype":   map[string]string{"name": issue.Type},
+		"fixVersions": fixVersions,
+	}
+
+	if issue.Parent != "" {
+		fields["parent"] = map[string]string{"key": issue.Parent}
+	}
+
+	if issue.Assignee != "" {
+		fields["assignee"] = map[string]string{"displayName": issue.Assignee}
+	}
+
+	if issue.Priority != "" {
+		fields["priority"] = map[string]string{"name": issue.Priority}
+	}
+
+	return map[string]interface{}{"key": issue.Key, "fields": fields}
+}
+
+func writeJSON(writer http.ResponseWriter, v interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(writer).Encode(v); err != nil {
+		writer.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func writeError(writer http.ResponseWriter, status int, message string) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+
+	_, _ = fmt.Fprintf(writer, `{"errorMessages":[%q],"errors":{}}`, message)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
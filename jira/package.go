@@ -0,0 +1,2 @@
+// Package jira provides a client for the REST API of JIRA to fetch the tickets branches belong to
+package jira
@@ -0,0 +1,60 @@
+package jira
+
+import "sync/atomic"
+
+// StatsProvider provides the stats of a client
+type StatsProvider interface {
+	// Stats returns the number of requests sent and the lookups served by the cache since the client was created
+	Stats() Stats
+}
+
+// Stats provides the number of requests sent to JIRA and how many lookups of issues by key were served by the cache
+type Stats struct {
+	Requests     int64   `json:"requests"`
+	RateLimited  int64   `json:"rate_limited"`
+	Retries      int64   `json:"retries"`
+	CacheHits    int64   `json:"cache_hits"`
+	CacheMisses  int64   `json:"cache_misses"`
+	CacheHitRate float64 `json:"cache_hit_rate"`
+}
+
+// add returns the sum of both stats
+func (s Stats) add(o Stats) Stats {
+	return Stats{
+		Requests:    s.Requests + o.Requests,
+		RateLimited: s.RateLimited + o.RateLimited,
+		Retries:     s.Retries + o.Retries,
+		CacheHits:   s.CacheHits + o.CacheHits,
+		CacheMisses: s.CacheMisses + o.CacheMisses,
+	}.withHitRate()
+}
+
+// withHitRate returns the stats with the share of lookups served by the cache
+func (s Stats) withHitRate() Stats {
+	s.CacheHitRate = 0
+
+	if lookups := s.CacheHits + s.CacheMisses; lookups > 0 {
+		s.CacheHitRate = float64(s.CacheHits) / float64(lookups)
+	}
+
+	return s
+}
+
+// counters are the stats of a client updated concurrently
+type counters struct {
+	requests    int64
+	rateLimited int64
+	retries     int64
+	cacheHits   int64
+	cacheMisses int64
+}
+
+func (c *counters) stats() Stats {
+	return Stats{
+		Requests:    atomic.LoadInt64(&c.requests),
+		RateLimited: atomic.LoadInt64(&c.rateLimited),
+		Retries:     atomic.LoadInt64(&c.retries),
+		CacheHits:   atomic.LoadInt64(&c.cacheHits),
+		CacheMisses: atomic.LoadInt64(&c.cacheMisses),
+	}.withHitRate()
+}
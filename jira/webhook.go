@@ -0,0 +1,57 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	// EventIssueUpdated is sent by JIRA if an issue was changed
+	EventIssueUpdated = "jira:issue_updated"
+
+	// EventIssueDeleted is sent by JIRA if an issue was deleted
+	EventIssueDeleted = "jira:issue_deleted"
+)
+
+// ErrInvalidWebhook occurs if the payload of a webhook is not a JIRA issue event
+var ErrInvalidWebhook = errors.New("invalid jira webhook payload")
+
+// WebhookEvent represents an event JIRA sends to a webhook
+type WebhookEvent struct {
+	Type  string
+	Issue *Issue
+}
+
+// restWebhookEvent represents a webhook event as it is sent by JIRA
+type restWebhookEvent struct {
+	WebhookEvent string     `json:"webhookEvent"`
+	Issue        *restIssue `json:"issue"`
+}
+
+// DecodeWebhook converts the JSON payload of a webhook to an event. Events of other types than issues are decoded
+// as well, but the issue is only set if the payload contains one.
+func DecodeWebhook(reader io.Reader) (*WebhookEvent, error) {
+	res := &restWebhookEvent{}
+	if err := json.NewDecoder(reader).Decode(res); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	if res.WebhookEvent == "" {
+		return nil, fmt.Errorf("%w: event type is missing", ErrInvalidWebhook)
+	}
+
+	event := &WebhookEvent{Type: res.WebhookEvent}
+
+	if res.Issue != nil {
+		event.Issue = res.Issue.issue()
+	}
+
+	if (event.Type == EventIssueUpdated || event.Type == EventIssueDeleted) &&
+		(event.Issue == nil || event.Issue.Key == "") {
+		return nil, fmt.Errorf("%w: issue is missing", ErrInvalidWebhook)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,103 @@
+package jira_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/jira"
+)
+
+func TestDecodeWebhook(t *testing.T) { // nolint:funlen
+	testCases := []struct {
+		name        string
+		payload     string
+		expected    *jira.WebhookEvent
+		expectedErr error
+	}{
+		{
+			name:        "no JSON",
+			payload:     "no JSON",
+			expectedErr: jira.ErrInvalidWebhook,
+		},
+		{
+			name:        "event type missing",
+			payload:     `{"issue": {"key": "JIRA-1"}}`,
+			expectedErr: jira.ErrInvalidWebhook,
+		},
+		{
+			name:        "issue missing",
+			payload:     `{"webhookEvent": "jira:issue_updated"}`,
+			expectedErr: jira.ErrInvalidWebhook,
+		},
+		{
+			name:     "other event",
+			payload:  `{"webhookEvent": "jira:version_released"}`,
+			expected: &jira.WebhookEvent{Type: "jira:version_released"},
+		},
+		{
+			name: "issue updated",
+			payload: `{
+				"webhookEvent": "jira:issue_updated",
+				"issue": {
+					"key": "JIRA-2",
+					"fields": {
+						"summary": "a nice summary",
+						"status": {"name": "Done", "statusCategory": {"key": "done"}},
+						"issuetype": {"name": "Bug"},
+						"parent": {"key": "JIRA-1"},
+						"assignee": {"displayName": "Jane Doe"},
+						"priority": {"name": "High"},
+						"fixVersions": [{"name": "1.0.0"}]
+					}
+				}
+			}`,
+			expected: &jira.WebhookEvent{
+				Type: jira.EventIssueUpdated,
+				Issue: &jira.Issue{
+					Key:            "JIRA-2",
+					Summary:        "a nice summary",
+					Status:         "Done",
+					StatusCategory: "done",
+					Type:           "Bug",
+					Parent:         "JIRA-1",
+					Assignee:       "Jane Doe",
+					Priority:       "High",
+					FixVersions:    []string{"1.0.0"},
+				},
+			},
+		},
+		{
+			name:     "issue deleted",
+			payload:  `{"webhookEvent": "jira:issue_deleted", "issue": {"key": "JIRA-3", "fields": {}}}`,
+			expected: &jira.WebhookEvent{Type: jira.EventIssueDeleted, Issue: &jira.Issue{Key: "JIRA-3"}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := jira.DecodeWebhook(strings.NewReader(testCase.payload))
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+			}
+
+			if testCase.expected == nil {
+				return
+			}
+
+			if actual.Type != testCase.expected.Type {
+				t.Errorf("expected type '%s' but got '%s'", testCase.expected.Type, actual.Type)
+			}
+
+			if testCase.expected.Issue == nil {
+				if actual.Issue != nil {
+					t.Errorf("expected no issue but got %#v", actual.Issue)
+				}
+
+				return
+			}
+
+			testIssue(t, testCase.expected.Issue, actual.Issue)
+		})
+	}
+}
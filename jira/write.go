@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	pathComment     = "/comment"
+	pathTransitions = "/transitions"
+)
+
+// Writer changes issues in JIRA
+type Writer interface {
+	// AddComment adds a comment with the given text to the issue
+	AddComment(ctx context.Context, key, text string) error
+
+	// AddFixVersion adds the version to the fix versions of the issue, the version must exist in the project
+	AddFixVersion(ctx context.Context, key, version string) error
+
+	// Transition moves the issue by the transition with the given name or the transition to the status with the
+	// given name. It does nothing if the issue is already in the status.
+	Transition(ctx context.Context, key, name string) error
+}
+
+// restTransitions represents the transitions of an issue as they are returned by the REST API
+type restTransitions struct {
+	Transitions []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		To   struct {
+			Name string `json:"name"`
+		} `json:"to"`
+	} `json:"transitions"`
+}
+
+// AddComment adds a comment with the given text to the issue
+func (r *REST) AddComment(ctx context.Context, key, text string) error {
+	body := map[string]string{"body": text}
+
+	return r.do(ctx, http.MethodPost, pathIssue+url.PathEscape(key)+pathComment, body, nil)
+}
+
+// AddFixVersion adds the version to the fix versions of the issue, adding a version twice has no effect
+func (r *REST) AddFixVersion(ctx context.Context, key, version string) error {
+	body := map[string]interface{}{
+		"update": map[string]interface{}{
+			"fixVersions": []interface{}{
+				map[string]interface{}{"add": map[string]string{"name": version}},
+			},
+		},
+	}
+
+	defer r.cache.delete(key)
+
+	return r.do(ctx, http.MethodPut, pathIssue+url.PathEscape(key), body, nil)
+}
+
+// Transition moves the issue by the transition with the given name or the transition to the status with the given
+// name. It does nothing if the issue is already in the status.
+func (r *REST) Transition(ctx context.Context, key, name string) error {
+	r.cache.delete(key)
+	defer r.cache.delete(key)
+
+	path := pathIssue + url.PathEscape(key) + pathTransitions
+
+	res := &restTransitions{}
+	if err := r.do(ctx, http.MethodGet, path, nil, res); err != nil {
+		return err
+	}
+
+	for _, t := range res.Transitions {
+		if strings.EqualFold(t.Name, name) || strings.EqualFold(t.To.Name, name) {
+			body := map[string]interface{}{"transition": map[string]string{"id": t.ID}}
+
+			return r.do(ctx, http.MethodPost, path, body, nil)
+		}
+	}
+
+	issue, err := r.Issue(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(issue.Status, name) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s for issue %s", ErrTransitionNotFound, name, key)
+}
@@ -0,0 +1,86 @@
+package jira_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rebel-l/branma_be/jira"
+)
+
+var _ jira.Writer = &jira.REST{}
+
+func TestREST_AddComment(t *testing.T) {
+	server, client := setup(t)
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1"})
+
+	if err := client.AddComment(context.Background(), "JIRA-1", "merged"); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if comments := server.Comments("JIRA-1"); len(comments) != 1 || comments[0] != "merged" {
+		t.Errorf("expected comment to be added but got %v", comments)
+	}
+
+	err := client.AddComment(context.Background(), "JIRA-2", "merged")
+	if !errors.Is(err, jira.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrNotFound, err)
+	}
+}
+
+func TestREST_AddFixVersion(t *testing.T) {
+	server, client := setup(t)
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", FixVersions: []string{"1.0.0"}})
+
+	for i := 0; i < 2; i++ {
+		if err := client.AddFixVersion(context.Background(), "JIRA-1", "1.1.0"); err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+	}
+
+	issue := server.Issue("JIRA-1")
+	if len(issue.FixVersions) != 2 || issue.FixVersions[1] != "1.1.0" {
+		t.Errorf("expected fix version 1.1.0 to be added once but got %v", issue.FixVersions)
+	}
+}
+
+func TestREST_Transition(t *testing.T) {
+	server, client := setup(t)
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", Status: "In Progress"})
+	server.SetTransitions(map[string]string{"Ready for QA": "QA", "Close": "Done"})
+
+	testCases := []struct {
+		name        string
+		transition  string
+		expected    string
+		expectedErr error
+	}{
+		{name: "by transition name", transition: "ready for qa", expected: "QA"},
+		{name: "by status", transition: "Done", expected: "Done"},
+		{name: "unknown", transition: "Reopen", expected: "Done", expectedErr: jira.ErrTransitionNotFound},
+	}
+
+	for _, testCase := range testCases {
+		err := client.Transition(context.Background(), "JIRA-1", testCase.transition)
+		if !errors.Is(err, testCase.expectedErr) {
+			t.Errorf("%s: expected error '%v' but got '%v'", testCase.name, testCase.expectedErr, err)
+		}
+
+		if status := server.Issue("JIRA-1").Status; status != testCase.expected {
+			t.Errorf("%s: expected status '%s' but got '%s'", testCase.name, testCase.expected, status)
+		}
+	}
+
+	// already in status
+	server.SetTransitions(map[string]string{"Reopen": "Open"})
+
+	if err := client.Transition(context.Background(), "JIRA-1", "Done"); err != nil {
+		t.Errorf("expected no error if issue is already in status but got: %v", err)
+	}
+}
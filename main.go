@@ -21,6 +21,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"net/http"
@@ -34,9 +35,18 @@ import (
 
 	"github.com/rebel-l/branma_be/bootstrap"
 	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/endpoint/branch"
 	"github.com/rebel-l/branma_be/endpoint/doc"
+	"github.com/rebel-l/branma_be/endpoint/git"
 	"github.com/rebel-l/branma_be/endpoint/ping"
+	"github.com/rebel-l/branma_be/endpoint/report"
 	"github.com/rebel-l/branma_be/endpoint/repository"
+	"github.com/rebel-l/branma_be/endpoint/ticket"
+	"github.com/rebel-l/branma_be/endpoint/webhook"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/ticket/ticketprovider"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+	"github.com/rebel-l/branma_be/ticket/ticketwriteback"
 	"github.com/rebel-l/smis"
 
 	"github.com/sirupsen/logrus"
@@ -58,6 +68,10 @@ var (
 	db            *sqlx.DB
 	log           logrus.FieldLogger
 	svc           *smis.Service
+	jiraClient    *jira.Instances
+	ticketSyncer  *ticketsync.Syncer
+	background    context.Context
+	stopSchedules context.CancelFunc
 )
 
 func initCustomFlags() {
@@ -87,6 +101,18 @@ func initCustomFlags() {
 		"prefix for release branches on your git repository",
 	)
 
+	cfg.GetGit().WorkPath = flag.String(
+		"git-path",
+		cfg.GetGit().GetWorkPath(),
+		"path where the git repositories are cloned to",
+	)
+
+	cfg.GetGit().MainBranch = flag.String(
+		"git-main",
+		cfg.GetGit().GetMainBranch(),
+		"branch all features and hotfixes end up in",
+	)
+
 	// JIRA
 	cfg.GetJira().BaseURL = flag.String(
 		"jira-url",
@@ -97,13 +123,31 @@ func initCustomFlags() {
 	cfg.GetJira().Username = flag.String(
 		"jira-user",
 		cfg.GetJira().GetUsername(),
-		"username of your login to JIRA",
+		"username of your login to JIRA, for JIRA Cloud your email",
+	)
+
+	cfg.GetJira().AuthType = flag.String(
+		"jira-auth",
+		"",
+		"auth scheme for JIRA: basic, token or bearer, empty derives it from the credentials configured",
 	)
 
-	cfg.GetJira().Password = flag.String(
-		"jira-pw",
-		cfg.GetJira().GetPassword(),
-		"password of your login to JIRA",
+	cfg.GetJira().TokenEnv = flag.String(
+		"jira-token-env",
+		cfg.GetJira().GetTokenEnv(),
+		"environment variable containing the API token or personal access token for JIRA",
+	)
+
+	cfg.GetJira().TokenFile = flag.String(
+		"jira-token-file",
+		cfg.GetJira().GetTokenFile(),
+		"file containing the API token or personal access token for JIRA",
+	)
+
+	cfg.GetJira().SyncInterval = flag.String(
+		"jira-sync",
+		cfg.GetJira().GetSyncInterval(),
+		"interval the tickets are synchronised with JIRA, e.g. 15m, empty disables the schedule",
 	)
 }
 
@@ -111,7 +155,10 @@ func initCustom() error {
 	/**
 	  2. add your custom service initialisation below, e.g. database connection, caches etc.
 	*/
-	var err error
+	err := cfg.GetJira().ValidateStatusCategories()
+	if err != nil {
+		return err
+	}
 
 	if *databaseReset {
 		err = bootstrap.DatabaseReset(cfg.GetDB())
@@ -125,6 +172,28 @@ func initCustom() error {
 		return err
 	}
 
+	background, stopSchedules = context.WithCancel(context.Background())
+
+	jiraClient = jira.NewInstances(cfg.GetJiraInstances())
+
+	providers, err := ticketprovider.NewAll(cfg.GetTicketProviders())
+	if err != nil {
+		return err
+	}
+
+	ticketSyncer = ticketsync.New(db, jiraClient).WithStatusCategories(cfg.GetJira()).WithProviders(providers)
+
+	if interval := cfg.GetJira().GetSyncInterval(); interval != "" {
+		var d time.Duration
+
+		d, err = time.ParseDuration(interval)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid interval for ticket synchronisation: %s", interval)
+		}
+
+		go ticketSyncer.Schedule(background, d, log)
+	}
+
 	return nil
 }
 
@@ -138,6 +207,31 @@ func initCustomRoutes() error {
 		return err
 	}
 
+	// branch
+	if err := branch.Init(svc, db, jiraClient); err != nil {
+		return err
+	}
+
+	// git
+	if err := git.Init(background, svc, db, cfg.GetGit(), ticketwriteback.New(db, jiraClient, cfg.GetJira())); err != nil {
+		return err
+	}
+
+	// report
+	if err := report.Init(svc, db, cfg.GetGit(), jiraClient, cfg.GetJira()); err != nil {
+		return err
+	}
+
+	// ticket
+	if err := ticket.Init(svc, ticketSyncer, jiraClient); err != nil {
+		return err
+	}
+
+	// webhook
+	if err := webhook.Init(svc, ticketSyncer, cfg.GetJira()); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -148,6 +242,10 @@ func closeCustom() {
 	*/
 	log.Info("Closing connections ...")
 
+	if stopSchedules != nil {
+		stopSchedules()
+	}
+
 	if err := db.Close(); err != nil {
 		log.Errorf("failed to close connections: %v", err)
 	}
@@ -0,0 +1,54 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidDocument occurs if the document to patch is not in JSON format
+	ErrInvalidDocument = errors.New("document is not valid JSON")
+
+	// ErrInvalidPatch occurs if the patch is not in JSON format
+	ErrInvalidPatch = errors.New("merge patch is not valid JSON")
+)
+
+// Apply applies the merge patch to the document and returns the patched document. Members of objects set to null in
+// the patch are removed, all other values replace the ones of the document. Objects are merged recursively.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target interface{}
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDocument, err)
+	}
+
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+
+		t[key] = merge(t[key], value)
+	}
+
+	return t
+}
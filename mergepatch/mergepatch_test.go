@@ -0,0 +1,54 @@
+package mergepatch_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rebel-l/branma_be/mergepatch"
+)
+
+func TestApply(t *testing.T) {
+	// test cases taken from appendix A of RFC 7396
+	testCases := []struct {
+		doc, patch, expected string
+	}{
+		{doc: `{"a":"b"}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{doc: `{"a":"b"}`, patch: `{"b":"c"}`, expected: `{"a":"b","b":"c"}`},
+		{doc: `{"a":"b"}`, patch: `{"a":null}`, expected: `{}`},
+		{doc: `{"a":"b","b":"c"}`, patch: `{"a":null}`, expected: `{"b":"c"}`},
+		{doc: `{"a":["b"]}`, patch: `{"a":"c"}`, expected: `{"a":"c"}`},
+		{doc: `{"a":"c"}`, patch: `{"a":["b"]}`, expected: `{"a":["b"]}`},
+		{doc: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, expected: `{"a":{"b":"d"}}`},
+		{doc: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, expected: `{"a":[1]}`},
+		{doc: `["a","b"]`, patch: `["c","d"]`, expected: `["c","d"]`},
+		{doc: `{"a":"b"}`, patch: `["c"]`, expected: `["c"]`},
+		{doc: `{"a":"foo"}`, patch: `null`, expected: `null`},
+		{doc: `{"a":"foo"}`, patch: `"bar"`, expected: `"bar"`},
+		{doc: `{"e":null}`, patch: `{"a":1}`, expected: `{"a":1,"e":null}`},
+		{doc: `[1,2]`, patch: `{"a":"b","c":null}`, expected: `{"a":"b"}`},
+		{doc: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, expected: `{"a":{"bb":{}}}`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.patch, func(t *testing.T) {
+			actual, err := mergepatch.Apply([]byte(testCase.doc), []byte(testCase.patch))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			if string(actual) != testCase.expected {
+				t.Errorf("expected '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	if _, err := mergepatch.Apply([]byte("no JSON"), []byte("{}")); !errors.Is(err, mergepatch.ErrInvalidDocument) {
+		t.Errorf("expected error '%v' but got '%v'", mergepatch.ErrInvalidDocument, err)
+	}
+
+	if _, err := mergepatch.Apply([]byte("{}"), []byte("no JSON")); !errors.Is(err, mergepatch.ErrInvalidPatch) {
+		t.Errorf("expected error '%v' but got '%v'", mergepatch.ErrInvalidPatch, err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package mergepatch applies JSON merge patches as defined by RFC 7396 to JSON documents
+package mergepatch
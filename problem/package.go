@@ -0,0 +1,2 @@
+// Package problem provides error responses in the format of problem details for HTTP APIs (RFC 7807)
+package problem
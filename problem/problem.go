@@ -0,0 +1,95 @@
+package problem
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rebel-l/smis"
+	"github.com/rebel-l/smis/middleware/requestid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/validation"
+)
+
+const (
+	// ContentType is the content type of problem responses
+	ContentType = "application/problem+json"
+
+	// TypeDefault is the type of problems which have no further semantics than the HTTP status
+	TypeDefault = "about:blank"
+)
+
+// Problem represents the details of an error, the fields beyond status and detail are extensions of the RFC
+type Problem struct {
+	Type       string                   `json:"type"`
+	Title      string                   `json:"title"`
+	Status     int                      `json:"status"`
+	Detail     string                   `json:"detail,omitempty"`
+	RequestID  string                   `json:"request_id,omitempty"`
+	Errors     []*validation.FieldError `json:"errors,omitempty"`
+	ExistingID int                      `json:"existing_id,omitempty"`
+}
+
+// New returns a problem of the status, the title is the text of the status
+func New(status int, detail string) *Problem {
+	return &Problem{
+		Type:   TypeDefault,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Write sends a problem with the status and detail as response
+func Write(writer http.ResponseWriter, request *http.Request, log logrus.FieldLogger, status int, detail string) {
+	WriteProblem(writer, request, log, New(status, detail))
+}
+
+// Writef sends a problem with the status and the detail formatted according to the format specifier as response
+func Writef(
+	writer http.ResponseWriter,
+	request *http.Request,
+	log logrus.FieldLogger,
+	status int,
+	format string,
+	args ...interface{},
+) {
+	WriteProblem(writer, request, log, New(status, fmt.Sprintf(format, args...)))
+}
+
+// WriteProblem sends the problem as response, the request ID is taken from the context of the request. The log is
+// used to report failures on writing the response and can be nil.
+func WriteProblem(writer http.ResponseWriter, request *http.Request, log logrus.FieldLogger, p *Problem) {
+	if writer == nil || p == nil {
+		logError(log, "writer or problem is nil")
+		return
+	}
+
+	if request != nil {
+		p.RequestID = requestid.GetID(request.Context())
+	}
+
+	body, err := json.Marshal(p)
+	if err != nil {
+		logError(log, "failed to encode problem: "+err.Error())
+		writer.WriteHeader(http.StatusInternalServerError)
+
+		return
+	}
+
+	writer.Header().Set(smis.HeaderKeyContentType, ContentType)
+	writer.WriteHeader(p.Status)
+
+	if _, err := writer.Write(body); err != nil {
+		logError(log, "failed to write problem: "+err.Error())
+	}
+}
+
+func logError(log logrus.FieldLogger, msg string) {
+	if log == nil {
+		return
+	}
+
+	log.Error(msg)
+}
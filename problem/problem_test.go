@@ -0,0 +1,78 @@
+package problem_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rebel-l/smis"
+	"github.com/rebel-l/smis/middleware/requestid"
+
+	"github.com/rebel-l/branma_be/problem"
+)
+
+func TestNew(t *testing.T) {
+	actual := problem.New(http.StatusNotFound, "branch with id 1 not found")
+
+	expected := problem.Problem{
+		Type:   problem.TypeDefault,
+		Title:  "Not Found",
+		Status: http.StatusNotFound,
+		Detail: "branch with id 1 not found",
+	}
+
+	if actual.Type != expected.Type || actual.Title != expected.Title || actual.Status != expected.Status ||
+		actual.Detail != expected.Detail {
+		t.Errorf("expected %#v but got %#v", expected, actual)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	request := httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx := context.WithValue(context.Background(), requestid.ContextKeyRequestID, "abc")
+
+	testCases := []struct {
+		name              string
+		request           *http.Request
+		expectedRequestID string
+	}{
+		{
+			name: "request nil",
+		},
+		{
+			name:              "request with id",
+			request:           request.WithContext(ctx),
+			expectedRequestID: "abc",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			problem.Writef(w, testCase.request, nil, http.StatusBadRequest, "id %s is not an integer", "x")
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected code %d but got %d", http.StatusBadRequest, w.Code)
+			}
+
+			if actual := w.Header().Get(smis.HeaderKeyContentType); actual != problem.ContentType {
+				t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, actual)
+			}
+
+			actual := &problem.Problem{}
+			if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+				t.Fatalf("failed to decode json: %v | %s", err, w.Body.Bytes())
+			}
+
+			if actual.Detail != "id x is not an integer" {
+				t.Errorf("expected detail 'id x is not an integer' but got '%s'", actual.Detail)
+			}
+
+			if actual.RequestID != testCase.expectedRequestID {
+				t.Errorf("expected request id '%s' but got '%s'", testCase.expectedRequestID, actual.RequestID)
+			}
+		})
+	}
+}
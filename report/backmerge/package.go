@@ -0,0 +1,2 @@
+// Package backmerge reports commits of release branches which were never merged back into the main branch
+package backmerge
@@ -0,0 +1,23 @@
+package backmerge
+
+import "time"
+
+// Commit represents a change which is missing on the main branch
+type Commit struct {
+	PatchID         string    `json:"patch_id"`
+	Hash            string    `json:"hash"`
+	Author          string    `json:"author"`
+	Date            time.Time `json:"date"`
+	Subject         string    `json:"subject"`
+	Tickets         []string  `json:"tickets"`
+	ReleaseBranches []string  `json:"release_branches"`
+}
+
+// Report lists the changes of release branches missing on the main branch of a repository
+type Report struct {
+	RepositoryID    int       `json:"repository_id"`
+	MainBranch      string    `json:"main_branch"`
+	ReleaseBranches []string  `json:"release_branches"`
+	Commits         []*Commit `json:"commits"`
+	Tickets         []string  `json:"tickets"`
+}
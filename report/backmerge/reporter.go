@@ -0,0 +1,178 @@
+package backmerge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitcli"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/ticket/ticketkey"
+	"github.com/rebel-l/branma_be/version/versionname"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+var (
+	// ErrRepositoryNotFound occurs if the repository doesn't exist
+	ErrRepositoryNotFound = errors.New("repository was not found")
+
+	// ErrNotSynchronised occurs if the repository was not synchronised with git yet
+	ErrNotSynchronised = errors.New("repository was not synchronised with git yet")
+)
+
+// Reporter creates back merge reports based on the local mirrors of the git repositories
+type Reporter struct {
+	db            *sqlx.DB
+	workPath      string
+	mainBranch    string
+	releasePrefix string
+}
+
+// New returns a new reporter
+func New(db *sqlx.DB, cfg *config.Git) *Reporter {
+	return &Reporter{
+		db:            db,
+		workPath:      cfg.GetWorkPath(),
+		mainBranch:    cfg.GetMainBranch(),
+		releasePrefix: versionname.ReleaseBranchPrefix(cfg),
+	}
+}
+
+// Report returns the commits reachable from release branches but not from the main branch. Commits which were
+// cherry-picked are recognised by their patch ID and the same change on several release branches is listed once.
+// By default only release branches of versions released in the repository are examined, as unreleased ones are still
+// in progress.
+func (r *Reporter) Report(ctx context.Context, repositoryID int, includeUnreleased bool) (*Report, error) {
+	repo := &repositorystore.Repository{ID: repositoryID}
+	if err := repo.Read(ctx, r.db); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRepositoryNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	gitRepo := gitcli.New(repo.URL, gitcli.Path(r.workPath, repo.ID))
+
+	branches, err := gitRepo.Branches(ctx)
+	if errors.Is(err, gitcli.ErrNotCloned) {
+		return nil, ErrNotSynchronised
+	} else if err != nil {
+		return nil, err
+	}
+
+	releases, err := r.releaseBranches(ctx, repo.ID, branches, includeUnreleased)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		RepositoryID:    repo.ID,
+		MainBranch:      r.mainBranch,
+		ReleaseBranches: releases,
+		Commits:         []*Commit{},
+		Tickets:         []string{},
+	}
+
+	commits := make(map[string]*Commit)
+	tickets := make(map[string]bool)
+
+	for _, release := range releases {
+		missing, err := gitRepo.MissingCommits(ctx, r.mainBranch, release)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range missing {
+			c, ok := commits[m.PatchID]
+			if !ok {
+				c = &Commit{
+					PatchID: m.PatchID,
+					Hash:    m.Hash,
+					Author:  m.Author,
+					Date:    m.Date,
+					Subject: m.Subject,
+					Tickets: ticketkey.FindAll(m.Subject),
+				}
+
+				commits[m.PatchID] = c
+				report.Commits = append(report.Commits, c)
+			}
+
+			c.ReleaseBranches = append(c.ReleaseBranches, release)
+
+			for _, t := range c.Tickets {
+				tickets[t] = true
+			}
+		}
+	}
+
+	for t := range tickets {
+		report.Tickets = append(report.Tickets, t)
+	}
+
+	sort.Strings(report.Tickets)
+
+	return report, nil
+}
+
+// releaseBranches returns the release branches ordered by version, unless unreleased ones are included only those of
+// versions released in the repository
+func (r *Reporter) releaseBranches(
+	ctx context.Context,
+	repositoryID int,
+	branches []string,
+	includeUnreleased bool,
+) ([]string, error) {
+	type release struct {
+		branch  string
+		version string
+	}
+
+	var released versionstore.Releases
+	if err := released.ReadByRepository(ctx, r.db, repositoryID); err != nil {
+		return nil, fmt.Errorf("failed to load releases: %w", err)
+	}
+
+	byKey := released.ByKey()
+
+	var releases []release
+
+	for _, b := range branches {
+		v := versionname.FromBranch(r.releasePrefix, b)
+		if v == "" {
+			continue
+		}
+
+		if !includeUnreleased {
+			version := &versionstore.Version{Version: v}
+
+			err := version.ReadByVersion(ctx, r.db)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("failed to load version %s: %w", v, err)
+			}
+
+			if _, ok := byKey[versionstore.ReleaseKey{VersionID: version.ID, RepositoryID: repositoryID}]; !ok {
+				continue
+			}
+		}
+
+		releases = append(releases, release{branch: b, version: v})
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return versionname.Compare(releases[i].version, releases[j].version) < 0
+	})
+
+	names := make([]string, 0, len(releases))
+	for _, rel := range releases {
+		names = append(names, rel.branch)
+	}
+
+	return names, nil
+}
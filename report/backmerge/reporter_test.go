@@ -0,0 +1,160 @@
+package backmerge_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitsync"
+	"github.com/rebel-l/branma_be/report/backmerge"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+)
+
+const (
+	testCluster = "test_backmerge"
+)
+
+func TestReporter_Report(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "report")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	remote := test.NewGitRemote(t, testCluster, "report")
+	remote.Branch("release/1.0.0", "master")
+	missing := remote.Commit("release/1.0.0", "a.txt", "JIRA-5 hotfix never merged back")
+	picked := remote.Commit("release/1.0.0", "b.txt", "JIRA-6 hotfix cherry picked")
+	remote.Tag("v1.0.0", "release/1.0.0")
+	remote.Branch("release/1.1.0", "release/1.0.0")
+	remote.Commit("release/1.1.0", "c.txt", "JIRA-7 stabilisation of upcoming release")
+	remote.Git("checkout", "--quiet", "master")
+	remote.Git("cherry-pick", picked)
+
+	// version 1.1.0 is released in another repository only
+	other := test.NewGitRemote(t, testCluster, "reportOther")
+	other.Branch("release/1.1.0", "master")
+	other.Commit("release/1.1.0", "d.txt", "JIRA-8 released in other repository")
+	other.Tag("v1.1.0", "release/1.1.0")
+
+	repo := &repositorystore.Repository{Name: "repo", URL: remote.Path}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if err := (&repositorystore.Repository{Name: "other", URL: other.Path}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	workPath := filepath.Join(filepath.Dir(remote.Path), "work")
+	cfg := &config.Git{WorkPath: &workPath}
+
+	syncer, err := gitsync.New(db, cfg)
+	if err != nil {
+		t.Fatalf("failed to create syncer: %v", err)
+	}
+
+	if _, err = syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	reporter := backmerge.New(db, cfg)
+
+	// 2. versions released in the repository only
+	report, err := reporter.Report(context.Background(), repo.ID, false)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.MainBranch != "master" || len(report.ReleaseBranches) != 1 || report.ReleaseBranches[0] != "release/1.0.0" {
+		t.Errorf("expected only release/1.0.0 to be compared with master but got %#v", report)
+	}
+
+	if len(report.Commits) != 1 {
+		t.Fatalf("expected 1 missing commit but got %d", len(report.Commits))
+	}
+
+	if report.Commits[0].Hash != missing || report.Commits[0].PatchID == "" {
+		t.Errorf("expected commit %s to be missing but got %#v", missing, report.Commits[0])
+	}
+
+	testStrings(t, []string{"JIRA-5"}, report.Tickets)
+
+	// 3. including unreleased versions, the same change is listed once
+	report, err = reporter.Report(context.Background(), repo.ID, true)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testStrings(t, []string{"release/1.0.0", "release/1.1.0"}, report.ReleaseBranches)
+	testStrings(t, []string{"JIRA-5", "JIRA-7"}, report.Tickets)
+
+	if len(report.Commits) != 2 {
+		t.Fatalf("expected 2 missing commits but got %d", len(report.Commits))
+	}
+
+	testStrings(t, []string{"release/1.0.0", "release/1.1.0"}, report.Commits[0].ReleaseBranches)
+	testStrings(t, []string{"release/1.1.0"}, report.Commits[1].ReleaseBranches)
+}
+
+func TestReporter_Report_Errors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "reportErrors")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "not synchronised"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	workPath := filepath.Join(".", "not_existing")
+	reporter := backmerge.New(db, &config.Git{WorkPath: &workPath})
+
+	// 2. test
+	_, err := reporter.Report(context.Background(), 2, false)
+	if !errors.Is(err, backmerge.ErrRepositoryNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", backmerge.ErrRepositoryNotFound, err)
+	}
+
+	_, err = reporter.Report(context.Background(), repo.ID, false)
+	if !errors.Is(err, backmerge.ErrNotSynchronised) {
+		t.Errorf("expected error '%v' but got '%v'", backmerge.ErrNotSynchronised, err)
+	}
+}
+
+func testStrings(t *testing.T, expected, actual []string) {
+	t.Helper()
+
+	if len(expected) != len(actual) {
+		t.Errorf("expected %v but got %v", expected, actual)
+		return
+	}
+
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Errorf("expected %v but got %v", expected, actual)
+			return
+		}
+	}
+}
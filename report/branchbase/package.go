@@ -0,0 +1,2 @@
+// Package branchbase reports branches which were cut from another base than expected for their ticket type
+package branchbase
@@ -0,0 +1,18 @@
+package branchbase
+
+// Branch represents a branch cut from an unexpected base
+type Branch struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	TicketID     string `json:"ticket_id"`
+	TicketType   string `json:"ticket_type"`
+	BaseBranch   string `json:"base_branch"`
+	ExpectedBase string `json:"expected_base"`
+}
+
+// Report lists the open branches of a repository which were cut from an unexpected base
+type Report struct {
+	RepositoryID int       `json:"repository_id"`
+	MainBranch   string    `json:"main_branch"`
+	Branches     []*Branch `json:"branches"`
+}
@@ -0,0 +1,118 @@
+package branchbase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionname"
+)
+
+// ErrRepositoryNotFound occurs if the repository doesn't exist
+var ErrRepositoryNotFound = errors.New("repository was not found")
+
+// Reporter creates reports about the bases of branches detected by the git synchronisation
+type Reporter struct {
+	db            *sqlx.DB
+	cfg           *config.Git
+	mainBranch    string
+	releasePrefix string
+}
+
+// New returns a new reporter
+func New(db *sqlx.DB, cfg *config.Git) *Reporter {
+	return &Reporter{
+		db:            db,
+		cfg:           cfg,
+		mainBranch:    cfg.GetMainBranch(),
+		releasePrefix: versionname.ReleaseBranchPrefix(cfg),
+	}
+}
+
+// Report returns the open branches of the repository whose base doesn't match the one expected for their ticket type.
+// Branches without detected base or without expectation for their ticket type are not reported. If a category is given,
+// only the branches of tickets of this category are reported.
+func (r *Reporter) Report(ctx context.Context, repositoryID int, category string) (*Report, error) {
+	repo := &repositorystore.Repository{ID: repositoryID}
+	if err := repo.Read(ctx, r.db); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRepositoryNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	branches := branchstore.Branches{}
+	if err := branches.ReadByRepository(ctx, r.db, repo.ID); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	var ticketIDs []string
+	for _, b := range branches {
+		if b.TicketID != "" {
+			ticketIDs = append(ticketIDs, b.TicketID)
+		}
+	}
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadByKeys(ctx, r.db, ticketIDs); err != nil {
+		return nil, fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	byKey := tickets.ByKey()
+
+	report := &Report{
+		RepositoryID: repo.ID,
+		MainBranch:   r.mainBranch,
+		Branches:     []*Branch{},
+	}
+
+	for _, b := range branches {
+		if b.Closed || b.BaseBranch == "" {
+			continue
+		}
+
+		var ticketType, ticketCategory string
+		if t, ok := byKey[b.TicketID]; ok {
+			ticketType, ticketCategory = t.Type, t.Category
+		}
+
+		if category != "" && ticketCategory != category {
+			continue
+		}
+
+		expected := r.cfg.GetExpectedBase(ticketType)
+		if expected == "" || expected == r.kind(b.BaseBranch) {
+			continue
+		}
+
+		report.Branches = append(report.Branches, &Branch{
+			ID:           b.ID,
+			Name:         b.Name,
+			TicketID:     b.TicketID,
+			TicketType:   ticketType,
+			BaseBranch:   b.BaseBranch,
+			ExpectedBase: expected,
+		})
+	}
+
+	return report, nil
+}
+
+// kind returns whether the base is the main branch or a release branch
+func (r *Reporter) kind(base string) string {
+	if versionname.FromBranch(r.releasePrefix, base) != "" {
+		return config.BaseRelease
+	}
+
+	if base == r.mainBranch {
+		return config.BaseMain
+	}
+
+	return ""
+}
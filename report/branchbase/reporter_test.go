@@ -0,0 +1,138 @@
+package branchbase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/report/branchbase"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+const (
+	testCluster = "test_branchbase"
+)
+
+func TestReporter_Report(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "report")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "https://github.com/rebel-l/repo"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	tickets := map[string]string{
+		"JIRA-1": "Story",
+		"JIRA-2": "Story",
+		"JIRA-3": "Bug",
+		"JIRA-4": "Bug",
+		"JIRA-5": "Bug",
+		"JIRA-6": "Story",
+		"JIRA-7": "Task",
+	}
+
+	for key, ticketType := range tickets {
+		ticket := &ticketstore.Ticket{Key: key, Type: ticketType}
+		if key == "JIRA-3" {
+			ticket.Category = config.CategoryInProgress
+		}
+
+		if err := ticket.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	branches := []*branchstore.Branch{
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", BaseBranch: "release/1.0.0"},
+		{Name: "feature/JIRA-2", TicketID: "JIRA-2", BaseBranch: "master"},
+		{Name: "hotfix/JIRA-3", TicketID: "JIRA-3", BaseBranch: "master"},
+		{Name: "hotfix/JIRA-4", TicketID: "JIRA-4", BaseBranch: "release/1.0.0"},
+		{Name: "hotfix/JIRA-5", TicketID: "JIRA-5", BaseBranch: "master", Closed: true},
+		{Name: "feature/JIRA-6", TicketID: "JIRA-6"},
+		{Name: "task/JIRA-7", TicketID: "JIRA-7", BaseBranch: "release/1.0.0"},
+		{Name: "feature/JIRA-8", TicketID: "JIRA-8", BaseBranch: "release/1.0.0"},
+	}
+
+	for _, b := range branches {
+		b.RepositoryID = repo.ID
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	reporter := branchbase.New(db, &config.Git{
+		ExpectedBases: map[string]string{"Story": config.BaseMain, "Bug": config.BaseRelease},
+	})
+
+	// 2. test
+	report, err := reporter.Report(context.Background(), repo.ID, "")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.RepositoryID != repo.ID || report.MainBranch != "master" {
+		t.Errorf("unexpected report %#v", report)
+	}
+
+	if len(report.Branches) != 2 {
+		t.Fatalf("expected 2 branches with unexpected base but got %d", len(report.Branches))
+	}
+
+	if report.Branches[0].Name != "feature/JIRA-1" || report.Branches[0].ExpectedBase != config.BaseMain {
+		t.Errorf("expected feature/JIRA-1 to be cut from main branch but got %#v", report.Branches[0])
+	}
+
+	if report.Branches[1].Name != "hotfix/JIRA-3" || report.Branches[1].ExpectedBase != config.BaseRelease ||
+		report.Branches[1].BaseBranch != "master" || report.Branches[1].TicketType != "Bug" {
+		t.Errorf("expected hotfix/JIRA-3 to be cut from release branch but got %#v", report.Branches[1])
+	}
+
+	report, err = reporter.Report(context.Background(), repo.ID, config.CategoryInProgress)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(report.Branches) != 1 || report.Branches[0].Name != "hotfix/JIRA-3" {
+		t.Errorf("expected only hotfix/JIRA-3 in progress but got %#v", report.Branches)
+	}
+}
+
+func TestReporter_Report_NotFound(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "reportNotFound")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	reporter := branchbase.New(db, &config.Git{})
+
+	// 2. test
+	_, err := reporter.Report(context.Background(), 1, "")
+	if !errors.Is(err, branchbase.ErrRepositoryNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", branchbase.ErrRepositoryNotFound, err)
+	}
+}
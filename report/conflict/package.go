@@ -0,0 +1,2 @@
+// Package conflict predicts merge conflicts between open branches by comparing the files and lines they change
+package conflict
@@ -0,0 +1,41 @@
+package conflict
+
+// File represents a file changed by both branches of a conflict
+type File struct {
+	Path string `json:"path"`
+
+	// Lines is true if both branches change the same lines, otherwise they only change the same file
+	Lines bool `json:"lines"`
+}
+
+// Conflict represents a pair of branches which are likely to conflict when they are merged one after the other
+type Conflict struct {
+	RepositoryID  int     `json:"repository_id"`
+	BranchID      int     `json:"branch_id"`
+	Branch        string  `json:"branch"`
+	OtherBranchID int     `json:"other_branch_id"`
+	OtherBranch   string  `json:"other_branch"`
+	Files         []*File `json:"files"`
+}
+
+// Lines returns the number of files both branches change the same lines in
+func (c *Conflict) Lines() int {
+	var count int
+
+	for _, f := range c.Files {
+		if f.Lines {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Report lists the predicted conflicts between open branches of a repository or version
+type Report struct {
+	RepositoryID int         `json:"repository_id,omitempty"`
+	VersionID    int         `json:"version_id,omitempty"`
+	BaseBranch   string      `json:"base_branch"`
+	Branches     int         `json:"branches"`
+	Conflicts    []*Conflict `json:"conflicts"`
+}
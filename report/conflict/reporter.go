@@ -0,0 +1,387 @@
+package conflict
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitcli"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionname"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+var (
+	// ErrRepositoryNotFound occurs if the repository doesn't exist
+	ErrRepositoryNotFound = errors.New("repository was not found")
+
+	// ErrVersionNotFound occurs if the version doesn't exist
+	ErrVersionNotFound = errors.New("version was not found")
+
+	// ErrNotSynchronised occurs if the repository was not synchronised with git yet
+	ErrNotSynchronised = errors.New("repository was not synchronised with git yet")
+)
+
+// Reporter creates conflict reports based on the local mirrors of the git repositories
+type Reporter struct {
+	db            *sqlx.DB
+	workPath      string
+	mainBranch    string
+	releasePrefix string
+}
+
+// New returns a new reporter
+func New(db *sqlx.DB, cfg *config.Git) *Reporter {
+	return &Reporter{
+		db:            db,
+		workPath:      cfg.GetWorkPath(),
+		mainBranch:    cfg.GetMainBranch(),
+		releasePrefix: versionname.ReleaseBranchPrefix(cfg),
+	}
+}
+
+// ReportByRepository returns the pairs of open branches of the repository which change the same files. The branches
+// are compared against the tip of the main branch. If a category is given, only the branches of tickets of this
+// category are compared.
+func (r *Reporter) ReportByRepository(ctx context.Context, repositoryID int, category string) (*Report, error) {
+	repo := &repositorystore.Repository{ID: repositoryID}
+	if err := repo.Read(ctx, r.db); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrRepositoryNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	branches := branchstore.Branches{}
+	if err := branches.ReadByRepository(ctx, r.db, repo.ID); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	branches, err := r.ofCategory(ctx, branches, category)
+	if err != nil {
+		return nil, err
+	}
+
+	gitRepo, existing, err := r.open(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, count, err := r.conflicts(ctx, repo, gitRepo, existing, r.mainBranch, branches)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		RepositoryID: repo.ID,
+		BaseBranch:   r.mainBranch,
+		Branches:     count,
+		Conflicts:    conflicts,
+	}
+
+	sortConflicts(report.Conflicts)
+
+	return report, nil
+}
+
+// ReportByVersion returns the pairs of open branches carrying tickets of the version which change the same files. The
+// tickets of the version are the ones of the branches assigned to it and of its releases. The branches are compared
+// against the tip of the release branch of the version in their repository, so branches not merged yet are included
+// and the pending merges can be scheduled. Only branches of the same repository can conflict, repositories without
+// the release branch are skipped. If a category is given, only the branches of tickets of this category are compared.
+func (r *Reporter) ReportByVersion(ctx context.Context, versionID int, category string) (*Report, error) {
+	version := &versionstore.Version{ID: versionID}
+	if err := version.Read(ctx, r.db); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVersionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	byRepository, err := r.versionBranches(ctx, version, category)
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryIDs := make([]int, 0, len(byRepository))
+	for id := range byRepository {
+		repositoryIDs = append(repositoryIDs, id)
+	}
+
+	sort.Ints(repositoryIDs)
+
+	report := &Report{
+		VersionID: version.ID,
+		Conflicts: []*Conflict{},
+	}
+
+	for _, repositoryID := range repositoryIDs {
+		if err = r.addRelease(ctx, report, repositoryID, version.Version, byRepository[repositoryID]); err != nil {
+			return nil, err
+		}
+	}
+
+	sortConflicts(report.Conflicts)
+
+	return report, nil
+}
+
+// addRelease adds the conflicts of the branches onto the release branch of the version in the repository to the report
+func (r *Reporter) addRelease(
+	ctx context.Context,
+	report *Report,
+	repositoryID int,
+	version string,
+	branches branchstore.Branches,
+) error {
+	repo := &repositorystore.Repository{ID: repositoryID}
+	if err := repo.Read(ctx, r.db); err != nil {
+		return fmt.Errorf("failed to load repository %d: %w", repositoryID, err)
+	}
+
+	gitRepo, existing, err := r.open(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	release := r.releaseBranch(existing, version)
+	if release == "" {
+		return nil
+	}
+
+	conflicts, count, err := r.conflicts(ctx, repo, gitRepo, existing, release, branches)
+	if err != nil {
+		return err
+	}
+
+	if report.BaseBranch == "" {
+		report.BaseBranch = release
+	}
+
+	report.Branches += count
+	report.Conflicts = append(report.Conflicts, conflicts...)
+
+	return nil
+}
+
+// versionBranches returns the branches carrying tickets of the version and the category by repository
+func (r *Reporter) versionBranches(
+	ctx context.Context,
+	version *versionstore.Version,
+	category string,
+) (map[int]branchstore.Branches, error) {
+	var assigned branchstore.Branches
+	if err := assigned.ReadByVersion(ctx, r.db, version.ID); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	var released versionstore.VersionTickets
+	if err := released.ReadByVersion(ctx, r.db, version.ID); err != nil {
+		return nil, fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	tickets := make(map[string]bool)
+
+	for _, b := range assigned {
+		if b.TicketID != "" {
+			tickets[b.TicketID] = true
+		}
+	}
+
+	for _, id := range released.TicketIDs() {
+		tickets[id] = true
+	}
+
+	var branches branchstore.Branches
+	if err := branches.ReadWithTicket(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	branches, err := r.ofCategory(ctx, branches, category)
+	if err != nil {
+		return nil, err
+	}
+
+	byRepository := make(map[int]branchstore.Branches)
+
+	for _, b := range branches {
+		if tickets[b.TicketID] {
+			byRepository[b.RepositoryID] = append(byRepository[b.RepositoryID], b)
+		}
+	}
+
+	return byRepository, nil
+}
+
+// ofCategory returns the branches whose ticket is of the category, all branches are returned if no category is given
+func (r *Reporter) ofCategory(
+	ctx context.Context,
+	branches branchstore.Branches,
+	category string,
+) (branchstore.Branches, error) {
+	if category == "" {
+		return branches, nil
+	}
+
+	var ticketIDs []string
+
+	for _, b := range branches {
+		if b.TicketID != "" {
+			ticketIDs = append(ticketIDs, b.TicketID)
+		}
+	}
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadByKeys(ctx, r.db, ticketIDs); err != nil {
+		return nil, fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	byKey := tickets.ByKey()
+	res := branchstore.Branches{}
+
+	for _, b := range branches {
+		if t, ok := byKey[b.TicketID]; ok && t.Category == category {
+			res = append(res, b)
+		}
+	}
+
+	return res, nil
+}
+
+// open returns the local mirror of the repository and its existing branches
+func (r *Reporter) open(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+) (*gitcli.Repository, map[string]bool, error) {
+	gitRepo := gitcli.New(repo.URL, gitcli.Path(r.workPath, repo.ID))
+
+	names, err := gitRepo.Branches(ctx)
+	if errors.Is(err, gitcli.ErrNotCloned) {
+		return nil, nil, ErrNotSynchronised
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	existing := make(map[string]bool)
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	return gitRepo, existing, nil
+}
+
+// releaseBranch returns the name of the release branch of the version or an empty string if it doesn't exist
+func (r *Reporter) releaseBranch(existing map[string]bool, version string) string {
+	var names []string
+
+	for name := range existing {
+		if versionname.FromBranch(r.releasePrefix, name) == version {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+
+	sort.Strings(names)
+
+	return names[0]
+}
+
+// conflicts compares the changes of all open branches onto the base with each other and returns the conflicts found
+// and the number of branches compared
+func (r *Reporter) conflicts(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	gitRepo *gitcli.Repository,
+	existing map[string]bool,
+	base string,
+	branches branchstore.Branches,
+) ([]*Conflict, int, error) {
+	type changeSet struct {
+		branch  *branchstore.Branch
+		changes map[string]*gitcli.FileChange
+	}
+
+	var changeSets []changeSet
+
+	for _, b := range branches {
+		if b.Closed || !existing[b.Name] || b.Name == r.mainBranch || versionname.FromBranch(r.releasePrefix, b.Name) != "" {
+			continue
+		}
+
+		changes, err := gitRepo.ChangesOnto(ctx, base, b.Name)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to compute changes of branch %s: %w", b.Name, err)
+		}
+
+		cs := changeSet{branch: b, changes: make(map[string]*gitcli.FileChange)}
+		for _, c := range changes {
+			cs.changes[c.Path] = c
+		}
+
+		changeSets = append(changeSets, cs)
+	}
+
+	conflicts := []*Conflict{}
+
+	for i, a := range changeSets {
+		for _, b := range changeSets[i+1:] {
+			var files []*File
+
+			for path, change := range a.changes {
+				other, ok := b.changes[path]
+				if !ok {
+					continue
+				}
+
+				files = append(files, &File{Path: path, Lines: change.Overlaps(other)})
+			}
+
+			if len(files) == 0 {
+				continue
+			}
+
+			sort.Slice(files, func(i, j int) bool {
+				return files[i].Path < files[j].Path
+			})
+
+			conflicts = append(conflicts, &Conflict{
+				RepositoryID:  repo.ID,
+				BranchID:      a.branch.ID,
+				Branch:        a.branch.Name,
+				OtherBranchID: b.branch.ID,
+				OtherBranch:   b.branch.Name,
+				Files:         files,
+			})
+		}
+	}
+
+	return conflicts, len(changeSets), nil
+}
+
+// sortConflicts orders the conflicts by severity, conflicting lines first, then the number of files in common
+func sortConflicts(conflicts []*Conflict) {
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		a, b := conflicts[i], conflicts[j]
+
+		switch {
+		case a.Lines() != b.Lines():
+			return a.Lines() > b.Lines()
+		case len(a.Files) != len(b.Files):
+			return len(a.Files) > len(b.Files)
+		case a.RepositoryID != b.RepositoryID:
+			return a.RepositoryID < b.RepositoryID
+		case a.Branch != b.Branch:
+			return a.Branch < b.Branch
+		default:
+			return a.OtherBranch < b.OtherBranch
+		}
+	})
+}
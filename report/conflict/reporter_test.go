@@ -0,0 +1,203 @@
+package conflict_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/git/gitsync"
+	"github.com/rebel-l/branma_be/report/conflict"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	testCluster = "test_conflict"
+)
+
+func TestReporter_Report(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "report")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	remote := test.NewGitRemote(t, testCluster, "report")
+	remote.Write("master", "c.txt", "1\n2\n3\n4\n5\n6\n", "add c.txt")
+
+	remote.Branch("feature/JIRA-1", "master")
+	remote.Commit("feature/JIRA-1", "a.txt", "JIRA-1 same lines")
+	remote.Branch("feature/JIRA-2", "master")
+	remote.Commit("feature/JIRA-2", "a.txt", "JIRA-2 same lines")
+	remote.Branch("feature/JIRA-3", "master")
+	remote.Write("feature/JIRA-3", "c.txt", "one\n2\n3\n4\n5\n6\n", "JIRA-3 same file")
+	remote.Branch("feature/JIRA-4", "master")
+	remote.Write("feature/JIRA-4", "c.txt", "1\n2\n3\n4\n5\n6\n7\n", "JIRA-4 same file")
+	remote.Branch("feature/JIRA-5", "master")
+	remote.Commit("feature/JIRA-5", "e.txt", "JIRA-5 no conflict")
+
+	remote.Branch("release/1.0.0", "master")
+	remote.Merge("release/1.0.0", "feature/JIRA-3")
+	remote.Merge("release/1.0.0", "feature/JIRA-4")
+	remote.Merge("release/1.0.0", "feature/JIRA-5")
+
+	repo := &repositorystore.Repository{Name: "repo", URL: remote.Path}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for key, category := range map[string]string{
+		"JIRA-1": config.CategoryReview,
+		"JIRA-2": config.CategoryReview,
+		"JIRA-3": config.CategoryInProgress,
+	} {
+		if err := (&ticketstore.Ticket{Key: key, Category: category}).Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	workPath := filepath.Join(filepath.Dir(remote.Path), "work")
+	cfg := &config.Git{WorkPath: &workPath}
+
+	syncer, err := gitsync.New(db, cfg)
+	if err != nil {
+		t.Fatalf("failed to create syncer: %v", err)
+	}
+
+	if _, err = syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	reporter := conflict.New(db, cfg)
+
+	// 2. by repository
+	report, err := reporter.ReportByRepository(context.Background(), repo.ID, "")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.RepositoryID != repo.ID || report.BaseBranch != "master" || report.Branches != 5 {
+		t.Errorf("expected 5 branches of repository compared with master but got %#v", report)
+	}
+
+	if len(report.Conflicts) != 2 {
+		t.Fatalf("expected 2 conflicts but got %d", len(report.Conflicts))
+	}
+
+	testConflict(t, report.Conflicts[0], "feature/JIRA-1", "feature/JIRA-2", "a.txt", true)
+	testConflict(t, report.Conflicts[1], "feature/JIRA-3", "feature/JIRA-4", "c.txt", false)
+
+	report, err = reporter.ReportByRepository(context.Background(), repo.ID, config.CategoryReview)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.Branches != 2 || len(report.Conflicts) != 1 {
+		t.Fatalf("expected 2 branches in review with 1 conflict but got %#v", report)
+	}
+
+	testConflict(t, report.Conflicts[0], "feature/JIRA-1", "feature/JIRA-2", "a.txt", true)
+
+	// 3. by version compares the pending branches of its tickets against the release branch
+	remote.Branch("bugfix/JIRA-3", "release/1.0.0")
+	remote.Commit("bugfix/JIRA-3", "a.txt", "JIRA-3 same lines on release")
+	remote.Branch("bugfix/JIRA-4", "release/1.0.0")
+	remote.Commit("bugfix/JIRA-4", "a.txt", "JIRA-4 same lines on release")
+
+	if _, err = syncer.Sync(context.Background()); err != nil {
+		t.Fatalf("failed to sync: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err = version.ReadByVersion(context.Background(), db); err != nil {
+		t.Fatalf("failed to load version: %v", err)
+	}
+
+	report, err = reporter.ReportByVersion(context.Background(), version.ID, "")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.VersionID != version.ID || report.BaseBranch != "release/1.0.0" || report.Branches != 5 {
+		t.Errorf("expected 5 branches of version compared with release/1.0.0 but got %#v", report)
+	}
+
+	if len(report.Conflicts) != 1 {
+		t.Fatalf("expected 1 conflict but got %d", len(report.Conflicts))
+	}
+
+	testConflict(t, report.Conflicts[0], "bugfix/JIRA-3", "bugfix/JIRA-4", "a.txt", true)
+
+	report, err = reporter.ReportByVersion(context.Background(), version.ID, config.CategoryInProgress)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.Branches != 2 || len(report.Conflicts) != 0 {
+		t.Errorf("expected 2 branches of JIRA-3 in progress without conflict but got %#v", report)
+	}
+}
+
+func TestReporter_Report_Errors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "reportErrors")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "not synchronised"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	workPath := filepath.Join(".", "not_existing")
+	reporter := conflict.New(db, &config.Git{WorkPath: &workPath})
+
+	// 2. test
+	_, err := reporter.ReportByRepository(context.Background(), 2, "")
+	if !errors.Is(err, conflict.ErrRepositoryNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", conflict.ErrRepositoryNotFound, err)
+	}
+
+	_, err = reporter.ReportByRepository(context.Background(), repo.ID, "")
+	if !errors.Is(err, conflict.ErrNotSynchronised) {
+		t.Errorf("expected error '%v' but got '%v'", conflict.ErrNotSynchronised, err)
+	}
+
+	_, err = reporter.ReportByVersion(context.Background(), 1, "")
+	if !errors.Is(err, conflict.ErrVersionNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", conflict.ErrVersionNotFound, err)
+	}
+}
+
+func testConflict(t *testing.T, actual *conflict.Conflict, branch, otherBranch, file string, lines bool) {
+	t.Helper()
+
+	if actual.Branch != branch || actual.OtherBranch != otherBranch || actual.BranchID == 0 || actual.OtherBranchID == 0 {
+		t.Errorf("expected conflict between %s and %s but got %#v", branch, otherBranch, actual)
+	}
+
+	if len(actual.Files) != 1 || actual.Files[0].Path != file || actual.Files[0].Lines != lines {
+		t.Errorf("expected conflict in file %s with lines %t but got %#v", file, lines, actual.Files)
+	}
+}
@@ -0,0 +1,2 @@
+// Package epic rolls up the progress of the branches of all tickets belonging to an epic across the repositories
+package epic
@@ -0,0 +1,43 @@
+package epic
+
+// Branch states of the rollup
+const (
+	// StateOpen is the state of a branch not merged yet
+	StateOpen = "open"
+
+	// StateMerged is the state of a branch merged into a release branch or deleted, but not released yet
+	StateMerged = "merged"
+
+	// StateReleased is the state of a branch contained in a released version
+	StateReleased = "released"
+)
+
+// Version represents a version containing tickets of the epic
+type Version struct {
+	ID       int    `json:"id"`
+	Version  string `json:"version"`
+	Released bool   `json:"released"`
+}
+
+// Epic represents the progress of all branches of the tickets belonging to an epic
+type Epic struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+
+	// Tickets is the number of tickets belonging to the epic, the epic itself is not counted
+	Tickets    int      `json:"tickets"`
+	TicketKeys []string `json:"ticket_keys"`
+
+	BranchesOpen     int `json:"branches_open"`
+	BranchesMerged   int `json:"branches_merged"`
+	BranchesReleased int `json:"branches_released"`
+
+	RepositoryIDs []int      `json:"repository_ids"`
+	Versions      []*Version `json:"versions"`
+}
+
+// Report represents the rollup of the epics ordered by key
+type Report struct {
+	Epics []*Epic `json:"epics"`
+}
@@ -0,0 +1,273 @@
+package epic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionname"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+// ErrNotFound occurs if the epic requested has no tickets
+var ErrNotFound = errors.New("epic not found")
+
+// Reporter creates the epic rollups based on the parents of the tickets synchronised from JIRA
+type Reporter struct {
+	db *sqlx.DB
+}
+
+// New returns a new reporter
+func New(db *sqlx.DB) *Reporter {
+	return &Reporter{db: db}
+}
+
+// Report returns the rollup of all epics or, if a key is given, of that epic only. A ticket belongs to the topmost
+// ticket of its parent chain, so sub-tasks are rolled up into the epic of their story. If a category is given, only the
+// tickets of this category are rolled up and epics without such tickets are left out.
+func (r *Reporter) Report(ctx context.Context, key, category string) (*Report, error) {
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadAll(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	byKey := tickets.ByKey()
+	children := make(map[string][]string)
+	found := false
+
+	for _, t := range tickets {
+		if t.Parent == "" {
+			continue
+		}
+
+		epicKey := root(t, byKey)
+		if key != "" && epicKey != key {
+			continue
+		}
+
+		found = true
+
+		if category == "" || t.Category == category {
+			children[epicKey] = append(children[epicKey], t.Key)
+		}
+	}
+
+	if key != "" && !found {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+
+	c, err := r.load(ctx, children)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Epics: []*Epic{}}
+
+	for epicKey, keys := range children {
+		report.Epics = append(report.Epics, c.rollup(epicKey, keys, byKey[epicKey]))
+	}
+
+	sort.Slice(report.Epics, func(i, j int) bool {
+		return report.Epics[i].Key < report.Epics[j].Key
+	})
+
+	return report, nil
+}
+
+// root returns the key of the topmost ticket of the parent chain, parents not synchronised end the chain
+func root(t *ticketstore.Ticket, byKey map[string]*ticketstore.Ticket) string {
+	key := t.Key
+	visited := map[string]bool{key: true}
+
+	for t != nil && t.Parent != "" && !visited[t.Parent] {
+		key = t.Parent
+		visited[key] = true
+		t = byKey[key]
+	}
+
+	return key
+}
+
+// contents holds the branches and versions of the tickets belonging to the epics
+type contents struct {
+	branches       map[string]branchstore.Branches
+	branchVersions map[int]int
+	ticketVersions map[string]versionstore.VersionTickets
+	versions       map[int]*versionstore.Version
+	releases       map[versionstore.ReleaseKey]*versionstore.Release
+}
+
+// load loads the branches and versions of the epics and their tickets
+func (r *Reporter) load(ctx context.Context, children map[string][]string) (*contents, error) {
+	var keys []string
+
+	relevant := make(map[string]bool)
+
+	for epicKey, ticketKeys := range children {
+		for _, k := range append([]string{epicKey}, ticketKeys...) {
+			if !relevant[k] {
+				relevant[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	var branches branchstore.Branches
+	if err := branches.ReadWithTicket(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	var branchVersions versionstore.BranchVersions
+	if err := branchVersions.ReadAll(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load branch versions: %w", err)
+	}
+
+	var ticketVersions versionstore.VersionTickets
+	if err := ticketVersions.ReadByTickets(ctx, r.db, keys); err != nil {
+		return nil, fmt.Errorf("failed to load versions of tickets: %w", err)
+	}
+
+	var versions versionstore.Versions
+	if err := versions.ReadAll(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load versions: %w", err)
+	}
+
+	var releases versionstore.Releases
+	if err := releases.ReadAll(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load releases: %w", err)
+	}
+
+	c := &contents{
+		branches:       make(map[string]branchstore.Branches),
+		branchVersions: make(map[int]int),
+		ticketVersions: make(map[string]versionstore.VersionTickets),
+		versions:       versions.ByID(),
+		releases:       releases.ByKey(),
+	}
+
+	for _, b := range branches {
+		if relevant[b.TicketID] {
+			c.branches[b.TicketID] = append(c.branches[b.TicketID], b)
+		}
+	}
+
+	for _, bv := range branchVersions {
+		c.branchVersions[bv.BranchID] = bv.VersionID
+	}
+
+	for _, vt := range ticketVersions {
+		c.ticketVersions[vt.TicketID] = append(c.ticketVersions[vt.TicketID], vt)
+	}
+
+	return c, nil
+}
+
+// rollup aggregates the branches and versions of the epic and its tickets, the epic ticket is nil if it isn't
+// synchronised
+func (c *contents) rollup(key string, ticketKeys []string, ticket *ticketstore.Ticket) *Epic {
+	sort.Strings(ticketKeys)
+
+	e := &Epic{
+		Key:           key,
+		Tickets:       len(ticketKeys),
+		TicketKeys:    ticketKeys,
+		RepositoryIDs: []int{},
+		Versions:      []*Version{},
+	}
+
+	if ticket != nil {
+		e.Summary = ticket.Summary
+		e.Status = ticket.Status
+	}
+
+	repositories := make(map[int]bool)
+
+	// versions maps the versions of the epic to true if they were released in a repository containing the epic
+	versions := make(map[int]bool)
+
+	for _, k := range append([]string{key}, ticketKeys...) {
+		for _, vt := range c.ticketVersions[k] {
+			versions[vt.VersionID] = versions[vt.VersionID] || c.released(vt.VersionID, vt.RepositoryID)
+		}
+
+		for _, b := range c.branches[k] {
+			repositories[b.RepositoryID] = true
+
+			if id, ok := c.branchVersions[b.ID]; ok {
+				versions[id] = versions[id] || c.released(id, b.RepositoryID)
+			}
+
+			switch c.state(b, c.ticketReleased(k, b.RepositoryID)) {
+			case StateReleased:
+				e.BranchesReleased++
+			case StateMerged:
+				e.BranchesMerged++
+			default:
+				e.BranchesOpen++
+			}
+		}
+	}
+
+	for id := range repositories {
+		e.RepositoryIDs = append(e.RepositoryIDs, id)
+	}
+
+	sort.Ints(e.RepositoryIDs)
+
+	for id, released := range versions {
+		if v, ok := c.versions[id]; ok {
+			e.Versions = append(e.Versions, &Version{ID: v.ID, Version: v.Version, Released: released})
+		}
+	}
+
+	sort.Slice(e.Versions, func(i, j int) bool {
+		return versionname.Compare(e.Versions[i].Version, e.Versions[j].Version) < 0
+	})
+
+	return e
+}
+
+// state returns the state of the branch, ticketReleased is true if the ticket is contained in a version released in
+// the repository of the branch. A deleted branch not assigned to any version counts as released if its ticket was
+// released.
+func (c *contents) state(b *branchstore.Branch, ticketReleased bool) string {
+	id, assigned := c.branchVersions[b.ID]
+
+	switch {
+	case assigned && c.released(id, b.RepositoryID), !assigned && b.Closed && ticketReleased:
+		return StateReleased
+	case assigned, b.Closed:
+		return StateMerged
+	default:
+		return StateOpen
+	}
+}
+
+// ticketReleased returns true if the ticket is contained in a version released in the repository, tickets without a
+// repository count for all repositories
+func (c *contents) ticketReleased(key string, repositoryID int) bool {
+	for _, vt := range c.ticketVersions[key] {
+		if (vt.RepositoryID == repositoryID || vt.RepositoryID == 0) && c.released(vt.VersionID, vt.RepositoryID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// released returns true if the version was released in the repository. Tickets of versions recorded before releases
+// were tracked per repository have no repository, for them it is enough that the version was released anywhere.
+func (c *contents) released(versionID, repositoryID int) bool {
+	if repositoryID == 0 {
+		return c.versions[versionID].IsReleased()
+	}
+
+	_, ok := c.releases[versionstore.ReleaseKey{VersionID: versionID, RepositoryID: repositoryID}]
+
+	return ok
+}
@@ -0,0 +1,221 @@
+package epic_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/report/epic"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	testCluster = "test_epic"
+)
+
+func setup(t *testing.T, name string) *sqlx.DB { // nolint:funlen
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+	ctx := context.Background()
+
+	for _, repoName := range []string{"repo1", "repo2"} {
+		repo := &repositorystore.Repository{Name: repoName, URL: repoName + ".url"}
+		if err := repo.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, ticket := range []*ticketstore.Ticket{
+		{Key: "EPIC-1", Summary: "first epic", Status: "In Progress", Type: "Epic"},
+		{Key: "JIRA-1", Parent: "EPIC-1", Category: config.CategoryDone},
+		{Key: "JIRA-2", Parent: "EPIC-1", Category: config.CategoryReview},
+		{Key: "JIRA-3", Parent: "JIRA-2", Type: "Sub-task"},
+		{Key: "JIRA-4", Parent: "EPIC-2"},
+		{Key: "JIRA-5"},
+	} {
+		if err := ticket.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	releasedAt := time.Now()
+
+	for _, v := range []*versionstore.Version{{Version: "1.1.0"}, {Version: "1.0.0", ReleasedAt: &releasedAt}} {
+		if err := v.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	versionIDs := map[string]int{"": 0, "1.1.0": 1, "1.0.0": 2}
+
+	release := &versionstore.Release{VersionID: versionIDs["1.0.0"], RepositoryID: 1, ReleasedAt: releasedAt}
+	if err := release.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, b := range []struct {
+		branch  *branchstore.Branch
+		version string
+	}{
+		{branch: &branchstore.Branch{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 1}, version: "1.0.0"},
+		{branch: &branchstore.Branch{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 2}},
+		{branch: &branchstore.Branch{Name: "feature/JIRA-2", TicketID: "JIRA-2", RepositoryID: 2}, version: "1.1.0"},
+		{branch: &branchstore.Branch{Name: "feature/JIRA-3", TicketID: "JIRA-3", RepositoryID: 1, Closed: true}},
+		{branch: &branchstore.Branch{Name: "feature/JIRA-4", TicketID: "JIRA-4", RepositoryID: 1}},
+		{branch: &branchstore.Branch{Name: "feature/JIRA-5", TicketID: "JIRA-5", RepositoryID: 1}},
+	} {
+		if err := b.branch.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+
+		if b.version == "" {
+			continue
+		}
+
+		bv := &versionstore.BranchVersion{BranchID: b.branch.ID, VersionID: versionIDs[b.version]}
+		if err := bv.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	return db
+}
+
+func TestReporter_Report(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "report")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	reporter := epic.New(db)
+
+	// 2. test
+	report, err := reporter.Report(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(report.Epics) != 2 || report.Epics[0].Key != "EPIC-1" || report.Epics[1].Key != "EPIC-2" {
+		t.Fatalf("expected epics EPIC-1 and EPIC-2 but got %#v", report.Epics)
+	}
+
+	e := report.Epics[0]
+	if e.Summary != "first epic" || e.Status != "In Progress" || e.Tickets != 3 || len(e.TicketKeys) != 3 ||
+		e.TicketKeys[2] != "JIRA-3" {
+		t.Errorf("expected EPIC-1 with tickets JIRA-1 to JIRA-3 but got %#v", e)
+	}
+
+	if e.BranchesOpen != 1 || e.BranchesMerged != 2 || e.BranchesReleased != 1 {
+		t.Errorf("expected 1 open, 2 merged and 1 released branch but got %d, %d and %d",
+			e.BranchesOpen, e.BranchesMerged, e.BranchesReleased)
+	}
+
+	if len(e.RepositoryIDs) != 2 || e.RepositoryIDs[0] != 1 || e.RepositoryIDs[1] != 2 {
+		t.Errorf("expected repositories [1 2] but got %v", e.RepositoryIDs)
+	}
+
+	if len(e.Versions) != 2 || e.Versions[0].Version != "1.0.0" || !e.Versions[0].Released ||
+		e.Versions[1].Version != "1.1.0" || e.Versions[1].Released {
+		t.Errorf("expected released 1.0.0 and unreleased 1.1.0 but got %#v", e.Versions)
+	}
+
+	e = report.Epics[1]
+	if e.Summary != "" || e.Tickets != 1 || e.BranchesOpen != 1 || len(e.Versions) != 0 {
+		t.Errorf("expected not synchronised EPIC-2 with one open branch but got %#v", e)
+	}
+
+	report, err = reporter.Report(context.Background(), "EPIC-2", "")
+	if err != nil || len(report.Epics) != 1 || report.Epics[0].Key != "EPIC-2" {
+		t.Errorf("expected only EPIC-2 but got %#v: %v", report, err)
+	}
+
+	report, err = reporter.Report(context.Background(), "", config.CategoryReview)
+	if err != nil || len(report.Epics) != 1 || len(report.Epics[0].TicketKeys) != 1 ||
+		report.Epics[0].TicketKeys[0] != "JIRA-2" {
+		t.Errorf("expected only EPIC-1 with JIRA-2 in review but got %#v: %v", report, err)
+	}
+
+	report, err = reporter.Report(context.Background(), "EPIC-2", config.CategoryReview)
+	if err != nil || len(report.Epics) != 0 {
+		t.Errorf("expected no epics for EPIC-2 without tickets in review but got %#v: %v", report, err)
+	}
+
+	if _, err = reporter.Report(context.Background(), "JIRA-5", ""); !errors.Is(err, epic.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", epic.ErrNotFound, err)
+	}
+}
+
+func TestReporter_Report_ReleasedPerRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "reportReleasedPerRepository")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+	releasedAt := time.Now()
+
+	// 1.1.0 is released in repo1 only, the branch of JIRA-2 assigned to it lives in repo2
+	version := &versionstore.Version{ID: 1}
+	if err := version.Read(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	version.ReleasedAt = &releasedAt
+	if err := version.Update(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	release := &versionstore.Release{VersionID: 1, RepositoryID: 1, ReleasedAt: releasedAt}
+	if err := release.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// JIRA-3 is contained in 1.0.0 in repo2 only, its closed branch lives in repo1
+	ticket := &versionstore.VersionTicket{VersionID: 2, RepositoryID: 2, TicketID: "JIRA-3"}
+	if err := ticket.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	report, err := epic.New(db).Report(ctx, "EPIC-1", "")
+	if err != nil || len(report.Epics) != 1 {
+		t.Fatalf("expected EPIC-1 but got %#v: %v", report, err)
+	}
+
+	e := report.Epics[0]
+	if e.BranchesOpen != 1 || e.BranchesMerged != 2 || e.BranchesReleased != 1 {
+		t.Errorf("expected 1 open, 2 merged and 1 released branch but got %d, %d and %d",
+			e.BranchesOpen, e.BranchesMerged, e.BranchesReleased)
+	}
+
+	if len(e.Versions) != 2 || e.Versions[0].Version != "1.0.0" || !e.Versions[0].Released ||
+		e.Versions[1].Version != "1.1.0" || e.Versions[1].Released {
+		t.Errorf("expected released 1.0.0 and 1.1.0 not released in repo2 but got %#v", e.Versions)
+	}
+}
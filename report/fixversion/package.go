@@ -0,0 +1,2 @@
+// Package fixversion reconciles the fix versions of the tickets in JIRA with the actual contents of the versions
+package fixversion
@@ -0,0 +1,36 @@
+package fixversion
+
+// Branch represents a branch bringing a ticket into the version
+type Branch struct {
+	ID           int    `json:"id"`
+	RepositoryID int    `json:"repository_id"`
+	Name         string `json:"name"`
+}
+
+// Ticket represents a ticket which differs between JIRA and the contents of the version
+type Ticket struct {
+	Key            string    `json:"key"`
+	Summary        string    `json:"summary"`
+	Status         string    `json:"status"`
+	StatusCategory string    `json:"status_category"`
+	FixVersions    []string  `json:"fix_versions"`
+	Branches       []*Branch `json:"branches,omitempty"`
+	Reason         string    `json:"reason"`
+}
+
+// Report represents the differences between the fix versions in JIRA and the contents of a version. The contents are
+// the tickets of the branches assigned to the version and, once released, the tickets contained in the release tag.
+type Report struct {
+	VersionID int    `json:"version_id"`
+	Version   string `json:"version"`
+	Released  bool   `json:"released"`
+
+	// Missing are tickets with the fix version in JIRA which are not contained in the version
+	Missing []*Ticket `json:"missing"`
+
+	// Unexpected are tickets contained in the version without having the fix version in JIRA
+	Unexpected []*Ticket `json:"unexpected"`
+
+	// Inconsistent are tickets having the fix version and being contained, but their status doesn't fit to the version
+	Inconsistent []*Ticket `json:"inconsistent"`
+}
@@ -0,0 +1,221 @@
+package fixversion
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	// DefaultBatchSize defines the number of tickets fetched from JIRA with one query
+	DefaultBatchSize = 50
+
+	statusCategoryNew  = "new"
+	statusCategoryDone = "done"
+
+	reasonMissing    = "ticket has the fix version in jira but is not contained in the version"
+	reasonUnexpected = "ticket is contained in the version but has not the fix version in jira"
+	reasonNotInJira  = "ticket is contained in the version but doesn't exist in jira"
+	reasonNotDone    = "version is released but ticket is not done"
+	reasonNotStarted = "ticket is contained in the version but was not started"
+)
+
+// ErrVersionNotFound occurs if the version doesn't exist
+var ErrVersionNotFound = errors.New("version was not found")
+
+// Reporter creates reconciliation reports comparing JIRA with the local contents of the versions
+type Reporter struct {
+	db        *sqlx.DB
+	client    jira.Client
+	batchSize int
+}
+
+// New returns a new reporter
+func New(db *sqlx.DB, client jira.Client) *Reporter {
+	return &Reporter{
+		db:        db,
+		client:    client,
+		batchSize: DefaultBatchSize,
+	}
+}
+
+// WithBatchSize sets the number of tickets fetched from JIRA with one query
+func (r *Reporter) WithBatchSize(size int) *Reporter {
+	if size > 0 {
+		r.batchSize = size
+	}
+
+	return r
+}
+
+// Report compares the tickets having the version as fix version in JIRA with the tickets contained in the version
+func (r *Reporter) Report(ctx context.Context, versionID int) (*Report, error) {
+	version := &versionstore.Version{ID: versionID}
+	if err := version.Read(ctx, r.db); errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrVersionNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	contents, err := r.contents(ctx, version.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := r.client.Search(ctx, fmt.Sprintf("fixVersion = %q ORDER BY key", version.Version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tickets of fix version: %w", err)
+	}
+
+	report := &Report{
+		VersionID:    version.ID,
+		Version:      version.Version,
+		Released:     version.IsReleased(),
+		Missing:      []*Ticket{},
+		Unexpected:   []*Ticket{},
+		Inconsistent: []*Ticket{},
+	}
+
+	withFixVersion := make(map[string]bool)
+
+	for _, issue := range issues {
+		withFixVersion[issue.Key] = true
+
+		branches, ok := contents[issue.Key]
+		if !ok {
+			report.Missing = append(report.Missing, newTicket(issue, nil, reasonMissing))
+			continue
+		}
+
+		if reason := inconsistency(issue, report.Released); reason != "" {
+			report.Inconsistent = append(report.Inconsistent, newTicket(issue, branches, reason))
+		}
+	}
+
+	if err := r.unexpected(ctx, report, contents, withFixVersion); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// contents returns the keys of the tickets contained in the version mapped to the branches bringing them in
+func (r *Reporter) contents(ctx context.Context, versionID int) (map[string][]*Branch, error) {
+	contents := make(map[string][]*Branch)
+
+	var branches branchstore.Branches
+	if err := branches.ReadByVersion(ctx, r.db, versionID); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	for _, b := range branches {
+		if b.TicketID == "" {
+			continue
+		}
+
+		contents[b.TicketID] = append(contents[b.TicketID], &Branch{
+			ID:           b.ID,
+			RepositoryID: b.RepositoryID,
+			Name:         b.Name,
+		})
+	}
+
+	var tickets versionstore.VersionTickets
+	if err := tickets.ReadByVersion(ctx, r.db, versionID); err != nil {
+		return nil, fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	for _, key := range tickets.TicketIDs() {
+		if _, ok := contents[key]; !ok {
+			contents[key] = nil
+		}
+	}
+
+	return contents, nil
+}
+
+// unexpected adds the contained tickets without the fix version to the report, their details are fetched in batches
+func (r *Reporter) unexpected(
+	ctx context.Context,
+	report *Report,
+	contents map[string][]*Branch,
+	withFixVersion map[string]bool,
+) error {
+	var keys []string
+
+	for key := range contents {
+		if !withFixVersion[key] {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	for start := 0; start < len(keys); start += r.batchSize {
+		end := start + r.batchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		issues, err := r.client.Issues(ctx, keys[start:end])
+		if err != nil {
+			return fmt.Errorf("failed to search contained tickets: %w", err)
+		}
+
+		found := make(map[string]*jira.Issue)
+		for _, issue := range issues {
+			found[issue.Key] = issue
+		}
+
+		for _, key := range keys[start:end] {
+			issue, ok := found[key]
+			reason := reasonUnexpected
+
+			if !ok {
+				issue = &jira.Issue{Key: key}
+				reason = reasonNotInJira
+			}
+
+			report.Unexpected = append(report.Unexpected, newTicket(issue, contents[key], reason))
+		}
+	}
+
+	return nil
+}
+
+// inconsistency returns the reason why the status of the ticket doesn't fit to the version, empty if it fits
+func inconsistency(issue *jira.Issue, released bool) string {
+	switch {
+	case released && issue.StatusCategory != statusCategoryDone:
+		return reasonNotDone
+	case !released && issue.StatusCategory == statusCategoryNew:
+		return reasonNotStarted
+	default:
+		return ""
+	}
+}
+
+func newTicket(issue *jira.Issue, branches []*Branch, reason string) *Ticket {
+	fixVersions := issue.FixVersions
+	if fixVersions == nil {
+		fixVersions = []string{}
+	}
+
+	return &Ticket{
+		Key:            issue.Key,
+		Summary:        issue.Summary,
+		Status:         issue.Status,
+		StatusCategory: issue.StatusCategory,
+		FixVersions:    fixVersions,
+		Branches:       branches,
+		Reason:         reason,
+	}
+}
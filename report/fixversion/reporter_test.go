@@ -0,0 +1,183 @@
+package fixversion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/report/fixversion"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	testCluster = "test_fixversion"
+)
+
+func setup(t *testing.T, name string) (*sqlx.DB, *jirafake.Server, *versionstore.Version) {
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "release/1.0.0"},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1"},
+		{Name: "feature/JIRA-2", TicketID: "JIRA-2"},
+		{Name: "feature/JIRA-3", TicketID: "JIRA-3"},
+		{Name: "feature/JIRA-4", TicketID: "JIRA-4"},
+	} {
+		b.RepositoryID = repo.ID
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+
+		bv := &versionstore.BranchVersion{BranchID: b.ID, VersionID: version.ID}
+		if err := bv.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	server := jirafake.NewServer()
+	for _, issue := range []*jira.Issue{
+		{Key: "JIRA-1", Status: "In Progress", StatusCategory: "indeterminate", FixVersions: []string{"1.0.0"}},
+		{Key: "JIRA-2", Status: "To Do", StatusCategory: "new", FixVersions: []string{"1.0.0"}},
+		{Key: "JIRA-3", Status: "Done", StatusCategory: "done", FixVersions: []string{"1.1.0"}},
+		{Key: "JIRA-5", Status: "Done", StatusCategory: "done", FixVersions: []string{"1.0.0"}},
+		{Key: "JIRA-6", Status: "Done", StatusCategory: "done", FixVersions: []string{"1.0.0"}},
+	} {
+		server.AddIssue(issue)
+	}
+
+	return db, server, version
+}
+
+func TestReporter_Report(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server, version := setup(t, "report")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	reporter := fixversion.New(db, jira.New(&config.Jira{BaseURL: &server.URL})).WithBatchSize(1)
+
+	// 2. unreleased version
+	report, err := reporter.Report(context.Background(), version.ID)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.VersionID != version.ID || report.Version != "1.0.0" || report.Released {
+		t.Errorf("unexpected report %#v", report)
+	}
+
+	testTickets(t, "missing", []string{"JIRA-5", "JIRA-6"}, report.Missing)
+	testTickets(t, "unexpected", []string{"JIRA-3", "JIRA-4"}, report.Unexpected)
+	testTickets(t, "inconsistent", []string{"JIRA-2"}, report.Inconsistent)
+
+	if len(report.Unexpected[0].Branches) != 1 || report.Unexpected[0].Branches[0].Name != "feature/JIRA-3" ||
+		report.Unexpected[0].FixVersions[0] != "1.1.0" {
+		t.Errorf("expected branch and fix versions of JIRA-3 to be reported but got %#v", report.Unexpected[0])
+	}
+
+	if report.Unexpected[1].Status != "" || report.Unexpected[1].Reason == report.Unexpected[0].Reason {
+		t.Errorf("expected JIRA-4 to be reported as not existing in jira but got %#v", report.Unexpected[1])
+	}
+
+	// 3. released version
+	releasedAt := time.Now()
+	version.ReleasedAt = &releasedAt
+
+	if err = version.Update(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	vt := &versionstore.VersionTicket{VersionID: version.ID, RepositoryID: 1, TicketID: "JIRA-6"}
+	if err = vt.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	report, err = reporter.Report(context.Background(), version.ID)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if !report.Released {
+		t.Error("expected version to be released")
+	}
+
+	testTickets(t, "missing", []string{"JIRA-5"}, report.Missing)
+	testTickets(t, "unexpected", []string{"JIRA-3", "JIRA-4"}, report.Unexpected)
+	testTickets(t, "inconsistent", []string{"JIRA-1", "JIRA-2"}, report.Inconsistent)
+}
+
+func TestReporter_Report_Errors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server, version := setup(t, "reportErrors")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	reporter := fixversion.New(db, jira.New(&config.Jira{BaseURL: &server.URL}))
+
+	// 2. test
+	_, err := reporter.Report(context.Background(), version.ID+1)
+	if !errors.Is(err, fixversion.ErrVersionNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", fixversion.ErrVersionNotFound, err)
+	}
+
+	server.SetCredentials("branma", "secret")
+
+	_, err = reporter.Report(context.Background(), version.ID)
+	if !errors.Is(err, jira.ErrAuthentication) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrAuthentication, err)
+	}
+}
+
+func testTickets(t *testing.T, kind string, expected []string, actual []*fixversion.Ticket) {
+	t.Helper()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %s tickets %v but got %d", kind, expected, len(actual))
+	}
+
+	for i, key := range expected {
+		if actual[i].Key != key || actual[i].Reason == "" {
+			t.Errorf("expected %s ticket %s with reason but got %#v", kind, key, actual[i])
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package missingbranch reports tickets expected to have a branch but having none and branches of unknown tickets
+package missingbranch
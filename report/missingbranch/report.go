@@ -0,0 +1,25 @@
+package missingbranch
+
+// Ticket represents a ticket of JIRA without any branch in the registered repositories
+type Ticket struct {
+	Key      string `json:"key"`
+	Summary  string `json:"summary"`
+	Status   string `json:"status"`
+	Type     string `json:"type"`
+	Assignee string `json:"assignee"`
+}
+
+// Branch represents an open branch whose ticket doesn't exist in JIRA
+type Branch struct {
+	ID           int    `json:"id"`
+	RepositoryID int    `json:"repository_id"`
+	Name         string `json:"name"`
+	TicketID     string `json:"ticket_id"`
+}
+
+// Report represents the tickets matching the query without branch and the branches of tickets not existing in JIRA
+type Report struct {
+	Query                 string    `json:"query"`
+	TicketsWithoutBranch  []*Ticket `json:"tickets_without_branch"`
+	BranchesUnknownTicket []*Branch `json:"branches_unknown_ticket"`
+}
@@ -0,0 +1,169 @@
+package missingbranch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+)
+
+// DefaultBatchSize defines the number of tickets checked in JIRA with one query
+const DefaultBatchSize = 50
+
+// ErrQueryMissing occurs if neither a query is given nor configured
+var ErrQueryMissing = errors.New("query of tickets expected to have a branch is missing")
+
+// Reporter creates the missing branch reports by comparing the tickets in JIRA with the branches
+type Reporter struct {
+	db         *sqlx.DB
+	client     jira.Client
+	query      string
+	batchSize  int
+	categories *config.Jira
+}
+
+// New returns a new reporter, the query is the default JQL of the tickets which are expected to have a branch
+func New(db *sqlx.DB, client jira.Client, query string) *Reporter {
+	return &Reporter{
+		db:        db,
+		client:    client,
+		query:     query,
+		batchSize: DefaultBatchSize,
+	}
+}
+
+// WithBatchSize sets the number of tickets checked in JIRA with one query
+func (r *Reporter) WithBatchSize(size int) *Reporter {
+	if size > 0 {
+		r.batchSize = size
+	}
+
+	return r
+}
+
+// WithStatusCategories sets the configuration mapping the statuses of JIRA to the categories of tickets
+func (r *Reporter) WithStatusCategories(cfg *config.Jira) *Reporter {
+	r.categories = cfg
+
+	return r
+}
+
+// Report returns the tickets matching the query which have no branch in any repository and the open branches whose
+// ticket doesn't exist in JIRA. Closed branches count as well, as the branch of a ticket in progress may already be
+// merged and deleted. If the query is empty, the configured one is used. If a category is given, only tickets of this
+// category are reported. Branches of unknown tickets have no category, so they are left out in that case.
+func (r *Reporter) Report(ctx context.Context, query, category string) (*Report, error) {
+	if query == "" {
+		query = r.query
+	}
+
+	if query == "" {
+		return nil, ErrQueryMissing
+	}
+
+	var branches branchstore.Branches
+	if err := branches.ReadWithTicket(ctx, r.db); err != nil {
+		return nil, fmt.Errorf("failed to load branches: %w", err)
+	}
+
+	report := &Report{
+		Query:                 query,
+		TicketsWithoutBranch:  []*Ticket{},
+		BranchesUnknownTicket: []*Branch{},
+	}
+
+	withBranch := make(map[string]bool)
+	byTicket := make(map[string]branchstore.Branches)
+
+	var ticketIDs []string
+
+	for _, b := range branches {
+		withBranch[b.TicketID] = true
+
+		if b.Closed {
+			continue
+		}
+
+		if _, ok := byTicket[b.TicketID]; !ok {
+			ticketIDs = append(ticketIDs, b.TicketID)
+		}
+
+		byTicket[b.TicketID] = append(byTicket[b.TicketID], b)
+	}
+
+	issues, err := r.client.Search(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tickets expected to have a branch: %w", err)
+	}
+
+	for _, issue := range issues {
+		if withBranch[issue.Key] || (category != "" && issue.Category(r.categories) != category) {
+			continue
+		}
+
+		report.TicketsWithoutBranch = append(report.TicketsWithoutBranch, &Ticket{
+			Key:      issue.Key,
+			Summary:  issue.Summary,
+			Status:   issue.Status,
+			Type:     issue.Type,
+			Assignee: issue.Assignee,
+		})
+	}
+
+	if category != "" {
+		return report, nil
+	}
+
+	for start := 0; start < len(ticketIDs); start += r.batchSize {
+		end := start + r.batchSize
+		if end > len(ticketIDs) {
+			end = len(ticketIDs)
+		}
+
+		if err := r.unknownTickets(ctx, ticketIDs[start:end], byTicket, report); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// unknownTickets adds the open branches of the tickets which don't exist in JIRA to the report
+func (r *Reporter) unknownTickets(
+	ctx context.Context,
+	ticketIDs []string,
+	byTicket map[string]branchstore.Branches,
+	report *Report,
+) error {
+	issues, err := r.client.Issues(ctx, ticketIDs)
+	if err != nil {
+		return fmt.Errorf("failed to search tickets of branches: %w", err)
+	}
+
+	found := make(map[string]bool)
+	for _, issue := range issues {
+		found[issue.Key] = true
+	}
+
+	for _, ticketID := range ticketIDs {
+		if found[ticketID] {
+			continue
+		}
+
+		for _, b := range byTicket[ticketID] {
+			report.BranchesUnknownTicket = append(report.BranchesUnknownTicket, &Branch{
+				ID:           b.ID,
+				RepositoryID: b.RepositoryID,
+				Name:         b.Name,
+				TicketID:     b.TicketID,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package missingbranch_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/report/missingbranch"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+)
+
+const (
+	testCluster = "test_missingbranch"
+)
+
+func TestReporter_Report(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "report")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, name := range []string{"repo1", "repo2"} {
+		repo := &repositorystore.Repository{Name: name, URL: name + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 1},
+		{Name: "feature/JIRA-3", TicketID: "JIRA-3", RepositoryID: 2, Closed: true},
+		{Name: "feature/JIRA-8", TicketID: "JIRA-8", RepositoryID: 1},
+		{Name: "feature/JIRA-8", TicketID: "JIRA-8", RepositoryID: 2},
+		{Name: "feature/JIRA-9", TicketID: "JIRA-9", RepositoryID: 2, Closed: true},
+	} {
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	server := jirafake.NewServer()
+	defer server.Close()
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", Status: "In Progress"})
+	server.AddIssue(&jira.Issue{
+		Key:            "JIRA-2",
+		Summary:        "second",
+		Status:         "In Progress",
+		StatusCategory: "indeterminate",
+		Assignee:       "Jane Doe",
+	})
+	server.AddIssue(&jira.Issue{Key: "JIRA-3", Status: "In Progress"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-4", Status: "Done"})
+	server.AddIssue(&jira.Issue{Key: "JIRA-5", Status: "Code Review", StatusCategory: "indeterminate"})
+
+	client := jira.New(&config.Jira{BaseURL: &server.URL})
+	reporter := missingbranch.New(db, client, `status = "In Progress"`).
+		WithBatchSize(1).
+		WithStatusCategories(&config.Jira{StatusCategories: map[string]string{"Code Review": config.CategoryReview}})
+
+	// 2. test
+	_, err := missingbranch.New(db, client, "").Report(context.Background(), "", "")
+	if !errors.Is(err, missingbranch.ErrQueryMissing) {
+		t.Errorf("expected error '%v' but got '%v'", missingbranch.ErrQueryMissing, err)
+	}
+
+	report, err := reporter.Report(context.Background(), "", "")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if report.Query != `status = "In Progress"` {
+		t.Errorf("expected configured query to be used but got '%s'", report.Query)
+	}
+
+	if len(report.TicketsWithoutBranch) != 1 || report.TicketsWithoutBranch[0].Key != "JIRA-2" ||
+		report.TicketsWithoutBranch[0].Assignee != "Jane Doe" {
+		t.Errorf("expected only JIRA-2 without branch but got %#v", report.TicketsWithoutBranch)
+	}
+
+	if len(report.BranchesUnknownTicket) != 2 || report.BranchesUnknownTicket[0].TicketID != "JIRA-8" ||
+		report.BranchesUnknownTicket[0].RepositoryID != 1 || report.BranchesUnknownTicket[1].RepositoryID != 2 {
+		t.Errorf("expected both open branches of JIRA-8 but got %#v", report.BranchesUnknownTicket)
+	}
+
+	report, err = reporter.Report(context.Background(), `status = Done`, "")
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(report.TicketsWithoutBranch) != 1 || report.TicketsWithoutBranch[0].Key != "JIRA-4" {
+		t.Errorf("expected given query to be used but got %#v", report.TicketsWithoutBranch)
+	}
+
+	for category, expected := range map[string]string{
+		config.CategoryReview:     "JIRA-5",
+		config.CategoryInProgress: "JIRA-2",
+	} {
+		report, err = reporter.Report(context.Background(), `status in ("In Progress", "Code Review")`, category)
+		if err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+
+		if len(report.TicketsWithoutBranch) != 1 || report.TicketsWithoutBranch[0].Key != expected {
+			t.Errorf("expected only %s of category %s but got %#v", expected, category, report.TicketsWithoutBranch)
+		}
+
+		if len(report.BranchesUnknownTicket) != 0 {
+			t.Errorf("expected no branches of unknown tickets in category %s but got %#v",
+				category, report.BranchesUnknownTicket)
+		}
+	}
+
+	_, err = reporter.Report(context.Background(), "unknown = field", "")
+	if !errors.Is(err, jira.ErrInvalidQuery) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrInvalidQuery, err)
+	}
+}
@@ -5,11 +5,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 
+	"github.com/rebel-l/branma_be/etag"
 	"github.com/rebel-l/branma_be/repository/repositorymodel"
 	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/validation"
 )
 
 var (
@@ -27,8 +31,39 @@ var (
 
 	// ErrNotFound occurs if record doesn't exist in database
 	ErrNotFound = errors.New("repository was not found")
+
+	// ErrPreconditionFailed occurs if the entity tag expected by the client doesn't match the one of the repository
+	ErrPreconditionFailed = errors.New("repository was modified or doesn't exist")
+
+	// ErrInvalid occurs if the model is invalid, the error returned is a *validation.Error listing the invalid fields
+	ErrInvalid = validation.ErrInvalid
+
+	// ErrDuplicate occurs if a repository with the same url and name already exists
+	ErrDuplicate = errors.New("repository with same url and name already exists")
+
+	// ErrHasBranches occurs if a repository to delete still has branches, the error returned tells how many
+	ErrHasBranches = repositorystore.ErrHasBranches
+
+	// ErrHasReleases occurs if a repository to delete still has releases of versions, the error returned tells how many
+	ErrHasReleases = repositorystore.ErrHasReleases
 )
 
+// DuplicateError occurs if a repository with the same url and name already exists, it provides the ID of the existing
+// repository
+type DuplicateError struct {
+	ID int
+}
+
+// Error returns the message of the error
+func (e *DuplicateError) Error() string {
+	return fmt.Sprintf("%v: id %d", ErrDuplicate, e.ID)
+}
+
+// Unwrap returns ErrDuplicate, so errors.Is works with the sentinel error
+func (e *DuplicateError) Unwrap() error {
+	return ErrDuplicate
+}
+
 // Mapper provides methods to load and persist repository models
 type Mapper struct {
 	db *sqlx.DB
@@ -53,22 +88,79 @@ func (m *Mapper) Load(ctx context.Context, id int) (*repositorymodel.Repository,
 	return storeToModel(s), nil
 }
 
-// Save persists (create or update) the model and returns the changed data (id, createdAt or modifiedAt)
+// List returns all repository models ordered by ID
+func (m *Mapper) List(ctx context.Context) (repositorymodel.Repositories, error) {
+	var repositories repositorystore.Repositories
+	if err := repositories.ReadAll(ctx, m.db); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadFromDB, err)
+	}
+
+	models := make(repositorymodel.Repositories, 0, len(repositories))
+	for _, r := range repositories {
+		models = append(models, storeToModel(r))
+	}
+
+	return models, nil
+}
+
+// Save persists (create or update) the model and returns the changed data (id, createdAt or modifiedAt). Invalid
+// models are not saved, a *validation.Error is returned instead.
 func (m *Mapper) Save(ctx context.Context, model *repositorymodel.Repository) (*repositorymodel.Repository, error) {
+	return m.SaveIfMatch(ctx, model, "")
+}
+
+// SaveIfMatch persists the model like Save, if the value of an If-Match header is given the repository is only updated
+// if its entity tag matches, otherwise ErrPreconditionFailed is returned. The entity tag is checked by the update
+// itself, so a change in between fails the precondition as well. Missing repositories never match, an empty value
+// always does.
+func (m *Mapper) SaveIfMatch(
+	ctx context.Context,
+	model *repositorymodel.Repository,
+	ifMatch string,
+) (*repositorymodel.Repository, error) {
 	if model == nil {
 		return nil, ErrNoData
 	}
 
+	modifiedAt, err := m.precondition(ctx, model.ID, ifMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.save(ctx, model, modifiedAt)
+}
+
+// save persists the model, an existing repository is only updated if it wasn't modified since the time given
+func (m *Mapper) save(
+	ctx context.Context,
+	model *repositorymodel.Repository,
+	modifiedAt *time.Time,
+) (*repositorymodel.Repository, error) {
+	if err := model.Validate(); err != nil {
+		return nil, err
+	}
+
 	s := modelToStore(model)
 
-	if model.ID != 0 {
-		if err := s.Update(ctx, m.db); err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrSaveToDB, err)
-		}
-	} else {
-		if err := s.Create(ctx, m.db); err != nil {
-			return nil, fmt.Errorf("%w: %v", ErrSaveToDB, err)
-		}
+	var err error
+
+	switch {
+	case model.ID == 0:
+		err = s.Create(ctx, m.db)
+	case modifiedAt != nil:
+		err = s.UpdateUnmodified(ctx, m.db, *modifiedAt)
+	default:
+		err = s.Update(ctx, m.db)
+	}
+
+	if errors.Is(err, repositorystore.ErrModified) {
+		return nil, ErrPreconditionFailed
+	} else if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if errors.Is(err, repositorystore.ErrDuplicate) {
+		return nil, m.duplicate(ctx, model)
+	} else if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSaveToDB, err)
 	}
 
 	model = storeToModel(s)
@@ -76,14 +168,153 @@ func (m *Mapper) Save(ctx context.Context, model *repositorymodel.Repository) (*
 	return model, nil
 }
 
-// Delete removes a model from database by ID
+// ETag returns the entity tag of the model derived from the time it was modified
+func ETag(model *repositorymodel.Repository) string {
+	if model == nil {
+		return ""
+	}
+
+	return etag.New(model.ModifiedAt)
+}
+
+// precondition returns the time the repository loaded by ID was modified at, if the value of an If-Match header
+// matches its entity tag. It is nil if no value is given.
+func (m *Mapper) precondition(ctx context.Context, id int, ifMatch string) (*time.Time, error) {
+	if ifMatch == "" {
+		return nil, nil
+	}
+
+	var current *repositorymodel.Repository
+
+	if id != 0 {
+		var err error
+
+		current, err = m.Load(ctx, id)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return match(ifMatch, current)
+}
+
+// match returns the time the repository was modified at, if the value of an If-Match header matches its entity tag.
+// It is nil if no value is given, missing repositories never match.
+func match(ifMatch string, current *repositorymodel.Repository) (*time.Time, error) {
+	if ifMatch == "" {
+		return nil, nil
+	}
+
+	if !etag.Match(ifMatch, ETag(current)) {
+		return nil, ErrPreconditionFailed
+	}
+
+	modifiedAt := current.ModifiedAt
+
+	return &modifiedAt, nil
+}
+
+// Patch applies the JSON merge patch (RFC 7396) to the repository loaded by ID, the result is validated on saving
+func (m *Mapper) Patch(ctx context.Context, id int, patch io.Reader) (*repositorymodel.Repository, error) {
+	return m.PatchIfMatch(ctx, id, patch, "")
+}
+
+// PatchIfMatch applies the JSON merge patch like Patch, if the value of an If-Match header is given the repository is
+// only updated if its entity tag matches, otherwise ErrPreconditionFailed is returned. The entity tag is checked by the
+// update itself, so a change in between fails the precondition as well.
+func (m *Mapper) PatchIfMatch(
+	ctx context.Context,
+	id int,
+	patch io.Reader,
+	ifMatch string,
+) (*repositorymodel.Repository, error) {
+	model, err := m.Load(ctx, id)
+	if errors.Is(err, ErrNotFound) && ifMatch != "" {
+		return nil, ErrPreconditionFailed
+	} else if err != nil {
+		return nil, err
+	}
+
+	modifiedAt, err := match(ifMatch, model)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = model.Patch(patch); err != nil {
+		return nil, err
+	}
+
+	return m.save(ctx, model, modifiedAt)
+}
+
+// Delete removes a model from database by ID, models having branches or releases are not deleted
 func (m *Mapper) Delete(ctx context.Context, id int) error {
+	return m.DeleteIfMatch(ctx, id, "")
+}
+
+// DeleteIfMatch removes a model from database by ID like Delete, if the value of an If-Match header is given the
+// repository is only removed if its entity tag matches, otherwise ErrPreconditionFailed is returned. The entity tag is
+// checked by the delete itself, so a change in between fails the precondition as well.
+func (m *Mapper) DeleteIfMatch(ctx context.Context, id int, ifMatch string) error {
+	modifiedAt, err := m.precondition(ctx, id, ifMatch)
+	if err != nil {
+		return err
+	}
+
 	s := &repositorystore.Repository{ID: id}
-	if err := s.Delete(ctx, m.db); err != nil {
+	if modifiedAt != nil {
+		return deleteError(s.DeleteUnmodified(ctx, m.db, *modifiedAt))
+	}
+
+	return deleteError(s.Delete(ctx, m.db))
+}
+
+// DeleteCascade removes a model from database by ID including its branches and releases
+func (m *Mapper) DeleteCascade(ctx context.Context, id int) error {
+	return m.DeleteCascadeIfMatch(ctx, id, "")
+}
+
+// DeleteCascadeIfMatch removes a model from database by ID including its branches like DeleteCascade, if the value of
+// an If-Match header is given the repository is only removed if its entity tag matches, otherwise ErrPreconditionFailed
+// is returned and nothing is removed
+func (m *Mapper) DeleteCascadeIfMatch(ctx context.Context, id int, ifMatch string) error {
+	modifiedAt, err := m.precondition(ctx, id, ifMatch)
+	if err != nil {
+		return err
+	}
+
+	s := &repositorystore.Repository{ID: id}
+	if modifiedAt != nil {
+		return deleteError(s.DeleteCascadeUnmodified(ctx, m.db, *modifiedAt))
+	}
+
+	return deleteError(s.DeleteCascade(ctx, m.db))
+}
+
+// deleteError maps the errors of the store on deleting to the errors of the mapper
+func deleteError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repositorystore.ErrModified):
+		return ErrPreconditionFailed
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrNotFound
+	case errors.Is(err, ErrHasBranches), errors.Is(err, ErrHasReleases):
+		return err
+	default:
 		return fmt.Errorf("%w: %v", ErrDeleteFromDB, err)
 	}
+}
+
+// duplicate returns the DuplicateError containing the ID of the repository having the same url and name as the model
+func (m *Mapper) duplicate(ctx context.Context, model *repositorymodel.Repository) error {
+	existing := &repositorystore.Repository{Name: model.Name, URL: model.URL}
+	if err := existing.ReadByURLAndName(ctx, m.db); err != nil {
+		return fmt.Errorf("%w: %v: %v", ErrSaveToDB, ErrDuplicate, err)
+	}
 
-	return nil
+	return &DuplicateError{ID: existing.ID}
 }
 
 func storeToModel(s *repositorystore.Repository) *repositorymodel.Repository {
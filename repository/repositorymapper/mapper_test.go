@@ -5,8 +5,11 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/rebel-l/branma_be/branch/branchstore"
 	"github.com/rebel-l/branma_be/config"
 
 	"github.com/rebel-l/branma_be/repository/repositorymapper"
@@ -73,8 +76,8 @@ func TestMapper_Load(t *testing.T) {
 	}{
 		{
 			name:     "success",
-			prepare:  &repositorymodel.Repository{Name: "niceName", URL: "niceURL"},
-			expected: &repositorymodel.Repository{ID: 1, Name: "niceName", URL: "niceURL"},
+			prepare:  &repositorymodel.Repository{Name: "niceName", URL: "https://host/nice.git"},
+			expected: &repositorymodel.Repository{ID: 1, Name: "niceName", URL: "https://host/nice.git"},
 		},
 		{
 			name:        "repository not existing",
@@ -105,6 +108,54 @@ func TestMapper_Load(t *testing.T) {
 	}
 }
 
+func TestMapper_List(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperList")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := repositorymapper.New(db)
+
+	// 2. test
+	actual, err := mapper.List(context.Background())
+	if err != nil || len(actual) != 0 {
+		t.Fatalf("expected no repositories but got %v and error '%v'", actual, err)
+	}
+
+	expected := repositorymodel.Repositories{
+		{ID: 1, Name: "first", URL: "https://host/first.git"},
+		{ID: 2, Name: "second", URL: "https://host/second.git"},
+	}
+
+	for _, repo := range expected {
+		model := &repositorymodel.Repository{Name: repo.Name, URL: repo.URL}
+		if _, err = mapper.Save(context.Background(), model); err != nil {
+			t.Fatalf("failed to prepare test data: %v", err)
+		}
+	}
+
+	actual, err = mapper.List(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got '%v'", err)
+	}
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %d repositories but got %d", len(expected), len(actual))
+	}
+
+	for i := range expected {
+		testRepository(t, expected[i], actual[i])
+	}
+}
+
 func TestMapper_Save(t *testing.T) {
 	if testing.Short() {
 		t.Skip("long running test")
@@ -132,25 +183,30 @@ func TestMapper_Save(t *testing.T) {
 			name:        "model is nil",
 			expectedErr: repositorymapper.ErrNoData,
 		},
+		{
+			name:        "model is invalid",
+			actual:      &repositorymodel.Repository{Name: "myname", URL: "myurl"},
+			expectedErr: repositorymapper.ErrInvalid,
+		},
 		{
 			name:     "model has no ID",
-			actual:   &repositorymodel.Repository{Name: "myname", URL: "myurl"},
-			expected: &repositorymodel.Repository{ID: 1, Name: "myname", URL: "myurl"},
+			actual:   &repositorymodel.Repository{Name: "myname", URL: "https://host/my.git"},
+			expected: &repositorymodel.Repository{ID: 1, Name: "myname", URL: "https://host/my.git"},
 		},
 		{
 			name:     "model has ID",
-			actual:   &repositorymodel.Repository{ID: 1, Name: "newname", URL: "newurl"},
-			expected: &repositorymodel.Repository{ID: 1, Name: "newname", URL: "newurl"},
+			actual:   &repositorymodel.Repository{ID: 1, Name: "newname", URL: "https://host/new.git"},
+			expected: &repositorymodel.Repository{ID: 1, Name: "newname", URL: "https://host/new.git"},
 		},
 		{
 			name:        "model is duplicate",
-			actual:      &repositorymodel.Repository{Name: "newname", URL: "newurl"},
-			expectedErr: repositorymapper.ErrSaveToDB,
+			actual:      &repositorymodel.Repository{Name: "newname", URL: "https://host/new.git"},
+			expectedErr: repositorymapper.ErrDuplicate,
 		},
 		{
 			name:        "update not existing model",
-			actual:      &repositorymodel.Repository{ID: 3, Name: "newname", URL: "newurl"},
-			expectedErr: repositorymapper.ErrSaveToDB,
+			actual:      &repositorymodel.Repository{ID: 3, Name: "newname", URL: "https://host/new.git"},
+			expectedErr: repositorymapper.ErrNotFound,
 		},
 	}
 
@@ -164,6 +220,231 @@ func TestMapper_Save(t *testing.T) {
 			testRepository(t, testCase.expected, res)
 		})
 	}
+
+	_, err := mapper.Save(context.Background(), &repositorymodel.Repository{Name: "newname", URL: "https://host/new.git"})
+
+	var duplicate *repositorymapper.DuplicateError
+	if !errors.As(err, &duplicate) || duplicate.ID != 1 {
+		t.Errorf("expected duplicate of repository 1 but got '%v'", err)
+	}
+}
+
+func TestMapper_Patch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperPatch")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := repositorymapper.New(db)
+
+	for _, model := range []*repositorymodel.Repository{
+		{Name: "repo", URL: "https://host/repo.git"},
+		{Name: "other", URL: "https://host/repo.git"},
+	} {
+		if _, err := mapper.Save(context.Background(), model); err != nil {
+			t.Fatalf("preparing test case failed: %v", err)
+		}
+	}
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		id          int
+		patch       string
+		expected    *repositorymodel.Repository
+		expectedErr error
+	}{
+		{
+			name:     "success",
+			id:       1,
+			patch:    `{"url": "git@host:changed.git"}`,
+			expected: &repositorymodel.Repository{ID: 1, Name: "repo", URL: "git@host:changed.git"},
+		},
+		{
+			name:        "not existing",
+			id:          3,
+			patch:       `{"url": "git@host:changed.git"}`,
+			expectedErr: repositorymapper.ErrNotFound,
+		},
+		{
+			name:        "no JSON format",
+			id:          1,
+			patch:       "no JSON",
+			expectedErr: repositorymodel.ErrDecodeJSON,
+		},
+		{
+			name:        "invalid",
+			id:          1,
+			patch:       `{"name": null}`,
+			expectedErr: repositorymapper.ErrInvalid,
+		},
+		{
+			name:        "duplicate",
+			id:          2,
+			patch:       `{"name": "repo", "url": "git@host:changed.git"}`,
+			expectedErr: repositorymapper.ErrDuplicate,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			res, err := mapper.Patch(context.Background(), testCase.id, strings.NewReader(testCase.patch))
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+			}
+
+			testRepository(t, testCase.expected, res)
+		})
+	}
+}
+
+func TestMapper_IfMatch(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperIfMatch")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := repositorymapper.New(db)
+
+	model, err := mapper.Save(context.Background(), &repositorymodel.Repository{
+		Name: "repo", URL: "https://host/repo.git",
+	})
+	if err != nil {
+		t.Fatalf("preparing test case failed: %v", err)
+	}
+
+	tag := repositorymapper.ETag(model)
+
+	// 2. test failing preconditions
+	testCases := []struct {
+		name string
+		do   func() error
+	}{
+		{
+			name: "save outdated",
+			do: func() error {
+				_, err := mapper.SaveIfMatch(context.Background(), &repositorymodel.Repository{
+					ID: model.ID, Name: "changed", URL: "https://host/changed.git",
+				}, `"outdated"`)
+
+				return err
+			},
+		},
+		{
+			name: "save not existing",
+			do: func() error {
+				_, err := mapper.SaveIfMatch(context.Background(), &repositorymodel.Repository{
+					ID: 2, Name: "changed", URL: "https://host/changed.git",
+				}, "*")
+
+				return err
+			},
+		},
+		{
+			name: "save new repository",
+			do: func() error {
+				_, err := mapper.SaveIfMatch(context.Background(), &repositorymodel.Repository{
+					Name: "new", URL: "https://host/new.git",
+				}, "*")
+
+				return err
+			},
+		},
+		{
+			name: "patch outdated",
+			do: func() error {
+				_, err := mapper.PatchIfMatch(context.Background(), model.ID, strings.NewReader(`{}`), `"outdated"`)
+
+				return err
+			},
+		},
+		{
+			name: "patch not existing",
+			do: func() error {
+				_, err := mapper.PatchIfMatch(context.Background(), 2, strings.NewReader(`{}`), "*")
+
+				return err
+			},
+		},
+		{
+			name: "delete outdated",
+			do: func() error {
+				return mapper.DeleteIfMatch(context.Background(), model.ID, `"outdated"`)
+			},
+		},
+		{
+			name: "delete cascade outdated",
+			do: func() error {
+				return mapper.DeleteCascadeIfMatch(context.Background(), model.ID, `"outdated"`)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.do(); !errors.Is(err, repositorymapper.ErrPreconditionFailed) {
+				t.Errorf("expected error '%v' but got '%v'", repositorymapper.ErrPreconditionFailed, err)
+			}
+		})
+	}
+
+	// 3. test matching preconditions, the entity tag changes with every modification
+	time.Sleep(2 * time.Millisecond)
+
+	model.Name = "changed"
+
+	changed, err := mapper.SaveIfMatch(context.Background(), model, tag)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if changed.Name != "changed" || repositorymapper.ETag(changed) == tag {
+		t.Errorf("expected repository to be changed with new entity tag but got %#v", changed)
+	}
+
+	_, err = mapper.PatchIfMatch(context.Background(), model.ID, strings.NewReader(`{"name": "patched"}`), tag)
+	if !errors.Is(err, repositorymapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", repositorymapper.ErrPreconditionFailed, err)
+	}
+
+	_, err = mapper.PatchIfMatch(context.Background(), model.ID, strings.NewReader(`{"name": "patched"}`), "*")
+	if err != nil {
+		t.Errorf("expected no error but got: %v", err)
+	}
+
+	err = mapper.DeleteIfMatch(context.Background(), model.ID, tag)
+	if !errors.Is(err, repositorymapper.ErrPreconditionFailed) {
+		t.Errorf("expected error '%v' but got '%v'", repositorymapper.ErrPreconditionFailed, err)
+	}
+
+	current, err := mapper.Load(context.Background(), model.ID)
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if err = mapper.DeleteIfMatch(context.Background(), model.ID, repositorymapper.ETag(current)); err != nil {
+		t.Errorf("expected no error but got: %v", err)
+	}
+
+	if repositorymapper.ETag(nil) != "" {
+		t.Error("expected no entity tag for nil repository")
+	}
 }
 
 func TestMapper_Delete(t *testing.T) {
@@ -190,7 +471,7 @@ func TestMapper_Delete(t *testing.T) {
 	}{
 		{
 			name:    "success",
-			prepare: &repositorymodel.Repository{Name: "delete", URL: "deleteURL"},
+			prepare: &repositorymodel.Repository{Name: "delete", URL: "https://host/delete.git"},
 		},
 		{
 			name:        "repository not existing",
@@ -225,6 +506,57 @@ func TestMapper_Delete(t *testing.T) {
 			}
 		})
 	}
+
+	if err := mapper.Delete(context.Background(), 99); !errors.Is(err, repositorymapper.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", repositorymapper.ErrNotFound, err)
+	}
+
+}
+
+func TestMapper_DeleteCascade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperDeleteCascade")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := repositorymapper.New(db)
+
+	model, err := mapper.Save(context.Background(), &repositorymodel.Repository{
+		Name: "delete", URL: "https://host/delete.git",
+	})
+	if err != nil {
+		t.Fatalf("preparing test case failed: %v", err)
+	}
+
+	b := &branchstore.Branch{Name: "feature/JIRA-1", RepositoryID: model.ID}
+	if err = b.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing test case failed: %v", err)
+	}
+
+	// 2. test
+	if err = mapper.Delete(context.Background(), model.ID); !errors.Is(err, repositorymapper.ErrHasBranches) {
+		t.Errorf("expected error '%v' but got '%v'", repositorymapper.ErrHasBranches, err)
+	}
+
+	if err = mapper.DeleteCascade(context.Background(), model.ID); err != nil {
+		t.Errorf("expected no error but got: %v", err)
+	}
+
+	if _, err = mapper.Load(context.Background(), model.ID); !errors.Is(err, repositorymapper.ErrNotFound) {
+		t.Errorf("expected that repository was deleted but got error '%v'", err)
+	}
+
+	if err = mapper.DeleteCascade(context.Background(), model.ID); !errors.Is(err, repositorymapper.ErrNotFound) {
+		t.Errorf("expected error '%v' but got '%v'", repositorymapper.ErrNotFound, err)
+	}
 }
 
 func testRepository(t *testing.T, expected, actual *repositorymodel.Repository) {
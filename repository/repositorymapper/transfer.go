@@ -0,0 +1,65 @@
+package repositorymapper
+
+import (
+	"context"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+)
+
+// key identifies a repository independent of its ID
+type key struct {
+	url  string
+	name string
+}
+
+// Import creates the repositories of the rows which don't exist yet. Repositories are matched by url and name, the IDs
+// of the rows are ignored, so lists exported by other instances can be imported. In a dry run nothing is saved. The
+// rows are saved one by one without a transaction: rows which are invalid or can't be saved fail without affecting the
+// others, so the repositories of the rows saved before a failure are kept and the result reports each row.
+func (m *Mapper) Import(ctx context.Context, rows []*bulk.Row, dryRun bool) (*bulk.Result, error) {
+	existing, err := m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[key]int, len(existing))
+	for _, r := range existing {
+		ids[key{url: r.URL, name: r.Name}] = r.ID
+	}
+
+	result := bulk.NewResult(dryRun)
+
+	for _, row := range rows {
+		model := &repositorymodel.Repository{}
+		if err = row.Decode(model); err != nil {
+			result.Fail(row, err)
+			continue
+		}
+
+		model.ID = 0
+
+		if err = model.Validate(); err != nil {
+			result.Fail(row, err)
+			continue
+		}
+
+		k := key{url: model.URL, name: model.Name}
+		if id, ok := ids[k]; ok {
+			result.Add(&bulk.RowResult{Row: row.Number, Action: bulk.ActionUnchanged, ID: id})
+			continue
+		}
+
+		if !dryRun {
+			if model, err = m.Save(ctx, model); err != nil {
+				result.Fail(row, err)
+				continue
+			}
+		}
+
+		ids[k] = model.ID
+		result.Add(&bulk.RowResult{Row: row.Number, Action: bulk.ActionCreated, ID: model.ID})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,148 @@
+package repositorymapper_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/repository/repositorymapper"
+	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestMapper_Import(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "mapperImport")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	mapper := repositorymapper.New(db)
+
+	existing := &repositorymodel.Repository{Name: "first", URL: "https://host/first.git"}
+	if _, err := mapper.Save(context.Background(), existing); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	// saving the repository named broken fails like the database would do unexpectedly
+	if _, err := db.Exec(`CREATE TRIGGER broken BEFORE INSERT ON repositories WHEN NEW.name = 'broken'
+		BEGIN SELECT RAISE(ABORT, 'broken'); END`); err != nil {
+		t.Fatalf("failed to prepare test data: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name          string
+		format        bulk.Format
+		input         string
+		dryRun        bool
+		expected      *bulk.Result
+		expectedCount int
+	}{
+		{
+			name:   "dry run",
+			format: bulk.FormatCSV,
+			input: "name,url\n" +
+				"first,https://host/first.git\n" +
+				"second,https://host/second.git\n" +
+				"second,https://host/second.git\n" +
+				",invalid\n",
+			dryRun: true,
+			expected: &bulk.Result{
+				DryRun:    true,
+				Created:   1,
+				Unchanged: 2,
+				Failed:    1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionUnchanged, ID: 1},
+					{Row: 2, Action: bulk.ActionCreated},
+					{Row: 3, Action: bulk.ActionUnchanged},
+					{
+						Row:    4,
+						Action: bulk.ActionFailed,
+						Errors: []*validation.FieldError{
+							{Field: "name", Code: validation.CodeRequired},
+							{Field: "url", Code: validation.CodeInvalidFormat},
+						},
+					},
+				},
+			},
+			expectedCount: 1,
+		},
+		{
+			name:   "import",
+			format: bulk.FormatJSON,
+			input: `[
+				{"id": 7, "name": "second", "url": "https://host/second.git"},
+				{"name": "third", "url": "git@host:third.git"},
+				{"name": "first", "url": "https://host/first.git"},
+				{"name": 1}
+			]`,
+			expected: &bulk.Result{
+				Created:   2,
+				Unchanged: 1,
+				Failed:    1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionCreated, ID: 2},
+					{Row: 2, Action: bulk.ActionCreated, ID: 3},
+					{Row: 3, Action: bulk.ActionUnchanged, ID: 1},
+					{Row: 4, Action: bulk.ActionFailed},
+				},
+			},
+			expectedCount: 3,
+		},
+		{
+			name:   "database failure",
+			format: bulk.FormatJSON,
+			input: `[
+				{"name": "fourth", "url": "https://host/fourth.git"},
+				{"name": "broken", "url": "https://host/broken.git"},
+				{"name": "fifth", "url": "https://host/fifth.git"}
+			]`,
+			expected: &bulk.Result{
+				Created: 2,
+				Failed:  1,
+				Rows: []*bulk.RowResult{
+					{Row: 1, Action: bulk.ActionCreated, ID: 4},
+					{Row: 2, Action: bulk.ActionFailed},
+					{Row: 3, Action: bulk.ActionCreated, ID: 5},
+				},
+			},
+			expectedCount: 5,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rows, err := bulk.Decode(testCase.format, strings.NewReader(testCase.input))
+			if err != nil {
+				t.Fatalf("failed to decode rows: %v", err)
+			}
+
+			actual, err := mapper.Import(context.Background(), rows, testCase.dryRun)
+			if err != nil {
+				t.Fatalf("expected no error but got '%v'", err)
+			}
+
+			test.CheckResult(t, testCase.expected, actual)
+
+			repositories, err := mapper.List(context.Background())
+			if err != nil {
+				t.Fatalf("failed to list repositories: %v", err)
+			}
+
+			if testCase.expectedCount != len(repositories) {
+				t.Errorf("expected %d repositories but got %d", testCase.expectedCount, len(repositories))
+			}
+		})
+	}
+}
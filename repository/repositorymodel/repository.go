@@ -5,7 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/rebel-l/branma_be/bulk"
+	"github.com/rebel-l/branma_be/mergepatch"
+	"github.com/rebel-l/branma_be/validation"
+)
+
+const (
+	// MaxLengthName is the maximum length of the name, it matches the size of the column in the database
+	MaxLengthName = 100
+
+	// MaxLengthURL is the maximum length of the url, it matches the size of the column in the database
+	MaxLengthURL = 100
 )
 
 var (
@@ -13,13 +28,18 @@ var (
 	ErrDecodeJSON = errors.New("failed to decode JSON")
 )
 
+// Columns returns the columns of a repository in CSV
+func Columns() []string {
+	return []string{"id", "name", "url", "created_at", "modified_at"}
+}
+
 // Repository represents a model of repository including business logic
 type Repository struct {
-	ID         int       `json:"id"`
-	Name       string    `json:"name"`
-	URL        string    `json:"url"`
-	CreatedAt  time.Time `json:"created_at"`
-	ModifiedAt time.Time `json:"modified_at"`
+	ID         int       `json:"id" yaml:"id"`
+	Name       string    `json:"name" yaml:"name"`
+	URL        string    `json:"url" yaml:"url"`
+	CreatedAt  time.Time `json:"created_at" yaml:"created_at"`
+	ModifiedAt time.Time `json:"modified_at" yaml:"modified_at"`
 }
 
 // DecodeJSON converts JSON data to struct
@@ -35,3 +55,90 @@ func (r *Repository) DecodeJSON(reader io.Reader) error {
 
 	return nil
 }
+
+// Patch applies the JSON merge patch (RFC 7396) to the repository, fields set to null are reset. The ID, created at
+// and modified at are kept.
+func (r *Repository) Patch(reader io.Reader) error {
+	if r == nil {
+		return nil
+	}
+
+	patch, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	doc, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	doc, err = mergepatch.Apply(doc, patch)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	patched := &Repository{}
+	if err = json.Unmarshal(doc, patched); err != nil {
+		return fmt.Errorf("%w: %v", ErrDecodeJSON, err)
+	}
+
+	patched.ID = r.ID
+	patched.CreatedAt = r.CreatedAt
+	patched.ModifiedAt = r.ModifiedAt
+	*r = *patched
+
+	return nil
+}
+
+// Validate returns a *validation.Error listing all invalid fields, if the repository is valid nil is returned
+func (r *Repository) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	e := &validation.Error{}
+	e.Required("name", r.Name)
+	e.MaxLength("name", r.Name, MaxLengthName)
+	e.Required("url", r.URL)
+	e.MaxLength("url", r.URL, MaxLengthURL)
+	e.GitURL("url", r.URL)
+
+	return e.Err()
+}
+
+// MarshalRecord returns the repository as row of CSV
+func (r *Repository) MarshalRecord() bulk.Record {
+	if r == nil {
+		return bulk.Record{}
+	}
+
+	return bulk.Record{
+		"id":          strconv.Itoa(r.ID),
+		"name":        r.Name,
+		"url":         r.URL,
+		"created_at":  bulk.FormatTime(r.CreatedAt),
+		"modified_at": bulk.FormatTime(r.ModifiedAt),
+	}
+}
+
+// UnmarshalRecord sets the repository from a row of CSV, the created at and modified at are ignored as they are
+// maintained by the database
+func (r *Repository) UnmarshalRecord(record bulk.Record) error {
+	if r == nil {
+		return nil
+	}
+
+	id, err := record.Int("id")
+	if err != nil {
+		return err
+	}
+
+	*r = Repository{
+		ID:   id,
+		Name: strings.TrimSpace(record["name"]),
+		URL:  strings.TrimSpace(record["url"]),
+	}
+
+	return nil
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/rebel-l/branma_be/bulk"
 	"github.com/rebel-l/branma_be/repository/repositorymodel"
+	"github.com/rebel-l/branma_be/validation"
 )
 
 func TestRepository_DecodeJSON(t *testing.T) {
@@ -70,6 +73,70 @@ func TestRepository_DecodeJSON(t *testing.T) {
 	}
 }
 
+func TestRepository_Patch(t *testing.T) { // nolint:funlen
+	createdAt, _ := time.Parse(time.RFC3339Nano, "2019-12-31T03:36:57.9167778+01:00")
+	modifiedAt, _ := time.Parse(time.RFC3339Nano, "2020-01-01T15:44:57.9168378+01:00")
+	original := repositorymodel.Repository{ID: 1, Name: "test", URL: "url", CreatedAt: createdAt, ModifiedAt: modifiedAt}
+
+	testCases := []struct {
+		name        string
+		patch       string
+		expected    *repositorymodel.Repository
+		expectedErr error
+	}{
+		{
+			name:        "no JSON format",
+			patch:       "no JSON",
+			expected:    &original,
+			expectedErr: repositorymodel.ErrDecodeJSON,
+		},
+		{
+			name:        "wrong type",
+			patch:       `{"name": 5}`,
+			expected:    &original,
+			expectedErr: repositorymodel.ErrDecodeJSON,
+		},
+		{
+			name:  "change name",
+			patch: `{"name": "changed"}`,
+			expected: &repositorymodel.Repository{
+				ID: 1, Name: "changed", URL: "url", CreatedAt: createdAt, ModifiedAt: modifiedAt,
+			},
+		},
+		{
+			name:  "reset url",
+			patch: `{"url": null}`,
+			expected: &repositorymodel.Repository{
+				ID: 1, Name: "test", CreatedAt: createdAt, ModifiedAt: modifiedAt,
+			},
+		},
+		{
+			name:     "read only fields",
+			patch:    `{"id": 2, "created_at": null, "modified_at": "2020-02-01T00:00:00Z"}`,
+			expected: &original,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := original
+
+			err := actual.Patch(bytes.NewReader([]byte(testCase.patch)))
+			if !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+				return
+			}
+
+			testRepository(t, testCase.expected, &actual)
+		})
+	}
+
+	var nilRepo *repositorymodel.Repository
+	if err := nilRepo.Patch(bytes.NewReader([]byte("{}"))); err != nil {
+		t.Errorf("expected no error for nil repository but got: %v", err)
+	}
+}
+
 func testRepository(t *testing.T, expected, actual *repositorymodel.Repository) {
 	t.Helper()
 
@@ -102,3 +169,129 @@ func testRepository(t *testing.T, expected, actual *repositorymodel.Repository)
 		t.Errorf("expected modified at '%s' but got '%s'", expected.ModifiedAt.String(), actual.ModifiedAt.String())
 	}
 }
+
+func TestRepository_Validate(t *testing.T) {
+	testCases := []struct {
+		name           string
+		actual         *repositorymodel.Repository
+		expectedFields []string
+	}{
+		{
+			name: "model is nil",
+		},
+		{
+			name:   "valid",
+			actual: &repositorymodel.Repository{Name: "branma", URL: "git@github.com:rebel-l/branma_be.git"},
+		},
+		{
+			name:           "empty",
+			actual:         &repositorymodel.Repository{},
+			expectedFields: []string{"name", "url"},
+		},
+		{
+			name: "too long",
+			actual: &repositorymodel.Repository{
+				Name: strings.Repeat("a", repositorymodel.MaxLengthName+1),
+				URL:  "https://github.com/" + strings.Repeat("a", repositorymodel.MaxLengthURL),
+			},
+			expectedFields: []string{"name", "url"},
+		},
+		{
+			name:           "invalid url",
+			actual:         &repositorymodel.Repository{Name: "branma", URL: "branma"},
+			expectedFields: []string{"url"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Validate()
+			if len(testCase.expectedFields) == 0 {
+				if err != nil {
+					t.Errorf("expected no error but got: %v", err)
+				}
+
+				return
+			}
+
+			var invalid *validation.Error
+			if !errors.As(err, &invalid) {
+				t.Fatalf("expected validation error but got: %v", err)
+			}
+
+			if len(testCase.expectedFields) != len(invalid.Errors) {
+				t.Fatalf("expected fields %v to be invalid but got: %v", testCase.expectedFields, err)
+			}
+
+			for i, field := range testCase.expectedFields {
+				if invalid.Errors[i].Field != field {
+					t.Errorf("expected field '%s' to be invalid but got '%s'", field, invalid.Errors[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestRepository_MarshalRecord(t *testing.T) {
+	modifiedAt := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	actual := (&repositorymodel.Repository{ID: 1, Name: "branma", URL: "/srv/git/branma", ModifiedAt: modifiedAt}).
+		MarshalRecord()
+
+	expected := bulk.Record{
+		"id":          "1",
+		"name":        "branma",
+		"url":         "/srv/git/branma",
+		"created_at":  "",
+		"modified_at": "2020-03-01T12:00:00Z",
+	}
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected record %v but got %v", expected, actual)
+	}
+
+	for _, column := range repositorymodel.Columns() {
+		if expected[column] != actual[column] {
+			t.Errorf("expected column %s to be '%s' but got '%s'", column, expected[column], actual[column])
+		}
+	}
+}
+
+func TestRepository_UnmarshalRecord(t *testing.T) {
+	testCases := []struct {
+		name     string
+		record   bulk.Record
+		expected *repositorymodel.Repository
+		err      error
+	}{
+		{
+			name:     "success",
+			record:   bulk.Record{"id": "1", "name": " branma ", "url": "/srv/git/branma", "created_at": "yesterday"},
+			expected: &repositorymodel.Repository{ID: 1, Name: "branma", URL: "/srv/git/branma"},
+		},
+		{
+			name:     "without id",
+			record:   bulk.Record{"name": "branma"},
+			expected: &repositorymodel.Repository{Name: "branma"},
+		},
+		{
+			name:   "id not integer",
+			record: bulk.Record{"id": "one"},
+			err:    bulk.ErrDecode,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := &repositorymodel.Repository{ID: 5, URL: "old"}
+
+			err := actual.UnmarshalRecord(testCase.record)
+			if !errors.Is(err, testCase.err) {
+				t.Fatalf("expected error '%v' but got '%v'", testCase.err, err)
+			}
+
+			if err == nil && *testCase.expected != *actual {
+				t.Errorf("expected %#v but got %#v", testCase.expected, actual)
+			}
+		})
+	}
+}
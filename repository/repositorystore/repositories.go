@@ -0,0 +1,21 @@
+package repositorystore
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Repositories represents a collection of Repository
+type Repositories []*Repository
+
+// ReadAll loads all repositories from the database
+func (r *Repositories) ReadAll(ctx context.Context, db *sqlx.DB) error {
+	if r == nil {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM repositories ORDER BY id`)
+
+	return db.SelectContext(ctx, r, q)
+}
@@ -0,0 +1,51 @@
+package repositorystore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+)
+
+func TestRepositories_ReadAll(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "storeReadAll")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	var repos repositorystore.Repositories
+	if err := repos.ReadAll(context.Background(), db); err != nil {
+		t.Fatalf("expected no error on empty database but got: %v", err)
+	}
+
+	if len(repos) != 0 {
+		t.Errorf("expected no repositories but got %d", len(repos))
+	}
+
+	for _, name := range []string{"repo1", "repo2"} {
+		repo := &repositorystore.Repository{Name: name, URL: name + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	if err := repos.ReadAll(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(repos) != 2 {
+		t.Fatalf("expected 2 repositories but got %d", len(repos))
+	}
+
+	testRepository(t, &repositorystore.Repository{ID: 1, Name: "repo1", URL: "repo1.url"}, repos[0])
+	testRepository(t, &repositorystore.Repository{ID: 2, Name: "repo2", URL: "repo2.url"}, repos[1])
+}
@@ -2,10 +2,13 @@ package repositorystore
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
 )
 
 var (
@@ -17,6 +20,18 @@ var (
 
 	// ErrDataMissing will be thrown if mandatory data is not set
 	ErrDataMissing = errors.New("no data or mandatory data missing")
+
+	// ErrDuplicate will be thrown if a repository with the same url and name already exists
+	ErrDuplicate = errors.New("repository with same url and name already exists")
+
+	// ErrHasBranches will be thrown if a repository to delete still has branches
+	ErrHasBranches = errors.New("repository still has branches")
+
+	// ErrHasReleases will be thrown if a repository to delete still has releases of versions or tickets assigned to them
+	ErrHasReleases = errors.New("repository still has releases")
+
+	// ErrModified will be thrown if a repository to change was modified meanwhile or doesn't exist anymore
+	ErrModified = errors.New("repository was modified meanwhile or doesn't exist")
 )
 
 // Repository represents the repository in the database
@@ -42,7 +57,7 @@ func (r *Repository) Create(ctx context.Context, db *sqlx.DB) error {
 
 	res, err := db.ExecContext(ctx, q, r.Name, r.URL)
 	if err != nil {
-		return err
+		return duplicate(err)
 	}
 
 	id, err := res.LastInsertId()
@@ -66,8 +81,29 @@ func (r *Repository) Read(ctx context.Context, db *sqlx.DB) error {
 	return db.GetContext(ctx, r, q, r.ID)
 }
 
+// ReadByURLAndName sets the repository from database by given url and name
+func (r *Repository) ReadByURLAndName(ctx context.Context, db *sqlx.DB) error {
+	if !r.IsValid() {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM repositories WHERE url = ? AND name = ?`)
+
+	return db.GetContext(ctx, r, q, r.URL, r.Name)
+}
+
 // Update changes the current object on the database by ID
 func (r *Repository) Update(ctx context.Context, db *sqlx.DB) error {
+	return r.update(ctx, db, nil)
+}
+
+// UpdateUnmodified changes the current object on the database by ID, if it wasn't modified since the given time.
+// Otherwise ErrModified is returned.
+func (r *Repository) UpdateUnmodified(ctx context.Context, db *sqlx.DB, modifiedAt time.Time) error {
+	return r.update(ctx, db, &modifiedAt)
+}
+
+func (r *Repository) update(ctx context.Context, db *sqlx.DB, modifiedAt *time.Time) error {
 	if !r.IsValid() {
 		return ErrDataMissing
 	}
@@ -76,26 +112,163 @@ func (r *Repository) Update(ctx context.Context, db *sqlx.DB) error {
 		return ErrIDMissing
 	}
 
-	q := db.Rebind(`UPDATE repositories SET name = ?, url = ? WHERE id = ?`)
+	condition, args := unmodified(modifiedAt)
+	q := db.Rebind(`UPDATE repositories SET name = ?, url = ? WHERE id = ?` + condition)
 
-	if _, err := db.ExecContext(ctx, q, r.Name, r.URL, r.ID); err != nil {
-		return err
+	res, err := db.ExecContext(ctx, q, append([]interface{}{r.Name, r.URL, r.ID}, args...)...)
+	if err != nil {
+		return duplicate(err)
+	}
+
+	if modifiedAt != nil {
+		if err = modified(res); err != nil {
+			return err
+		}
 	}
 
 	return r.Read(ctx, db)
 }
 
-// Delete removes the current object from database by its ID
+// Delete removes the current object from database by its ID, repositories having branches or releases are not deleted
 func (r *Repository) Delete(ctx context.Context, db *sqlx.DB) error {
+	return r.delete(ctx, db, nil)
+}
+
+// DeleteUnmodified removes the current object from database by its ID like Delete, if it wasn't modified since the
+// given time. Otherwise ErrModified is returned.
+func (r *Repository) DeleteUnmodified(ctx context.Context, db *sqlx.DB, modifiedAt time.Time) error {
+	return r.delete(ctx, db, &modifiedAt)
+}
+
+func (r *Repository) delete(ctx context.Context, db *sqlx.DB, modifiedAt *time.Time) error {
 	if r == nil || r.ID == 0 {
 		return ErrIDMissing
 	}
 
-	q := db.Rebind(`DELETE FROM repositories WHERE id = ?`)
+	var branches int
 
-	_, err := db.ExecContext(ctx, q, r.ID)
+	q := db.Rebind(`SELECT COUNT(*) FROM branches WHERE repository_id = ?`)
+	if err := db.GetContext(ctx, &branches, q, r.ID); err != nil {
+		return err
+	}
 
-	return err
+	if branches > 0 {
+		return fmt.Errorf("%w (%d)", ErrHasBranches, branches)
+	}
+
+	var releases struct {
+		Releases int `db:"releases"`
+		Tickets  int `db:"tickets"`
+	}
+
+	q = db.Rebind(`SELECT
+		(SELECT COUNT(*) FROM version_releases WHERE repository_id = ?) AS releases,
+		(SELECT COUNT(*) FROM version_tickets WHERE repository_id = ?) AS tickets`)
+	if err := db.GetContext(ctx, &releases, q, r.ID, r.ID); err != nil {
+		return err
+	}
+
+	if releases.Releases > 0 || releases.Tickets > 0 {
+		return fmt.Errorf("%w (releases: %d, tickets: %d)", ErrHasReleases, releases.Releases, releases.Tickets)
+	}
+
+	return r.deleteWithSync(ctx, db, modifiedAt)
+}
+
+// deleteWithSync removes the current object together with its synchronisation state in one transaction
+func (r *Repository) deleteWithSync(ctx context.Context, db *sqlx.DB, modifiedAt *time.Time) (err error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(`DELETE FROM repository_syncs WHERE repository_id = ?`), r.ID); err != nil {
+		return err
+	}
+
+	condition, args := unmodified(modifiedAt)
+	q := tx.Rebind(`DELETE FROM repositories WHERE id = ?` + condition)
+
+	res, err := tx.ExecContext(ctx, q, append([]interface{}{r.ID}, args...)...)
+	if modifiedAt != nil && err == nil {
+		err = modified(res)
+	} else {
+		err = deleted(res, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// DeleteCascade removes the current object with its branches, their assignments to versions, their commits and the
+// releases of versions in the repository including their tickets from database in one transaction. Versions are kept,
+// but they lose the link to their release branch.
+func (r *Repository) DeleteCascade(ctx context.Context, db *sqlx.DB) error {
+	return r.deleteCascade(ctx, db, nil)
+}
+
+// DeleteCascadeUnmodified removes the current object with its branches like DeleteCascade, if it wasn't modified since
+// the given time. Otherwise nothing is removed and ErrModified is returned.
+func (r *Repository) DeleteCascadeUnmodified(ctx context.Context, db *sqlx.DB, modifiedAt time.Time) error {
+	return r.deleteCascade(ctx, db, &modifiedAt)
+}
+
+func (r *Repository) deleteCascade(ctx context.Context, db *sqlx.DB, modifiedAt *time.Time) (err error) {
+	if r == nil || r.ID == 0 {
+		return ErrIDMissing
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	branches := `SELECT id FROM branches WHERE repository_id = ?`
+
+	for _, q := range []string{
+		`UPDATE versions SET branch_id = NULL WHERE branch_id IN (` + branches + `)`,
+		`DELETE FROM branch_commits WHERE branch_id IN (` + branches + `)`,
+		`DELETE FROM branch_versions WHERE branch_id IN (` + branches + `)`,
+		`DELETE FROM branches WHERE repository_id = ?`,
+		`DELETE FROM version_tickets WHERE repository_id = ?`,
+		`DELETE FROM version_releases WHERE repository_id = ?`,
+		`DELETE FROM repository_syncs WHERE repository_id = ?`,
+	} {
+		if _, err = tx.ExecContext(ctx, tx.Rebind(q), r.ID); err != nil {
+			return err
+		}
+	}
+
+	condition, args := unmodified(modifiedAt)
+	q := tx.Rebind(`DELETE FROM repositories WHERE id = ?` + condition)
+
+	res, err := tx.ExecContext(ctx, q, append([]interface{}{r.ID}, args...)...)
+	if modifiedAt != nil && err == nil {
+		err = modified(res)
+	} else {
+		err = deleted(res, err)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // IsValid returns true if all mandatory fields are set
@@ -106,3 +279,56 @@ func (r *Repository) IsValid() bool {
 
 	return true
 }
+
+// unmodified returns the condition and its arguments matching only rows not modified since the given time, both are
+// empty if no time is given. The times are compared in the format SQLite stores them, so with milliseconds.
+func unmodified(modifiedAt *time.Time) (string, []interface{}) {
+	if modifiedAt == nil {
+		return "", nil
+	}
+
+	return ` AND strftime('%Y-%m-%d %H:%M:%f', modified_at) = ?`,
+		[]interface{}{modifiedAt.UTC().Format("2006-01-02 15:04:05.000")}
+}
+
+// modified returns ErrModified if the statement restricted to unmodified rows didn't affect any row
+func modified(res sql.Result) error {
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return ErrModified
+	}
+
+	return nil
+}
+
+// deleted returns sql.ErrNoRows if the delete statement didn't remove any row
+func deleted(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// duplicate returns ErrDuplicate if the error is caused by the unique index on url and name
+func duplicate(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return fmt.Errorf("%w: %v", ErrDuplicate, err)
+	}
+
+	return err
+}
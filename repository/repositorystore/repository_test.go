@@ -13,8 +13,10 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 
 	"github.com/rebel-l/branma_be/bootstrap"
+	"github.com/rebel-l/branma_be/branch/branchstore"
 	"github.com/rebel-l/branma_be/config"
 	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/version/versionstore"
 	"github.com/rebel-l/go-utils/osutils"
 )
 
@@ -93,7 +95,7 @@ func TestRepository_Create(t *testing.T) { // nolint:funlen
 		{
 			name:        "duplicate",
 			actual:      &repositorystore.Repository{Name: "myname", URL: "myurl"},
-			expectedErr: errors.New("UNIQUE constraint failed: repositories.url, repositories.name"),
+			expectedErr: repositorystore.ErrDuplicate,
 		},
 	}
 
@@ -190,6 +192,44 @@ func testRepository(t *testing.T, expected, actual *repositorystore.Repository)
 	}
 }
 
+func TestRepository_ReadByURLAndName(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "storeReadByURLAndName")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, r := range []*repositorystore.Repository{
+		{Name: "project", URL: "myproject.git"},
+		{Name: "other", URL: "other.git"},
+	} {
+		if err := r.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var nilRepo *repositorystore.Repository
+	checkErrors(t, repositorystore.ErrDataMissing, nilRepo.ReadByURLAndName(context.Background(), db))
+
+	actual := &repositorystore.Repository{Name: "project", URL: "myproject.git"}
+	checkErrors(t, nil, actual.ReadByURLAndName(context.Background(), db))
+	testRepository(t, &repositorystore.Repository{ID: 1, Name: "project", URL: "myproject.git"}, actual)
+
+	actual = &repositorystore.Repository{Name: "project", URL: "other.git"}
+	checkErrors(t, sql.ErrNoRows, actual.ReadByURLAndName(context.Background(), db))
+
+	actual = &repositorystore.Repository{ID: 2, Name: "project", URL: "myproject.git"}
+	checkErrors(t, repositorystore.ErrDuplicate, actual.Update(context.Background(), db))
+}
+
 func TestRepository_Update(t *testing.T) { // nolint:funlen
 	if testing.Short() {
 		t.Skip("long running test")
@@ -311,6 +351,11 @@ func TestRepository_Delete(t *testing.T) {
 			prepare: &repositorystore.Repository{Name: "init name", URL: "init url"},
 			actual:  &repositorystore.Repository{ID: 1},
 		},
+		{
+			name:        "repository not existing",
+			actual:      &repositorystore.Repository{ID: 3},
+			expectedErr: sql.ErrNoRows,
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -339,6 +384,138 @@ func TestRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestRepository_Delete_Released(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "storeDeleteReleased")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "url"}
+	if err := repo.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	release := &versionstore.Release{VersionID: version.ID, RepositoryID: repo.ID, ReleasedAt: time.Now()}
+	if err := release.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	checkErrors(t, repositorystore.ErrHasReleases, repo.Delete(ctx, db))
+	checkErrors(t, repositorystore.ErrHasReleases, repo.DeleteUnmodified(ctx, db, repo.ModifiedAt))
+	checkErrors(t, nil, repo.DeleteCascade(ctx, db))
+	checkErrors(t, sql.ErrNoRows, release.Read(ctx, db))
+}
+
+func TestRepository_DeleteCascade(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "storeDeleteCascade")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	for _, r := range []*repositorystore.Repository{{Name: "repo", URL: "url"}, {Name: "other", URL: "other url"}} {
+		if err := r.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "release/1.0.0", RepositoryID: 1},
+		{Name: "feature/JIRA-1", RepositoryID: 1},
+		{Name: "feature/JIRA-1", RepositoryID: 2},
+	} {
+		if err := b.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	releaseBranchID := 1
+	version := &versionstore.Version{Version: "1.0.0", BranchID: &releaseBranchID}
+
+	if err := version.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if err := (&versionstore.BranchVersion{BranchID: 2, VersionID: version.ID}).Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, repositoryID := range []int{1, 2} {
+		release := &versionstore.Release{VersionID: version.ID, RepositoryID: repositoryID, ReleasedAt: time.Now()}
+		if err := release.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+
+		ticket := &versionstore.VersionTicket{VersionID: version.ID, RepositoryID: repositoryID, TicketID: "JIRA-1"}
+		if err := ticket.Create(ctx, db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, q := range []string{
+		`INSERT INTO commits (commit_hash) VALUES ('abc')`,
+		`INSERT INTO branch_commits (branch_id, commit_id) VALUES (2, 1)`,
+	} {
+		if _, err := db.ExecContext(ctx, q); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var nilRepo *repositorystore.Repository
+	checkErrors(t, repositorystore.ErrIDMissing, nilRepo.DeleteCascade(ctx, db))
+
+	repo := &repositorystore.Repository{ID: 1}
+	checkErrors(t, repositorystore.ErrHasBranches, repo.Delete(ctx, db))
+	checkErrors(t, nil, repo.DeleteCascade(ctx, db))
+	checkErrors(t, sql.ErrNoRows, repo.Read(ctx, db))
+	checkErrors(t, sql.ErrNoRows, repo.DeleteCascade(ctx, db))
+
+	for table, expected := range map[string]int{
+		"branches":         1,
+		"branch_versions":  0,
+		"branch_commits":   0,
+		"commits":          1,
+		"versions":         1,
+		"version_releases": 1,
+		"version_tickets":  1,
+	} {
+		var actual int
+		if err := db.GetContext(ctx, &actual, `SELECT COUNT(*) FROM `+table); err != nil || actual != expected {
+			t.Errorf("expected %d rows in %s but got %d: %v", expected, table, actual, err)
+		}
+	}
+
+	if err := version.Read(ctx, db); err != nil || version.BranchID != nil {
+		t.Errorf("expected version to lose its release branch but got %v: %v", version.BranchID, err)
+	}
+}
+
 func TestRepository_IsValid(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -381,6 +558,68 @@ func TestRepository_IsValid(t *testing.T) {
 	}
 }
 
+func TestRepository_Unmodified(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "storeUnmodified")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, name := range []string{"first", "second"} {
+		r := &repositorystore.Repository{Name: name, URL: "url " + name}
+		if err := r.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparation failed: %v", err)
+		}
+	}
+
+	current := &repositorystore.Repository{ID: 1}
+	if err := current.Read(context.Background(), db); err != nil {
+		t.Fatalf("preparation failed: %v", err)
+	}
+
+	stale := current.ModifiedAt.Add(-time.Second)
+
+	// 2. test
+	update := &repositorystore.Repository{ID: 1, Name: "changed", URL: "changed url"}
+	checkErrors(t, repositorystore.ErrModified, update.UpdateUnmodified(context.Background(), db, stale))
+
+	actual := &repositorystore.Repository{ID: 1}
+	if err := actual.Read(context.Background(), db); err != nil {
+		t.Fatalf("failed to read repository: %v", err)
+	}
+
+	if actual.Name != "first" {
+		t.Errorf("expected modified repository not to be changed but got %#v", actual)
+	}
+
+	checkErrors(t, nil, update.UpdateUnmodified(context.Background(), db, current.ModifiedAt))
+
+	if update.Name != "changed" {
+		t.Errorf("expected unmodified repository to be changed but got %#v", update)
+	}
+
+	checkErrors(t, repositorystore.ErrModified, update.DeleteUnmodified(context.Background(), db, stale))
+	checkErrors(t, nil, update.DeleteUnmodified(context.Background(), db, update.ModifiedAt))
+
+	cascade := &repositorystore.Repository{ID: 2}
+	if err := cascade.Read(context.Background(), db); err != nil {
+		t.Fatalf("preparation failed: %v", err)
+	}
+
+	checkErrors(t, repositorystore.ErrModified, cascade.DeleteCascadeUnmodified(context.Background(), db, stale))
+	checkErrors(t, nil, cascade.DeleteCascadeUnmodified(context.Background(), db, cascade.ModifiedAt))
+
+	err := cascade.DeleteCascadeUnmodified(context.Background(), db, cascade.ModifiedAt)
+	checkErrors(t, repositorystore.ErrModified, err)
+}
+
 func checkErrors(t *testing.T, expected, actual error) {
 	t.Helper()
 
@@ -0,0 +1,75 @@
+package repositorystore
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Sync represents the first completed synchronisation of a repository in the database
+type Sync struct {
+	ID           int       `db:"id"`
+	RepositoryID int       `db:"repository_id"`
+	SyncedAt     time.Time `db:"synced_at"`
+	CreatedAt    time.Time `db:"created_at"`
+	ModifiedAt   time.Time `db:"modified_at"`
+}
+
+// Create creates current sync in the database
+func (s *Sync) Create(ctx context.Context, db *sqlx.DB) error {
+	if !s.IsValid() {
+		return ErrDataMissing
+	}
+
+	if s.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`INSERT INTO repository_syncs (repository_id, synced_at) VALUES (?, ?)`)
+
+	res, err := db.ExecContext(ctx, q, s.RepositoryID, s.SyncedAt)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	s.ID = int(id)
+
+	return s.Read(ctx, db)
+}
+
+// Read sets the sync from database by given ID
+func (s *Sync) Read(ctx context.Context, db *sqlx.DB) error {
+	if s == nil || s.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM repository_syncs WHERE id = ?`)
+
+	return db.GetContext(ctx, s, q, s.ID)
+}
+
+// ReadByRepository sets the sync from database by the given repository ID
+func (s *Sync) ReadByRepository(ctx context.Context, db *sqlx.DB) error {
+	if s == nil || s.RepositoryID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM repository_syncs WHERE repository_id = ?`)
+
+	return db.GetContext(ctx, s, q, s.RepositoryID)
+}
+
+// IsValid returns true if all mandatory fields are set
+func (s *Sync) IsValid() bool {
+	if s == nil || s.RepositoryID == 0 || s.SyncedAt.IsZero() {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package repositorystore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+)
+
+func TestSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := setup(t, "storeSync")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ctx := context.Background()
+
+	repo := &repositorystore.Repository{Name: "repo", URL: "repo.url"}
+	if err := repo.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	s := &repositorystore.Sync{RepositoryID: repo.ID}
+	if err := s.ReadByRepository(ctx, db); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected error '%v' for repository not synced yet but got '%v'", sql.ErrNoRows, err)
+	}
+
+	if err := s.Create(ctx, db); !errors.Is(err, repositorystore.ErrDataMissing) {
+		t.Errorf("expected error '%v' without time but got '%v'", repositorystore.ErrDataMissing, err)
+	}
+
+	s.SyncedAt = time.Now()
+	if err := s.Create(ctx, db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if err := s.Create(ctx, db); !errors.Is(err, repositorystore.ErrIDIsSet) {
+		t.Errorf("expected error '%v' but got '%v'", repositorystore.ErrIDIsSet, err)
+	}
+
+	if err := (&repositorystore.Sync{RepositoryID: repo.ID, SyncedAt: time.Now()}).Create(ctx, db); err == nil {
+		t.Error("expected error on second sync of the same repository")
+	}
+
+	actual := &repositorystore.Sync{RepositoryID: repo.ID}
+	if err := actual.ReadByRepository(ctx, db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if actual.ID != s.ID || !actual.SyncedAt.Equal(s.SyncedAt) {
+		t.Errorf("expected sync %#v but got %#v", s, actual)
+	}
+
+	if err := (&repositorystore.Sync{}).ReadByRepository(ctx, db); !errors.Is(err, repositorystore.ErrIDMissing) {
+		t.Errorf("expected error '%v' but got '%v'", repositorystore.ErrIDMissing, err)
+	}
+
+	// 3. synced repositories can be deleted
+	other := &repositorystore.Repository{Name: "other", URL: "other.url"}
+	if err := other.Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if err := (&repositorystore.Sync{RepositoryID: other.ID, SyncedAt: time.Now()}).Create(ctx, db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if err := repo.Delete(ctx, db); err != nil {
+		t.Errorf("expected no error on delete but got: %v", err)
+	}
+
+	if err := other.DeleteCascade(ctx, db); err != nil {
+		t.Errorf("expected no error on cascading delete but got: %v", err)
+	}
+
+	var count int
+	if err := db.GetContext(ctx, &count, `SELECT COUNT(*) FROM repository_syncs`); err != nil || count != 0 {
+		t.Errorf("expected syncs to be deleted with their repositories but got %d: %v", count, err)
+	}
+}
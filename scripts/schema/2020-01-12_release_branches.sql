@@ -0,0 +1,22 @@
+-- up
+ALTER TABLE versions ADD COLUMN branch_id INTEGER NULL REFERENCES branches(id);
+
+
+-- down
+CREATE TABLE IF NOT EXISTS versions_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    version VARCHAR(50) NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO versions_backup (id, version, created_at, modified_at)
+    SELECT id, version, created_at, modified_at FROM versions;
+
+DROP TRIGGER IF EXISTS versions_after_update;
+DROP TABLE IF EXISTS versions;
+ALTER TABLE versions_backup RENAME TO versions;
+
+CREATE TRIGGER IF NOT EXISTS versions_after_update AFTER UPDATE ON versions BEGIN
+    UPDATE versions SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
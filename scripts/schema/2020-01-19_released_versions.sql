@@ -0,0 +1,43 @@
+-- up
+ALTER TABLE versions ADD COLUMN released_at DATETIME NULL;
+ALTER TABLE versions ADD COLUMN release_commit VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS version_tickets (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    version_id INTEGER NOT NULL,
+    ticket_id VARCHAR(50) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (version_id) REFERENCES versions(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS version_tickets_idx ON version_tickets(version_id, ticket_id);
+
+CREATE TRIGGER IF NOT EXISTS version_tickets_after_update AFTER UPDATE ON version_tickets BEGIN
+    UPDATE version_tickets SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+
+-- down
+DROP TRIGGER IF EXISTS version_tickets_after_update;
+DROP INDEX IF EXISTS version_tickets_idx;
+DROP TABLE IF EXISTS version_tickets;
+
+CREATE TABLE IF NOT EXISTS versions_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    version VARCHAR(50) NOT NULL UNIQUE,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    branch_id INTEGER NULL REFERENCES branches(id)
+);
+
+INSERT INTO versions_backup (id, version, created_at, modified_at, branch_id)
+    SELECT id, version, created_at, modified_at, branch_id FROM versions;
+
+DROP TRIGGER IF EXISTS versions_after_update;
+DROP TABLE IF EXISTS versions;
+ALTER TABLE versions_backup RENAME TO versions;
+
+CREATE TRIGGER IF NOT EXISTS versions_after_update AFTER UPDATE ON versions BEGIN
+    UPDATE versions SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
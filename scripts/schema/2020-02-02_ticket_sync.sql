@@ -0,0 +1,40 @@
+-- up
+ALTER TABLE branches ADD COLUMN ticket_synced_at DATETIME NULL;
+ALTER TABLE branches ADD COLUMN ticket_sync_error VARCHAR(250) NOT NULL DEFAULT '';
+
+
+-- down
+CREATE TABLE IF NOT EXISTS branches_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_id VARCHAR(50) NOT NULL DEFAULT '',
+    parent_ticket_id VARCHAR(50) NOT NULL DEFAULT '',
+    repository_id INTEGER NOT NULL,
+    ticket_summary VARCHAR(250) NOT NULL,
+    ticket_status VARCHAR(100) NOT NULL,
+    ticket_type VARCHAR(50) NOT NULL,
+    branch_name VARCHAR(250) NOT NULL,
+    closed INTEGER(1) NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    base_branch VARCHAR(250) NOT NULL DEFAULT '',
+    FOREIGN KEY (repository_id) REFERENCES repositories(id)
+);
+
+INSERT INTO branches_backup (
+        id, ticket_id, parent_ticket_id, repository_id, ticket_summary, ticket_status, ticket_type, branch_name,
+        closed, created_at, modified_at, base_branch
+    )
+    SELECT id, ticket_id, parent_ticket_id, repository_id, ticket_summary, ticket_status, ticket_type, branch_name,
+        closed, created_at, modified_at, base_branch
+    FROM branches;
+
+DROP TRIGGER IF EXISTS branches_after_update;
+DROP INDEX IF EXISTS branches_idx;
+DROP TABLE IF EXISTS branches;
+ALTER TABLE branches_backup RENAME TO branches;
+
+CREATE UNIQUE INDEX IF NOT EXISTS branches_idx ON branches(branch_name, repository_id);
+
+CREATE TRIGGER IF NOT EXISTS branches_after_update AFTER UPDATE ON branches BEGIN
+    UPDATE branches SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
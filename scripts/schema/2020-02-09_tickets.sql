@@ -0,0 +1,97 @@
+-- up
+CREATE TABLE IF NOT EXISTS tickets (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_key VARCHAR(50) NOT NULL UNIQUE,
+    summary VARCHAR(250) NOT NULL DEFAULT '',
+    status VARCHAR(100) NOT NULL DEFAULT '',
+    ticket_type VARCHAR(50) NOT NULL DEFAULT '',
+    parent_key VARCHAR(50) NOT NULL DEFAULT '',
+    assignee VARCHAR(250) NOT NULL DEFAULT '',
+    priority VARCHAR(50) NOT NULL DEFAULT '',
+    fetched_at DATETIME NULL,
+    fetch_error VARCHAR(250) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS tickets_after_update AFTER UPDATE ON tickets BEGIN
+    UPDATE tickets SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+INSERT INTO tickets (ticket_key, summary, status, ticket_type, parent_key, fetched_at, fetch_error)
+    SELECT ticket_id, ticket_summary, ticket_status, ticket_type, parent_ticket_id, ticket_synced_at, ticket_sync_error
+    FROM (
+        SELECT *, MAX(modified_at) FROM branches WHERE ticket_id != '' GROUP BY ticket_id
+    );
+
+CREATE TABLE IF NOT EXISTS branches_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_id VARCHAR(50) NOT NULL DEFAULT '',
+    repository_id INTEGER NOT NULL,
+    branch_name VARCHAR(250) NOT NULL,
+    closed INTEGER(1) NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    base_branch VARCHAR(250) NOT NULL DEFAULT '',
+    FOREIGN KEY (repository_id) REFERENCES repositories(id)
+);
+
+INSERT INTO branches_backup (id, ticket_id, repository_id, branch_name, closed, created_at, modified_at, base_branch)
+    SELECT id, ticket_id, repository_id, branch_name, closed, created_at, modified_at, base_branch FROM branches;
+
+DROP TRIGGER IF EXISTS branches_after_update;
+DROP INDEX IF EXISTS branches_idx;
+DROP TABLE IF EXISTS branches;
+ALTER TABLE branches_backup RENAME TO branches;
+
+CREATE UNIQUE INDEX IF NOT EXISTS branches_idx ON branches(branch_name, repository_id);
+CREATE INDEX IF NOT EXISTS branches_ticket_idx ON branches(ticket_id);
+
+CREATE TRIGGER IF NOT EXISTS branches_after_update AFTER UPDATE ON branches BEGIN
+    UPDATE branches SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+
+-- down
+CREATE TABLE IF NOT EXISTS branches_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_id VARCHAR(50) NOT NULL DEFAULT '',
+    parent_ticket_id VARCHAR(50) NOT NULL DEFAULT '',
+    repository_id INTEGER NOT NULL,
+    ticket_summary VARCHAR(250) NOT NULL,
+    ticket_status VARCHAR(100) NOT NULL,
+    ticket_type VARCHAR(50) NOT NULL,
+    branch_name VARCHAR(250) NOT NULL,
+    closed INTEGER(1) NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    base_branch VARCHAR(250) NOT NULL DEFAULT '',
+    ticket_synced_at DATETIME NULL,
+    ticket_sync_error VARCHAR(250) NOT NULL DEFAULT '',
+    FOREIGN KEY (repository_id) REFERENCES repositories(id)
+);
+
+INSERT INTO branches_backup (
+        id, ticket_id, parent_ticket_id, repository_id, ticket_summary, ticket_status, ticket_type, branch_name,
+        closed, created_at, modified_at, base_branch, ticket_synced_at, ticket_sync_error
+    )
+    SELECT b.id, b.ticket_id, IFNULL(t.parent_key, ''), b.repository_id, IFNULL(t.summary, ''),
+        IFNULL(t.status, ''), IFNULL(t.ticket_type, ''), b.branch_name, b.closed, b.created_at, b.modified_at,
+        b.base_branch, t.fetched_at, IFNULL(t.fetch_error, '')
+    FROM branches b
+    LEFT JOIN tickets t ON t.ticket_key = b.ticket_id;
+
+DROP TRIGGER IF EXISTS branches_after_update;
+DROP INDEX IF EXISTS branches_ticket_idx;
+DROP INDEX IF EXISTS branches_idx;
+DROP TABLE IF EXISTS branches;
+ALTER TABLE branches_backup RENAME TO branches;
+
+CREATE UNIQUE INDEX IF NOT EXISTS branches_idx ON branches(branch_name, repository_id);
+
+CREATE TRIGGER IF NOT EXISTS branches_after_update AFTER UPDATE ON branches BEGIN
+    UPDATE branches SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+DROP TRIGGER IF EXISTS tickets_after_update;
+DROP TABLE IF EXISTS tickets;
@@ -0,0 +1,38 @@
+-- up
+ALTER TABLE tickets ADD COLUMN status_category VARCHAR(20) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tickets_category_idx ON tickets(status_category);
+
+
+-- down
+CREATE TABLE IF NOT EXISTS tickets_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_key VARCHAR(50) NOT NULL UNIQUE,
+    summary VARCHAR(250) NOT NULL DEFAULT '',
+    status VARCHAR(100) NOT NULL DEFAULT '',
+    ticket_type VARCHAR(50) NOT NULL DEFAULT '',
+    parent_key VARCHAR(50) NOT NULL DEFAULT '',
+    assignee VARCHAR(250) NOT NULL DEFAULT '',
+    priority VARCHAR(50) NOT NULL DEFAULT '',
+    fetched_at DATETIME NULL,
+    fetch_error VARCHAR(250) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO tickets_backup (
+        id, ticket_key, summary, status, ticket_type, parent_key, assignee, priority, fetched_at, fetch_error,
+        created_at, modified_at
+    )
+    SELECT id, ticket_key, summary, status, ticket_type, parent_key, assignee, priority, fetched_at, fetch_error,
+        created_at, modified_at
+    FROM tickets;
+
+DROP TRIGGER IF EXISTS tickets_after_update;
+DROP INDEX IF EXISTS tickets_category_idx;
+DROP TABLE IF EXISTS tickets;
+ALTER TABLE tickets_backup RENAME TO tickets;
+
+CREATE TRIGGER IF NOT EXISTS tickets_after_update AFTER UPDATE ON tickets BEGIN
+    UPDATE tickets SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
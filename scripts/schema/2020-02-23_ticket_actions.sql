@@ -0,0 +1,17 @@
+-- up
+CREATE TABLE IF NOT EXISTS ticket_actions (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    ticket_key VARCHAR(50) NOT NULL,
+    version_id INTEGER NOT NULL,
+    event VARCHAR(20) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (version_id) REFERENCES versions(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ticket_actions_idx ON ticket_actions(ticket_key, version_id, event, action);
+
+
+-- down
+DROP INDEX IF EXISTS ticket_actions_idx;
+DROP TABLE IF EXISTS ticket_actions;
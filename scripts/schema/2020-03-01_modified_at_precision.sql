@@ -0,0 +1,22 @@
+-- up
+DROP TRIGGER IF EXISTS repositories_after_update;
+CREATE TRIGGER IF NOT EXISTS repositories_after_update AFTER UPDATE ON repositories BEGIN
+    UPDATE repositories SET modified_at = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+end;
+
+DROP TRIGGER IF EXISTS branches_after_update;
+CREATE TRIGGER IF NOT EXISTS branches_after_update AFTER UPDATE ON branches BEGIN
+    UPDATE branches SET modified_at = strftime('%Y-%m-%d %H:%M:%f', 'now') WHERE id = NEW.id;
+end;
+
+
+-- down
+DROP TRIGGER IF EXISTS branches_after_update;
+CREATE TRIGGER IF NOT EXISTS branches_after_update AFTER UPDATE ON branches BEGIN
+    UPDATE branches SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+DROP TRIGGER IF EXISTS repositories_after_update;
+CREATE TRIGGER IF NOT EXISTS repositories_after_update AFTER UPDATE ON repositories BEGIN
+    UPDATE repositories SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
@@ -0,0 +1,65 @@
+-- up
+CREATE TABLE IF NOT EXISTS version_releases (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    version_id INTEGER NOT NULL,
+    repository_id INTEGER NOT NULL,
+    released_at DATETIME NOT NULL,
+    release_commit VARCHAR(50) NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (version_id) REFERENCES versions(id),
+    FOREIGN KEY (repository_id) REFERENCES repositories(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS version_releases_idx ON version_releases(version_id, repository_id);
+
+CREATE TRIGGER IF NOT EXISTS version_releases_after_update AFTER UPDATE ON version_releases BEGIN
+    UPDATE version_releases SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+INSERT INTO version_releases (version_id, repository_id, released_at, release_commit)
+    SELECT v.id, b.repository_id, v.released_at, v.release_commit
+    FROM versions v
+    JOIN branches b ON b.id = v.branch_id
+    WHERE v.released_at IS NOT NULL;
+
+ALTER TABLE version_tickets ADD COLUMN repository_id INTEGER NOT NULL DEFAULT 0;
+
+UPDATE version_tickets SET repository_id = IFNULL(
+    (SELECT b.repository_id FROM versions v JOIN branches b ON b.id = v.branch_id WHERE v.id = version_tickets.version_id),
+    0
+);
+
+DROP INDEX IF EXISTS version_tickets_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS version_tickets_idx ON version_tickets(version_id, repository_id, ticket_id);
+
+
+-- down
+CREATE TABLE IF NOT EXISTS version_tickets_backup (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    version_id INTEGER NOT NULL,
+    ticket_id VARCHAR(50) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (version_id) REFERENCES versions(id)
+);
+
+INSERT INTO version_tickets_backup (id, version_id, ticket_id, created_at, modified_at)
+    SELECT MIN(id), version_id, ticket_id, MIN(created_at), MAX(modified_at)
+    FROM version_tickets
+    GROUP BY version_id, ticket_id;
+
+DROP TRIGGER IF EXISTS version_tickets_after_update;
+DROP INDEX IF EXISTS version_tickets_idx;
+DROP TABLE IF EXISTS version_tickets;
+ALTER TABLE version_tickets_backup RENAME TO version_tickets;
+
+CREATE UNIQUE INDEX IF NOT EXISTS version_tickets_idx ON version_tickets(version_id, ticket_id);
+
+CREATE TRIGGER IF NOT EXISTS version_tickets_after_update AFTER UPDATE ON version_tickets BEGIN
+    UPDATE version_tickets SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+DROP TRIGGER IF EXISTS version_releases_after_update;
+DROP INDEX IF EXISTS version_releases_idx;
+DROP TABLE IF EXISTS version_releases;
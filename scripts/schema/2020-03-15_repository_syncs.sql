@@ -0,0 +1,27 @@
+-- up
+CREATE TABLE IF NOT EXISTS repository_syncs (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    repository_id INTEGER NOT NULL,
+    synced_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    modified_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (repository_id) REFERENCES repositories(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS repository_syncs_idx ON repository_syncs(repository_id);
+
+CREATE TRIGGER IF NOT EXISTS repository_syncs_after_update AFTER UPDATE ON repository_syncs BEGIN
+    UPDATE repository_syncs SET modified_at = datetime('now') WHERE id = NEW.id;
+end;
+
+INSERT INTO repository_syncs (repository_id, synced_at)
+    SELECT r.id, MIN(b.created_at)
+    FROM repositories r
+    JOIN branches b ON b.repository_id = r.id
+    GROUP BY r.id;
+
+
+-- down
+DROP TRIGGER IF EXISTS repository_syncs_after_update;
+DROP INDEX IF EXISTS repository_syncs_idx;
+DROP TABLE IF EXISTS repository_syncs;
@@ -0,0 +1,142 @@
+package test
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// GitRemote represents a git repository used as remote in tests
+type GitRemote struct {
+	t    *testing.T
+	Path string
+}
+
+// NewGitRemote creates a git repository with an initial commit on branch master
+func NewGitRemote(t *testing.T, cluster, name string) *GitRemote {
+	t.Helper()
+
+	path, err := filepath.Abs(filepath.Join(".", "..", "..", "storage", cluster, name, "remote"))
+	if err != nil {
+		t.Fatalf("failed to build path of git remote: %v", err)
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		t.Fatalf("failed to cleanup git remote: %v", err)
+	}
+
+	if err := os.MkdirAll(path, 0750); err != nil {
+		t.Fatalf("failed to create git remote: %v", err)
+	}
+
+	g := &GitRemote{t: t, Path: path}
+	g.Git("init", "--quiet")
+	g.Git("symbolic-ref", "HEAD", "refs/heads/master")
+	g.commit("README.md", "initial commit")
+
+	return g
+}
+
+// Git executes the git command with given arguments on the remote and returns the trimmed output
+func (g *GitRemote) Git(args ...string) string {
+	g.t.Helper()
+
+	args = append([]string{"-c", "user.name=branma", "-c", "user.email=branma@example.com"}, args...)
+	cmd := exec.Command("git", args...) // nolint: gosec
+	cmd.Dir = g.Path
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		g.t.Fatalf("git %s failed: %v: %s", strings.Join(args, " "), err, out)
+	}
+
+	return strings.TrimSpace(string(out))
+}
+
+// Branch creates a new branch from the given reference
+func (g *GitRemote) Branch(name, from string) {
+	g.t.Helper()
+	g.Git("branch", name, from)
+}
+
+// Commit adds a commit changing the given file to the branch and returns the commit hash
+func (g *GitRemote) Commit(branch, file, message string) string {
+	g.t.Helper()
+
+	g.Git("checkout", "--quiet", branch)
+
+	return g.commit(file, message)
+}
+
+// Write replaces the content of a file on the given branch and commits it, the hash of the commit is returned
+func (g *GitRemote) Write(branch, file, content, message string) string {
+	g.t.Helper()
+
+	g.Git("checkout", "--quiet", branch)
+
+	fileName := filepath.Join(g.Path, file)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0750); err != nil {
+		g.t.Fatalf("failed to create directory for file %s: %v", file, err)
+	}
+
+	if err := ioutil.WriteFile(fileName, []byte(content), 0600); err != nil {
+		g.t.Fatalf("failed to write file %s: %v", file, err)
+	}
+
+	g.Git("add", file)
+	g.Git("commit", "--quiet", "-m", message)
+
+	return g.Git("rev-parse", "HEAD")
+}
+
+func (g *GitRemote) commit(file, message string) string {
+	g.t.Helper()
+
+	fileName := filepath.Join(g.Path, file)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0750); err != nil {
+		g.t.Fatalf("failed to create directory for file %s: %v", file, err)
+	}
+
+	f, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		g.t.Fatalf("failed to open file %s: %v", file, err)
+	}
+
+	if _, err = f.WriteString(message + "\n"); err != nil {
+		g.t.Fatalf("failed to write file %s: %v", file, err)
+	}
+
+	if err = f.Close(); err != nil {
+		g.t.Fatalf("failed to close file %s: %v", file, err)
+	}
+
+	g.Git("add", file)
+	g.Git("commit", "--quiet", "-m", message)
+
+	return g.Git("rev-parse", "HEAD")
+}
+
+// Merge merges the branch into the target branch by creating a merge commit
+func (g *GitRemote) Merge(target, branch string) {
+	g.t.Helper()
+
+	g.Git("checkout", "--quiet", target)
+	g.Git("merge", "--quiet", "--no-ff", "-m", "Merge branch '"+branch+"' into "+target, branch)
+}
+
+// DeleteBranch removes the branch from the remote
+func (g *GitRemote) DeleteBranch(name string) {
+	g.t.Helper()
+
+	g.Git("checkout", "--quiet", "master")
+	g.Git("branch", "-D", name)
+}
+
+// Tag creates an annotated tag on the given reference
+func (g *GitRemote) Tag(name, ref string) {
+	g.t.Helper()
+	g.Git("tag", "-a", "-m", "release "+name, name, ref)
+}
@@ -1,7 +1,9 @@
 package test
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -9,8 +11,11 @@ import (
 	"github.com/rebel-l/branma_be/bootstrap"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/rebel-l/branma_be/bulk"
 	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/problem"
 	"github.com/rebel-l/go-utils/osutils"
+	"github.com/rebel-l/smis"
 )
 
 // Setup takes care about creating, cleaning and establishing database / connection
@@ -59,3 +64,70 @@ func CheckErrors(t *testing.T, expected, actual error) {
 
 	t.Errorf("expected error '%v' but got '%v'", expected, actual)
 }
+
+// CheckProblem is a helper to assert the response is the expected problem, the request ID is ignored
+func CheckProblem(t *testing.T, expected *problem.Problem, w *httptest.ResponseRecorder) {
+	t.Helper()
+
+	if expected.Status != w.Code {
+		t.Errorf("expected code %d but got %d", expected.Status, w.Code)
+	}
+
+	if contentType := w.Header().Get(smis.HeaderKeyContentType); contentType != problem.ContentType {
+		t.Errorf("expected content type '%s' but got '%s'", problem.ContentType, contentType)
+	}
+
+	actual := &problem.Problem{}
+	if err := json.Unmarshal(w.Body.Bytes(), actual); err != nil {
+		t.Fatalf("failed to decode problem: %v | %s", err, w.Body.Bytes())
+	}
+
+	if expected.Type != actual.Type || expected.Title != actual.Title || expected.Status != actual.Status {
+		t.Errorf("expected problem '%#v' but got '%#v'", expected, actual)
+	}
+
+	if expected.Detail != actual.Detail {
+		t.Errorf("expected detail '%s' but got '%s'", expected.Detail, actual.Detail)
+	}
+
+	if expected.ExistingID != actual.ExistingID {
+		t.Errorf("expected existing ID %d but got %d", expected.ExistingID, actual.ExistingID)
+	}
+
+	if len(expected.Errors) != len(actual.Errors) {
+		t.Errorf("expected %d field errors but got %d", len(expected.Errors), len(actual.Errors))
+		return
+	}
+
+	for i, e := range expected.Errors {
+		if *e != *actual.Errors[i] {
+			t.Errorf("expected field error '%#v' but got '%#v'", e, actual.Errors[i])
+		}
+	}
+}
+
+// CheckResult compares the result of an import, the details of rows are only compared if they are expected
+func CheckResult(t *testing.T, expected, actual *bulk.Result) {
+	t.Helper()
+
+	if actual == nil {
+		t.Fatal("expected result but got nil")
+	}
+
+	if expected.DryRun != actual.DryRun || expected.Created != actual.Created || expected.Updated != actual.Updated ||
+		expected.Unchanged != actual.Unchanged || expected.Failed != actual.Failed {
+		t.Errorf("expected counts of %#v but got %#v", expected, actual)
+	}
+
+	if len(expected.Rows) != len(actual.Rows) {
+		t.Fatalf("expected %d rows but got %d", len(expected.Rows), len(actual.Rows))
+	}
+
+	for i, e := range expected.Rows {
+		a := actual.Rows[i]
+		if e.Row != a.Row || e.Action != a.Action || e.ID != a.ID ||
+			len(e.Errors) != len(a.Errors) || e.Detail != "" && e.Detail != a.Detail {
+			t.Errorf("expected row %#v but got %#v", e, a)
+		}
+	}
+}
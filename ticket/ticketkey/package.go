@@ -0,0 +1,2 @@
+// Package ticketkey extracts ticket keys like JIRA-123 from texts like branch names or commit messages
+package ticketkey
@@ -0,0 +1,28 @@
+package ticketkey
+
+import "regexp"
+
+var pattern = regexp.MustCompile(`[A-Z][A-Z0-9]+-[0-9]+`)
+
+// Find returns the first ticket key found in the text or an empty string if there is none
+func Find(text string) string {
+	return pattern.FindString(text)
+}
+
+// FindAll returns all distinct ticket keys found in the text in order of their appearance
+func FindAll(text string) []string {
+	var keys []string
+
+	found := make(map[string]bool)
+
+	for _, key := range pattern.FindAllString(text, -1) {
+		if found[key] {
+			continue
+		}
+
+		found[key] = true
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,58 @@
+package ticketkey_test
+
+import (
+	"testing"
+
+	"github.com/rebel-l/branma_be/ticket/ticketkey"
+	"github.com/rebel-l/go-utils/slice"
+)
+
+func TestFind(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "feature branch", text: "feature/JIRA-123-nice-feature", expected: "JIRA-123"},
+		{name: "project key with numbers", text: "bugfix/P2P-7", expected: "P2P-7"},
+		{name: "multiple keys", text: "JIRA-1 and JIRA-2", expected: "JIRA-1"},
+		{name: "no key", text: "master", expected: ""},
+		{name: "lower case", text: "feature/jira-123", expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := ticketkey.Find(testCase.text)
+			if testCase.expected != actual {
+				t.Errorf("expected key '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	testCases := []struct {
+		name     string
+		text     string
+		expected slice.StringSlice
+	}{
+		{name: "single key", text: "JIRA-1 fix login", expected: slice.StringSlice{"JIRA-1"}},
+		{name: "multiple keys", text: "JIRA-2, JIRA-1: fix JIRA-2", expected: slice.StringSlice{"JIRA-2", "JIRA-1"}},
+		{name: "no key", text: "fix typo", expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := ticketkey.FindAll(testCase.text)
+			if len(testCase.expected) != len(actual) {
+				t.Fatalf("expected keys %v but got %v", testCase.expected, actual)
+			}
+
+			for i := range actual {
+				if testCase.expected[i] != actual[i] {
+					t.Errorf("expected keys %v but got %v", testCase.expected, actual)
+				}
+			}
+		})
+	}
+}
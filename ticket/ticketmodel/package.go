@@ -0,0 +1,2 @@
+// Package ticketmodel provides the model of tickets as they are exposed by the endpoints
+package ticketmodel
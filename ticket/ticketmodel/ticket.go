@@ -0,0 +1,18 @@
+package ticketmodel
+
+import "time"
+
+// Ticket represents a model of ticket
+type Ticket struct {
+	Key        string     `json:"key" yaml:"key"`
+	Summary    string     `json:"summary" yaml:"summary"`
+	Status     string     `json:"status" yaml:"status"`
+	Category   string     `json:"category,omitempty" yaml:"category,omitempty"`
+	Type       string     `json:"type" yaml:"type"`
+	Parent     string     `json:"parent,omitempty" yaml:"parent,omitempty"`
+	Assignee   string     `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	Priority   string     `json:"priority,omitempty" yaml:"priority,omitempty"`
+	FetchedAt  *time.Time `json:"fetched_at,omitempty" yaml:"fetched_at,omitempty"`
+	FetchError string     `json:"fetch_error,omitempty" yaml:"fetch_error,omitempty"`
+	URL        string     `json:"url,omitempty" yaml:"url,omitempty"`
+}
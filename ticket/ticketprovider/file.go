@@ -0,0 +1,114 @@
+package ticketprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/rebel-l/branma_be/jira"
+)
+
+// fileTicket represents a ticket in the file
+type fileTicket struct {
+	Key         string   `json:"key" yaml:"key"`
+	Summary     string   `json:"summary" yaml:"summary"`
+	Status      string   `json:"status" yaml:"status"`
+	Category    string   `json:"category" yaml:"category"`
+	Type        string   `json:"type" yaml:"type"`
+	Parent      string   `json:"parent" yaml:"parent"`
+	Assignee    string   `json:"assignee" yaml:"assignee"`
+	Priority    string   `json:"priority" yaml:"priority"`
+	FixVersions []string `json:"fix_versions" yaml:"fix_versions"`
+}
+
+// fileContent represents the content of the file
+type fileContent struct {
+	Tickets []*fileTicket `json:"tickets" yaml:"tickets"`
+}
+
+// File provides the tickets listed in a YAML or JSON file, the format is chosen by the extension of the file. The file
+// is read with every request, so changes don't need a restart. The category is one of the categories of tickets like
+// in_progress.
+type File struct {
+	path string
+}
+
+// NewFile returns a provider for the given file, it fails if the file can't be read
+func NewFile(path string) (*File, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: path to the file is required", ErrNotConfigured)
+	}
+
+	f := &File{path: path}
+	if _, err := f.read(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Issues returns the tickets with the given keys, keys not listed in the file are ignored
+func (f *File) Issues(_ context.Context, keys []string) ([]*jira.Issue, error) {
+	tickets, err := f.read()
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []*jira.Issue{}
+
+	for _, key := range keys {
+		t, ok := tickets[key]
+		if !ok {
+			continue
+		}
+
+		issues = append(issues, &jira.Issue{
+			Key:            t.Key,
+			Summary:        t.Summary,
+			Status:         t.Status,
+			StatusCategory: t.Category,
+			Type:           t.Type,
+			Parent:         t.Parent,
+			Assignee:       t.Assignee,
+			Priority:       t.Priority,
+			FixVersions:    t.FixVersions,
+		})
+	}
+
+	return issues, nil
+}
+
+// read returns the tickets of the file mapped by their key
+func (f *File) read() (map[string]*fileTicket, error) {
+	data, err := ioutil.ReadFile(filepath.Clean(f.path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tickets from file: %w", err)
+	}
+
+	content := &fileContent{}
+
+	switch strings.ToLower(filepath.Ext(f.path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, content)
+	case ".json":
+		err = json.Unmarshal(data, content)
+	default:
+		return nil, fmt.Errorf("%w: file %s must be YAML or JSON", ErrNotConfigured, f.path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tickets from file %s: %w", f.path, err)
+	}
+
+	tickets := make(map[string]*fileTicket, len(content.Tickets))
+	for _, t := range content.Tickets {
+		tickets[t.Key] = t
+	}
+
+	return tickets, nil
+}
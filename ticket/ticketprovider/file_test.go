@@ -0,0 +1,70 @@
+package ticketprovider_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebel-l/branma_be/ticket/ticketprovider"
+)
+
+func TestFile_Issues(t *testing.T) {
+	for _, name := range []string{"tickets.yaml", "tickets.json"} {
+		t.Run(name, func(t *testing.T) {
+			provider, err := ticketprovider.NewFile(filepath.Join(".", "testdata", name))
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			issues, err := provider.Issues(context.Background(), []string{"DOC-1", "DOC-3"})
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			if len(issues) != 1 {
+				t.Fatalf("expected only DOC-1 but got %#v", issues)
+			}
+
+			issue := issues[0]
+			if issue.Key != "DOC-1" || issue.Summary != "Describe the setup" || issue.Status != "In Progress" ||
+				issue.StatusCategory != "in_progress" || issue.Type != "Task" || issue.Parent != "DOC-9" ||
+				issue.Assignee != "Jane Doe" || issue.Priority != "High" ||
+				len(issue.FixVersions) != 1 || issue.FixVersions[0] != "1.0.0" {
+				t.Errorf("unexpected issue %#v", issue)
+			}
+		})
+	}
+}
+
+func TestNewFile_Errors(t *testing.T) {
+	testCases := []struct {
+		name        string
+		path        string
+		expectedErr error
+	}{
+		{
+			name:        "no path",
+			expectedErr: ticketprovider.ErrNotConfigured,
+		},
+		{
+			name:        "unknown format",
+			path:        filepath.Join(".", "file_test.go"),
+			expectedErr: ticketprovider.ErrNotConfigured,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := ticketprovider.NewFile(testCase.path); !errors.Is(err, testCase.expectedErr) {
+				t.Errorf("expected error '%v' but got '%v'", testCase.expectedErr, err)
+			}
+		})
+	}
+
+	for _, path := range []string{"no_file.yaml", "tickets_error.yaml"} {
+		if _, err := ticketprovider.NewFile(filepath.Join(".", "testdata", path)); err == nil {
+			t.Errorf("expected an error for %s but got none", path)
+		}
+	}
+}
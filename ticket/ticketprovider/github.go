@@ -0,0 +1,171 @@
+package ticketprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+)
+
+const (
+	// TypeIssue is the type of tickets which are issues of GitHub
+	TypeIssue = "Issue"
+
+	// TypePullRequest is the type of tickets which are pull requests of GitHub
+	TypePullRequest = "Pull Request"
+
+	stateClosed = "closed"
+	timeout     = 30 * time.Second
+)
+
+// gitHubIssue represents an issue as it is returned by the REST API of GitHub
+type gitHubIssue struct {
+	Number   int    `json:"number"`
+	Title    string `json:"title"`
+	State    string `json:"state"`
+	Assignee *struct {
+		Login string `json:"login"`
+	} `json:"assignee"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// issue returns the issue of GitHub as ticket with the given key, the category is derived from the state and the
+// assignee
+func (g *gitHubIssue) issue(key string) *jira.Issue {
+	issue := &jira.Issue{
+		Key:            key,
+		Summary:        g.Title,
+		Status:         g.State,
+		StatusCategory: config.CategoryTodo,
+		Type:           TypeIssue,
+	}
+
+	if g.PullRequest != nil {
+		issue.Type = TypePullRequest
+	}
+
+	if g.Assignee != nil {
+		issue.Assignee = g.Assignee.Login
+		issue.StatusCategory = config.CategoryInProgress
+	}
+
+	if g.State == stateClosed {
+		issue.StatusCategory = config.CategoryDone
+	}
+
+	if g.Milestone != nil {
+		issue.FixVersions = []string{g.Milestone.Title}
+	}
+
+	return issue
+}
+
+// GitHub provides the issues of a repository by the REST API of GitHub or a compatible one. Ticket keys are mapped
+// to issue numbers by a prefix, e.g. GH-12 is issue 12.
+type GitHub struct {
+	baseURL    string
+	repository string
+	prefix     string
+	token      string
+	httpClient *http.Client
+}
+
+// NewGitHub returns a provider for the GitHub repository configured
+func NewGitHub(cfg *config.TicketProvider) (*GitHub, error) {
+	if cfg.GetRepository() == "" || !strings.Contains(cfg.GetRepository(), "/") {
+		return nil, fmt.Errorf("%w: repository in the form owner/name is required", ErrNotConfigured)
+	}
+
+	g := &GitHub{
+		baseURL:    strings.TrimSuffix(cfg.GetBaseURL(), "/"),
+		repository: cfg.GetRepository(),
+		prefix:     cfg.GetKeyPrefix() + "-",
+		httpClient: &http.Client{Timeout: timeout},
+	}
+
+	if env := cfg.GetTokenEnv(); env != "" {
+		g.token = strings.TrimSpace(os.Getenv(env))
+		if g.token == "" {
+			return nil, fmt.Errorf("%w: environment variable %s is empty", ErrNotConfigured, env)
+		}
+	}
+
+	return g, nil
+}
+
+// Issues returns the issues with the given keys, keys without the prefix or of issues which don't exist are ignored
+func (g *GitHub) Issues(ctx context.Context, keys []string) ([]*jira.Issue, error) {
+	issues := []*jira.Issue{}
+
+	for _, key := range keys {
+		number, err := strconv.Atoi(strings.TrimPrefix(key, g.prefix))
+		if !strings.HasPrefix(key, g.prefix) || err != nil {
+			continue
+		}
+
+		issue, err := g.issue(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+
+		if issue != nil {
+			issues = append(issues, issue.issue(key))
+		}
+	}
+
+	return issues, nil
+}
+
+// issue returns the issue with the given number, it is nil if the issue doesn't exist
+func (g *GitHub) issue(ctx context.Context, number int) (*gitHubIssue, error) {
+	url := fmt.Sprintf("%s/repos/%s/issues/%d", g.baseURL, g.repository, number)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	if g.token != "" {
+		req.Header.Set("Authorization", "token "+g.token)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to github failed: %w", err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusGone:
+		return nil, nil
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return nil, ErrRateLimited
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		return nil, ErrAuthentication
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: status %d", ErrUnexpectedResponse, resp.StatusCode)
+	}
+
+	issue := &gitHubIssue{}
+	if err = json.NewDecoder(resp.Body).Decode(issue); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnexpectedResponse, err)
+	}
+
+	return issue, nil
+}
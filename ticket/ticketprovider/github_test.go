@@ -0,0 +1,120 @@
+package ticketprovider_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/ticket/ticketprovider"
+)
+
+func newGitHubServer(t *testing.T, token string) *httptest.Server {
+	t.Helper()
+
+	issues := map[string]string{
+		"/repos/rebel-l/docs/issues/1": `{"number": 1, "title": "first", "state": "open", "milestone": {"title": "1.0.0"}}`,
+		"/repos/rebel-l/docs/issues/2": `{"number": 2, "title": "second", "state": "open", "assignee": {"login": "jane"}}`,
+		"/repos/rebel-l/docs/issues/3": `{"number": 3, "title": "third", "state": "closed", "pull_request": {}}`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != "token "+token {
+			writer.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		body, ok := issues[request.URL.Path]
+		if !ok {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = writer.Write([]byte(body))
+	}))
+}
+
+func TestGitHub_Issues(t *testing.T) { // nolint:funlen
+	server := newGitHubServer(t, "secret")
+	defer server.Close()
+
+	if err := os.Setenv("BRANMA_TEST_GITHUB_TOKEN", "secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		_ = os.Unsetenv("BRANMA_TEST_GITHUB_TOKEN")
+	}()
+
+	repository := "rebel-l/docs"
+	tokenEnv := "BRANMA_TEST_GITHUB_TOKEN"
+
+	provider, err := ticketprovider.NewGitHub(&config.TicketProvider{
+		BaseURL:    &server.URL,
+		Repository: &repository,
+		TokenEnv:   &tokenEnv,
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	// 1. issues
+	issues, err := provider.Issues(context.Background(), []string{"GH-1", "GH-2", "GH-3", "GH-4", "JIRA-1"})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 issues but got %#v", issues)
+	}
+
+	expected := []struct {
+		key, summary, status, category, typ, assignee string
+	}{
+		{"GH-1", "first", "open", config.CategoryTodo, ticketprovider.TypeIssue, ""},
+		{"GH-2", "second", "open", config.CategoryInProgress, ticketprovider.TypeIssue, "jane"},
+		{"GH-3", "third", "closed", config.CategoryDone, ticketprovider.TypePullRequest, ""},
+	}
+
+	for i, e := range expected {
+		issue := issues[i]
+		if issue.Key != e.key || issue.Summary != e.summary || issue.Status != e.status ||
+			issue.StatusCategory != e.category || issue.Type != e.typ || issue.Assignee != e.assignee {
+			t.Errorf("expected %v but got %#v", e, issue)
+		}
+	}
+
+	if len(issues[0].FixVersions) != 1 || issues[0].FixVersions[0] != "1.0.0" {
+		t.Errorf("expected milestone as fix version but got %v", issues[0].FixVersions)
+	}
+
+	// 2. authentication
+	provider, err = ticketprovider.NewGitHub(&config.TicketProvider{BaseURL: &server.URL, Repository: &repository})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	_, err = provider.Issues(context.Background(), []string{"GH-1"})
+	if !errors.Is(err, ticketprovider.ErrAuthentication) {
+		t.Errorf("expected error '%v' but got '%v'", ticketprovider.ErrAuthentication, err)
+	}
+}
+
+func TestNewGitHub_Errors(t *testing.T) {
+	repository := "docs"
+	_, err := ticketprovider.NewGitHub(&config.TicketProvider{Repository: &repository})
+	if !errors.Is(err, ticketprovider.ErrNotConfigured) {
+		t.Errorf("expected error '%v' but got '%v'", ticketprovider.ErrNotConfigured, err)
+	}
+
+	repository = "rebel-l/docs"
+	tokenEnv := "BRANMA_TEST_GITHUB_TOKEN_NOT_SET"
+
+	_, err = ticketprovider.NewGitHub(&config.TicketProvider{Repository: &repository, TokenEnv: &tokenEnv})
+	if !errors.Is(err, ticketprovider.ErrNotConfigured) {
+		t.Errorf("expected error '%v' but got '%v'", ticketprovider.ErrNotConfigured, err)
+	}
+}
@@ -0,0 +1,3 @@
+// Package ticketprovider provides the ticket trackers the tickets of the branches are fetched from besides JIRA, like
+// the issues of a GitHub repository or a static file
+package ticketprovider
@@ -0,0 +1,66 @@
+package ticketprovider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+)
+
+var (
+	// ErrUnknownType occurs if the type of a provider configured is not supported
+	ErrUnknownType = errors.New("unknown ticket provider")
+
+	// ErrNotConfigured occurs if settings required by the provider are missing
+	ErrNotConfigured = errors.New("ticket provider is not configured")
+
+	// ErrAuthentication occurs if the ticket tracker rejects the credentials
+	ErrAuthentication = errors.New("ticket provider authentication failed")
+
+	// ErrRateLimited occurs if the ticket tracker rejects requests due to too many requests in a period of time
+	ErrRateLimited = errors.New("ticket provider rate limit exceeded")
+
+	// ErrUnexpectedResponse occurs if the ticket tracker responds with an unexpected status code or body
+	ErrUnexpectedResponse = errors.New("unexpected response from ticket provider")
+)
+
+// Provider fetches tickets from a ticket tracker, the tickets are represented like issues of JIRA. The JIRA client
+// is a provider as well.
+type Provider interface {
+	// Issues returns the tickets with the given keys, keys of tickets which don't exist are ignored
+	Issues(ctx context.Context, keys []string) ([]*jira.Issue, error)
+}
+
+// New returns the provider configured, it is nil for ProviderJira as the JIRA client is shared
+func New(cfg *config.TicketProvider) (Provider, error) {
+	switch cfg.GetType() {
+	case config.ProviderJira:
+		return nil, nil
+	case config.ProviderGitHub:
+		return NewGitHub(cfg)
+	case config.ProviderFile:
+		return NewFile(cfg.GetPath())
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, cfg.GetType())
+	}
+}
+
+// NewAll returns the providers configured by the names of the repositories, repositories using JIRA are omitted
+func NewAll(cfgs map[string]*config.TicketProvider) (map[string]Provider, error) {
+	providers := make(map[string]Provider)
+
+	for repository, cfg := range cfgs {
+		provider, err := New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("ticket provider of repository %s: %w", repository, err)
+		}
+
+		if provider != nil {
+			providers[repository] = provider
+		}
+	}
+
+	return providers, nil
+}
@@ -0,0 +1,37 @@
+package ticketprovider_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/ticket/ticketprovider"
+)
+
+func TestNewAll(t *testing.T) {
+	jira := config.ProviderJira
+	file := config.ProviderFile
+	github := config.ProviderGitHub
+	unknown := "trello"
+	path := filepath.Join(".", "testdata", "tickets.yaml")
+	repository := "rebel-l/docs"
+
+	providers, err := ticketprovider.NewAll(map[string]*config.TicketProvider{
+		"backend": {Type: &jira},
+		"docs":    {Type: &file, Path: &path},
+		"website": {Type: &github, Repository: &repository},
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(providers) != 2 || providers["docs"] == nil || providers["website"] == nil {
+		t.Errorf("expected providers for docs and website only but got %#v", providers)
+	}
+
+	_, err = ticketprovider.NewAll(map[string]*config.TicketProvider{"board": {Type: &unknown}})
+	if !errors.Is(err, ticketprovider.ErrUnknownType) {
+		t.Errorf("expected error '%v' but got '%v'", ticketprovider.ErrUnknownType, err)
+	}
+}
@@ -0,0 +1,79 @@
+package ticketstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Action represents a change written back to JIRA for a ticket in the database, it is stored once the change was
+// done successfully, so it is never done twice
+type Action struct {
+	ID        int       `db:"id"`
+	TicketKey string    `db:"ticket_key"`
+	VersionID int       `db:"version_id"`
+	Event     string    `db:"event"`
+	Action    string    `db:"action"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Create creates current action in the database
+func (a *Action) Create(ctx context.Context, db *sqlx.DB) error {
+	if !a.IsValid() {
+		return ErrDataMissing
+	}
+
+	if a.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`INSERT INTO ticket_actions (ticket_key, version_id, event, action) VALUES (?, ?, ?, ?)`)
+
+	res, err := db.ExecContext(ctx, q, a.TicketKey, a.VersionID, a.Event, a.Action)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	a.ID = int(id)
+
+	return a.Read(ctx, db)
+}
+
+// Read sets the action from database by given ID
+func (a *Action) Read(ctx context.Context, db *sqlx.DB) error {
+	if a == nil || a.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM ticket_actions WHERE id = ?`)
+
+	return db.GetContext(ctx, a, q, a.ID)
+}
+
+// ReadByEvent sets the action from database by given ticket key, version ID, event and action
+func (a *Action) ReadByEvent(ctx context.Context, db *sqlx.DB) error {
+	if !a.IsValid() {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`
+		SELECT * FROM ticket_actions WHERE ticket_key = ? AND version_id = ? AND event = ? AND action = ?
+	`)
+
+	return db.GetContext(ctx, a, q, a.TicketKey, a.VersionID, a.Event, a.Action)
+}
+
+// IsValid returns true if all mandatory fields are set
+func (a *Action) IsValid() bool {
+	if a == nil || a.TicketKey == "" || a.VersionID == 0 || a.Event == "" || a.Action == "" {
+		return false
+	}
+
+	return true
+}
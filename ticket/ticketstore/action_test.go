@@ -0,0 +1,115 @@
+package ticketstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestAction_Create(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "actionCreate")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := (&versionstore.Version{Version: "1.0.0"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *ticketstore.Action
+		expectedErr error
+	}{
+		{
+			name:        "action is nil",
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "action has no event",
+			actual:      &ticketstore.Action{TicketKey: "JIRA-1", VersionID: 1, Action: "comment"},
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "action has ID",
+			actual:      &ticketstore.Action{ID: 1, TicketKey: "JIRA-1", VersionID: 1, Event: "merged", Action: "comment"},
+			expectedErr: ticketstore.ErrIDIsSet,
+		},
+		{
+			name:   "success",
+			actual: &ticketstore.Action{TicketKey: "JIRA-1", VersionID: 1, Event: "merged", Action: "comment"},
+		},
+		{
+			name:   "duplicate",
+			actual: &ticketstore.Action{TicketKey: "JIRA-1", VersionID: 1, Event: "merged", Action: "comment"},
+			expectedErr: errors.New("UNIQUE constraint failed: ticket_actions.ticket_key, ticket_actions.version_id, " +
+				"ticket_actions.event, ticket_actions.action"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Create(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+
+			if err == nil && (testCase.actual.ID == 0 || testCase.actual.CreatedAt.IsZero()) {
+				t.Errorf("expected action to be stored but got %#v", testCase.actual)
+			}
+		})
+	}
+}
+
+func TestAction_ReadByEvent(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "actionReadByEvent")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := (&versionstore.Version{Version: "1.0.0"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	action := &ticketstore.Action{TicketKey: "JIRA-1", VersionID: 1, Event: "merged", Action: "comment"}
+	if err := action.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	var nilAction *ticketstore.Action
+	test.CheckErrors(t, ticketstore.ErrDataMissing, nilAction.ReadByEvent(context.Background(), db))
+
+	actual := &ticketstore.Action{TicketKey: "JIRA-1", VersionID: 1, Event: "merged", Action: "comment"}
+	if err := actual.ReadByEvent(context.Background(), db); err != nil || actual.ID != action.ID {
+		t.Errorf("expected action %d but got %d: %v", action.ID, actual.ID, err)
+	}
+
+	err := (&ticketstore.Action{TicketKey: "JIRA-1", VersionID: 1, Event: "released", Action: "comment"}).
+		ReadByEvent(context.Background(), db)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected error '%v' but got '%v'", sql.ErrNoRows, err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package ticketstore contains the CRUD operations for the ticket on the database
+package ticketstore
@@ -0,0 +1,198 @@
+package ticketstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ErrIDMissing will be thrown if an ID is expected but not set
+	ErrIDMissing = errors.New("id is mandatory for this operation")
+
+	// ErrIDIsSet will be thrown if no ID is expected but already set
+	ErrIDIsSet = errors.New("id should be not set for this operation, use update instead")
+
+	// ErrDataMissing will be thrown if mandatory data is not set
+	ErrDataMissing = errors.New("no data or mandatory data missing")
+)
+
+// Ticket represents the ticket in the database
+type Ticket struct {
+	ID         int        `db:"id"`
+	Key        string     `db:"ticket_key"`
+	Summary    string     `db:"summary"`
+	Status     string     `db:"status"`
+	Category   string     `db:"status_category"`
+	Type       string     `db:"ticket_type"`
+	Parent     string     `db:"parent_key"`
+	Assignee   string     `db:"assignee"`
+	Priority   string     `db:"priority"`
+	FetchedAt  *time.Time `db:"fetched_at"`
+	FetchError string     `db:"fetch_error"`
+	CreatedAt  time.Time  `db:"created_at"`
+	ModifiedAt time.Time  `db:"modified_at"`
+}
+
+// Create creates current ticket in the database
+func (t *Ticket) Create(ctx context.Context, db *sqlx.DB) error {
+	if !t.IsValid() {
+		return ErrDataMissing
+	}
+
+	if t.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`
+		INSERT INTO tickets (
+			ticket_key,
+			summary,
+			status,
+			status_category,
+			ticket_type,
+			parent_key,
+			assignee,
+			priority,
+			fetched_at,
+			fetch_error
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`)
+
+	res, err := db.ExecContext(ctx, q, t.getCreateArgs()...)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	t.ID = int(id)
+
+	return t.Read(ctx, db)
+}
+
+// Read sets the ticket from database by given ID
+func (t *Ticket) Read(ctx context.Context, db *sqlx.DB) error {
+	if t == nil || t.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM tickets WHERE id = ?`)
+
+	return t.get(ctx, db, q, t.ID)
+}
+
+// ReadByKey sets the ticket from database by given key
+func (t *Ticket) ReadByKey(ctx context.Context, db *sqlx.DB) error {
+	if t == nil || t.Key == "" {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM tickets WHERE ticket_key = ?`)
+
+	return t.get(ctx, db, q, t.Key)
+}
+
+// ReadOrCreate sets the ticket from database by given key, if it doesn't exist it is created
+func (t *Ticket) ReadOrCreate(ctx context.Context, db *sqlx.DB) error {
+	err := t.ReadByKey(ctx, db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return t.Create(ctx, db)
+	}
+
+	return err
+}
+
+// get scans into a new ticket, so the current one is untouched if loading fails
+func (t *Ticket) get(ctx context.Context, db *sqlx.DB, q string, args ...interface{}) error {
+	res := &Ticket{}
+	if err := db.GetContext(ctx, res, q, args...); err != nil {
+		return err
+	}
+
+	*t = *res
+
+	return nil
+}
+
+// Update changes the current ticket on the database by ID
+func (t *Ticket) Update(ctx context.Context, db *sqlx.DB) error {
+	if !t.IsValid() {
+		return ErrDataMissing
+	}
+
+	if t.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`
+		UPDATE tickets
+		SET ticket_key = ?,
+			summary = ?,
+			status = ?,
+			status_category = ?,
+			ticket_type = ?,
+			parent_key = ?,
+			assignee = ?,
+			priority = ?,
+			fetched_at = ?,
+			fetch_error = ?
+		WHERE id = ?
+	`)
+
+	if _, err := db.ExecContext(ctx, q, t.getUpdateArgs()...); err != nil {
+		return err
+	}
+
+	return t.Read(ctx, db)
+}
+
+// Delete removes the current ticket from database by its ID
+func (t *Ticket) Delete(ctx context.Context, db *sqlx.DB) error {
+	if t == nil || t.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`DELETE FROM tickets WHERE id = ?`)
+
+	_, err := db.ExecContext(ctx, q, t.ID)
+
+	return err
+}
+
+// IsValid returns true if all mandatory fields are set
+func (t *Ticket) IsValid() bool {
+	if t == nil || t.Key == "" {
+		return false
+	}
+
+	return true
+}
+
+func (t *Ticket) getCreateArgs() []interface{} {
+	return []interface{}{
+		t.Key,
+		t.Summary,
+		t.Status,
+		t.Category,
+		t.Type,
+		t.Parent,
+		t.Assignee,
+		t.Priority,
+		t.FetchedAt,
+		t.FetchError,
+	}
+}
+
+func (t *Ticket) getUpdateArgs() []interface{} {
+	args := t.getCreateArgs()
+	args = append(args, t.ID)
+
+	return args
+}
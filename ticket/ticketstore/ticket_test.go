@@ -0,0 +1,371 @@
+package ticketstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+const (
+	testCluster = "test_ticket"
+)
+
+func TestTicket_Create(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeCreate")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	fetchedAt := time.Date(2020, 2, 9, 10, 30, 0, 0, time.UTC)
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *ticketstore.Ticket
+		expected    *ticketstore.Ticket
+		expectedErr error
+	}{
+		{
+			name:        "ticket is nil",
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "ticket has no key",
+			actual:      &ticketstore.Ticket{Summary: "do something"},
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "ticket has ID",
+			actual:      &ticketstore.Ticket{ID: 1, Key: "JIRA-1"},
+			expectedErr: ticketstore.ErrIDIsSet,
+		},
+		{
+			name:     "success key only",
+			actual:   &ticketstore.Ticket{Key: "JIRA-1"},
+			expected: &ticketstore.Ticket{ID: 1, Key: "JIRA-1"},
+		},
+		{
+			name: "success all fields",
+			actual: &ticketstore.Ticket{
+				Key:        "JIRA-2",
+				Summary:    "do something",
+				Status:     "Open",
+				Category:   "todo",
+				Type:       "Story",
+				Parent:     "JIRA-1",
+				Assignee:   "Jane Doe",
+				Priority:   "High",
+				FetchedAt:  &fetchedAt,
+				FetchError: "something went wrong",
+			},
+			expected: &ticketstore.Ticket{
+				ID:         2,
+				Key:        "JIRA-2",
+				Summary:    "do something",
+				Status:     "Open",
+				Category:   "todo",
+				Type:       "Story",
+				Parent:     "JIRA-1",
+				Assignee:   "Jane Doe",
+				Priority:   "High",
+				FetchedAt:  &fetchedAt,
+				FetchError: "something went wrong",
+			},
+		},
+		{
+			name:        "duplicate",
+			actual:      &ticketstore.Ticket{Key: "JIRA-1"},
+			expectedErr: errors.New("UNIQUE constraint failed: tickets.ticket_key"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Create(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+			testTicket(t, testCase.expected, testCase.actual)
+		})
+	}
+}
+
+func TestTicket_ReadByKey(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByKey")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := (&ticketstore.Ticket{Key: "JIRA-1", Summary: "first"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *ticketstore.Ticket
+		expected    *ticketstore.Ticket
+		expectedErr error
+	}{
+		{
+			name:        "ticket is nil",
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "key not set",
+			actual:      &ticketstore.Ticket{},
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "key not existing",
+			actual:      &ticketstore.Ticket{Key: "JIRA-2"},
+			expected:    &ticketstore.Ticket{Key: "JIRA-2"},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name:     "success",
+			actual:   &ticketstore.Ticket{Key: "JIRA-1"},
+			expected: &ticketstore.Ticket{ID: 1, Key: "JIRA-1", Summary: "first"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.ReadByKey(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+
+			if err == nil {
+				testTicket(t, testCase.expected, testCase.actual)
+			}
+		})
+	}
+}
+
+func TestTicket_ReadOrCreate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadOrCreate")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := (&ticketstore.Ticket{Key: "JIRA-1", Summary: "first"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	var nilTicket *ticketstore.Ticket
+	test.CheckErrors(t, ticketstore.ErrDataMissing, nilTicket.ReadOrCreate(context.Background(), db))
+
+	existing := &ticketstore.Ticket{Key: "JIRA-1"}
+	if err := existing.ReadOrCreate(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testTicket(t, &ticketstore.Ticket{ID: 1, Key: "JIRA-1", Summary: "first"}, existing)
+
+	created := &ticketstore.Ticket{Key: "JIRA-2"}
+	if err := created.ReadOrCreate(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	testTicket(t, &ticketstore.Ticket{ID: 2, Key: "JIRA-2"}, created)
+}
+
+func TestTicket_Update(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeUpdate")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := (&ticketstore.Ticket{Key: "JIRA-1"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	fetchedAt := time.Date(2020, 2, 9, 10, 30, 0, 0, time.UTC)
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *ticketstore.Ticket
+		expected    *ticketstore.Ticket
+		expectedErr error
+	}{
+		{
+			name:        "ticket is nil",
+			expectedErr: ticketstore.ErrDataMissing,
+		},
+		{
+			name:        "ticket has no ID",
+			actual:      &ticketstore.Ticket{Key: "JIRA-1"},
+			expectedErr: ticketstore.ErrIDMissing,
+		},
+		{
+			name: "success",
+			actual: &ticketstore.Ticket{
+				ID:        1,
+				Key:       "JIRA-1",
+				Summary:   "do something",
+				Status:    "Done",
+				Category:  "done",
+				Type:      "Bug",
+				Assignee:  "John Doe",
+				Priority:  "Low",
+				FetchedAt: &fetchedAt,
+			},
+			expected: &ticketstore.Ticket{
+				ID:        1,
+				Key:       "JIRA-1",
+				Summary:   "do something",
+				Status:    "Done",
+				Category:  "done",
+				Type:      "Bug",
+				Assignee:  "John Doe",
+				Priority:  "Low",
+				FetchedAt: &fetchedAt,
+			},
+		},
+		{
+			name:        "not existing ticket",
+			actual:      &ticketstore.Ticket{ID: 2, Key: "JIRA-2"},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Update(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+			testTicket(t, testCase.expected, testCase.actual)
+		})
+	}
+}
+
+func TestTicket_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeDelete")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	ticket := &ticketstore.Ticket{Key: "JIRA-1"}
+	if err := ticket.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	var nilTicket *ticketstore.Ticket
+	test.CheckErrors(t, ticketstore.ErrIDMissing, nilTicket.Delete(context.Background(), db))
+
+	if err := ticket.Delete(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	err := (&ticketstore.Ticket{ID: ticket.ID}).Read(context.Background(), db)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected error '%v' after deletion but got '%v'", sql.ErrNoRows, err)
+	}
+}
+
+func testTicket(t *testing.T, expected, actual *ticketstore.Ticket) { // nolint:gocyclo
+	t.Helper()
+
+	if expected == nil || actual == nil {
+		return
+	}
+
+	if expected.ID != actual.ID {
+		t.Errorf("expected ID %d but got %d", expected.ID, actual.ID)
+	}
+
+	if expected.Key != actual.Key {
+		t.Errorf("expected key '%s' but got '%s'", expected.Key, actual.Key)
+	}
+
+	if expected.Summary != actual.Summary {
+		t.Errorf("expected summary '%s' but got '%s'", expected.Summary, actual.Summary)
+	}
+
+	if expected.Status != actual.Status {
+		t.Errorf("expected status '%s' but got '%s'", expected.Status, actual.Status)
+	}
+
+	if expected.Category != actual.Category {
+		t.Errorf("expected category '%s' but got '%s'", expected.Category, actual.Category)
+	}
+
+	if expected.Type != actual.Type {
+		t.Errorf("expected type '%s' but got '%s'", expected.Type, actual.Type)
+	}
+
+	if expected.Parent != actual.Parent {
+		t.Errorf("expected parent '%s' but got '%s'", expected.Parent, actual.Parent)
+	}
+
+	if expected.Assignee != actual.Assignee {
+		t.Errorf("expected assignee '%s' but got '%s'", expected.Assignee, actual.Assignee)
+	}
+
+	if expected.Priority != actual.Priority {
+		t.Errorf("expected priority '%s' but got '%s'", expected.Priority, actual.Priority)
+	}
+
+	if (expected.FetchedAt == nil) != (actual.FetchedAt == nil) ||
+		expected.FetchedAt != nil && !expected.FetchedAt.Equal(*actual.FetchedAt) {
+		t.Errorf("expected fetched at %v but got %v", expected.FetchedAt, actual.FetchedAt)
+	}
+
+	if expected.FetchError != actual.FetchError {
+		t.Errorf("expected fetch error '%s' but got '%s'", expected.FetchError, actual.FetchError)
+	}
+
+	if expected.ID != 0 && actual.CreatedAt.IsZero() {
+		t.Error("created at should be greater than the zero date")
+	}
+
+	if expected.ID != 0 && actual.ModifiedAt.IsZero() {
+		t.Error("modified at should be greater than the zero date")
+	}
+}
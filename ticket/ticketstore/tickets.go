@@ -0,0 +1,80 @@
+package ticketstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// maxKeysPerQuery limits the keys bound to one query, SQLite allows at most 999 variables per statement
+const maxKeysPerQuery = 999
+
+// Tickets represents a collection of Ticket
+type Tickets []*Ticket
+
+// ReadAll loads all tickets from the database ordered by key
+func (t *Tickets) ReadAll(ctx context.Context, db *sqlx.DB) error {
+	if t == nil {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM tickets ORDER BY ticket_key`)
+
+	return db.SelectContext(ctx, t, q)
+}
+
+// ReadByKeys loads the tickets with the given keys ordered by key, keys not existing in the database are ignored. The
+// keys are queried in chunks, so any number of keys can be loaded.
+func (t *Tickets) ReadByKeys(ctx context.Context, db *sqlx.DB, keys []string) error {
+	if t == nil {
+		return ErrDataMissing
+	}
+
+	unique := uniqueKeys(keys)
+
+	for start := 0; start < len(unique); start += maxKeysPerQuery {
+		end := start + maxKeysPerQuery
+		if end > len(unique) {
+			end = len(unique)
+		}
+
+		q, args, err := sqlx.In(`SELECT * FROM tickets WHERE ticket_key IN (?) ORDER BY ticket_key`, unique[start:end])
+		if err != nil {
+			return err
+		}
+
+		if err = db.SelectContext(ctx, t, db.Rebind(q), args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueKeys returns the keys sorted and without duplicates
+func uniqueKeys(keys []string) []string {
+	found := make(map[string]bool, len(keys))
+	unique := make([]string, 0, len(keys))
+
+	for _, k := range keys {
+		if !found[k] {
+			found[k] = true
+			unique = append(unique, k)
+		}
+	}
+
+	sort.Strings(unique)
+
+	return unique
+}
+
+// ByKey returns the tickets of the collection mapped by their key
+func (t Tickets) ByKey() map[string]*Ticket {
+	res := make(map[string]*Ticket, len(t))
+	for _, ticket := range t {
+		res[ticket.Key] = ticket
+	}
+
+	return res
+}
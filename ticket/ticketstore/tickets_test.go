@@ -0,0 +1,77 @@
+package ticketstore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+func TestTickets_ReadByKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByKeys")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, key := range []string{"JIRA-2", "JIRA-1", "JIRA-3"} {
+		if err := (&ticketstore.Ticket{Key: key}).Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var nilTickets *ticketstore.Tickets
+	test.CheckErrors(t, ticketstore.ErrDataMissing, nilTickets.ReadByKeys(context.Background(), db, []string{"JIRA-1"}))
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadByKeys(context.Background(), db, nil); err != nil || len(tickets) != 0 {
+		t.Fatalf("expected no tickets and no error but got %d tickets and error: %v", len(tickets), err)
+	}
+
+	if err := tickets.ReadByKeys(context.Background(), db, []string{"JIRA-3", "JIRA-1", "JIRA-9"}); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(tickets) != 2 || tickets[0].Key != "JIRA-1" || tickets[1].Key != "JIRA-3" {
+		t.Fatalf("expected tickets JIRA-1 and JIRA-3 but got %#v", tickets)
+	}
+
+	byKey := tickets.ByKey()
+	if byKey["JIRA-1"] != tickets[0] || byKey["JIRA-3"] != tickets[1] || byKey["JIRA-2"] != nil {
+		t.Errorf("unexpected mapping by key: %#v", byKey)
+	}
+
+	// more keys than variables allowed in one query
+	keys := []string{"JIRA-3", "JIRA-3"}
+	for i := 0; i < 1500; i++ {
+		keys = append(keys, fmt.Sprintf("AAA-%d", i), "JIRA-2")
+	}
+
+	tickets = nil
+	if err := tickets.ReadByKeys(context.Background(), db, keys); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(tickets) != 2 || tickets[0].Key != "JIRA-2" || tickets[1].Key != "JIRA-3" {
+		t.Errorf("expected tickets JIRA-2 and JIRA-3 once but got %#v", tickets)
+	}
+
+	var all ticketstore.Tickets
+	if err := all.ReadAll(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(all) != 3 || all[0].Key != "JIRA-1" || all[2].Key != "JIRA-3" {
+		t.Errorf("expected all tickets ordered by key but got %#v", all)
+	}
+}
@@ -0,0 +1,2 @@
+// Package ticketsync keeps the stored tickets up to date with JIRA
+package ticketsync
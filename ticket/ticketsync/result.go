@@ -0,0 +1,20 @@
+package ticketsync
+
+import "time"
+
+// Result provides the statistics of a synchronisation run
+type Result struct {
+	StartedAt      time.Time         `json:"started_at"`
+	FinishedAt     time.Time         `json:"finished_at"`
+	Tickets        int               `json:"tickets"`
+	TicketsUpdated int               `json:"tickets_updated"`
+	Errors         map[string]string `json:"errors,omitempty"`
+}
+
+func (r *Result) addError(ticketID string, err error) {
+	if r.Errors == nil {
+		r.Errors = make(map[string]string)
+	}
+
+	r.Errors[ticketID] = err.Error()
+}
@@ -0,0 +1,352 @@
+package ticketsync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/ticket/ticketprovider"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+// DefaultBatchSize defines the number of tickets fetched from JIRA with one query
+const DefaultBatchSize = 50
+
+var (
+	// ErrRunning occurs if a synchronisation is started while another one is still running
+	ErrRunning = errors.New("synchronisation is already running")
+
+	// ErrLoadTickets occurs if the tickets to synchronise couldn't be loaded
+	ErrLoadTickets = errors.New("failed to load tickets")
+
+	// ErrTicketNotFound is recorded for tickets which don't exist in the ticket tracker
+	ErrTicketNotFound = errors.New("ticket not found in ticket tracker")
+)
+
+// Syncer synchronises the stored tickets with JIRA or the ticket providers configured for the repositories
+type Syncer struct {
+	db         *sqlx.DB
+	client     jira.Client
+	providers  map[string]ticketprovider.Provider
+	categories *config.Jira
+	batchSize  int
+	mutex      sync.Mutex
+	running    bool
+	last       *Result
+}
+
+// New returns a new syncer
+func New(db *sqlx.DB, client jira.Client) *Syncer {
+	return &Syncer{
+		db:        db,
+		client:    client,
+		batchSize: DefaultBatchSize,
+	}
+}
+
+// WithBatchSize sets the number of tickets fetched from JIRA with one query
+func (s *Syncer) WithBatchSize(size int) *Syncer {
+	if size > 0 {
+		s.batchSize = size
+	}
+
+	return s
+}
+
+// WithStatusCategories sets the configuration mapping the statuses of JIRA to the categories of tickets
+func (s *Syncer) WithStatusCategories(cfg *config.Jira) *Syncer {
+	s.categories = cfg
+
+	return s
+}
+
+// WithProviders sets the ticket providers by the names of the repositories, tickets of branches of other repositories
+// are fetched from JIRA
+func (s *Syncer) WithProviders(providers map[string]ticketprovider.Provider) *Syncer {
+	s.providers = providers
+
+	return s
+}
+
+// Sync fetches all tickets from JIRA in batches and updates summary, status, type, parent, assignee and priority
+// of the tickets. Tickets referenced by branches but not stored yet are created first. Every ticket records when it
+// was fetched and the error if it failed. Failures of single tickets or batches don't stop the synchronisation, they
+// are reported in the result. Only a failed authentication stops it, as all further requests would fail as well.
+// Tickets of branches of repositories with a ticket provider configured are fetched from that provider instead.
+func (s *Syncer) Sync(ctx context.Context) (*Result, error) {
+	if err := s.start(); err != nil {
+		return nil, err
+	}
+	defer s.stop()
+
+	res := &Result{StartedAt: time.Now()}
+
+	tickets, branches, err := s.loadTickets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Tickets = len(tickets)
+
+	groups, err := s.group(ctx, tickets, branches)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range groups {
+		for start := 0; start < len(g.tickets); start += s.batchSize {
+			end := start + s.batchSize
+			if end > len(g.tickets) {
+				end = len(g.tickets)
+			}
+
+			if err := s.syncBatch(ctx, g.provider, g.tickets[start:end], res); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	res.FinishedAt = time.Now()
+	s.setLast(res)
+
+	return res, nil
+}
+
+// Last returns the result of the last synchronisation run, it is nil if it never ran
+func (s *Syncer) Last() *Result {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.last
+}
+
+// Schedule runs the synchronisation in the given interval until the context is done. Errors are logged.
+func (s *Syncer) Schedule(ctx context.Context, interval time.Duration, log logrus.FieldLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			res, err := s.Sync(ctx)
+			if err != nil {
+				log.Errorf("scheduled ticket synchronisation failed: %v", err)
+				continue
+			}
+
+			for ticketID, msg := range res.Errors {
+				log.Warnf("failed to synchronise ticket %s: %s", ticketID, msg)
+			}
+		}
+	}
+}
+
+// loadTickets ensures every ticket referenced by a branch is stored and returns all stored tickets and the branches
+// referencing them
+func (s *Syncer) loadTickets(ctx context.Context) (ticketstore.Tickets, branchstore.Branches, error) {
+	var branches branchstore.Branches
+	if err := branches.ReadWithTicket(ctx, s.db); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrLoadTickets, err)
+	}
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadAll(ctx, s.db); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrLoadTickets, err)
+	}
+
+	stored := tickets.ByKey()
+
+	for _, b := range branches {
+		if _, ok := stored[b.TicketID]; ok {
+			continue
+		}
+
+		t := &ticketstore.Ticket{Key: b.TicketID}
+		if err := t.Create(ctx, s.db); err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrLoadTickets, err)
+		}
+
+		stored[t.Key] = t
+		tickets = append(tickets, t)
+	}
+
+	return tickets, branches, nil
+}
+
+// group holds the tickets fetched from the same provider
+type group struct {
+	provider ticketprovider.Provider
+	tickets  ticketstore.Tickets
+}
+
+// group assigns the tickets to the provider of the repository of their first branch, tickets of repositories without
+// a provider configured are fetched from JIRA. The group of JIRA comes first, the others follow in order of tickets.
+func (s *Syncer) group(
+	ctx context.Context,
+	tickets ticketstore.Tickets,
+	branches branchstore.Branches,
+) ([]*group, error) {
+	groups := []*group{{provider: s.client}}
+
+	if len(s.providers) == 0 {
+		groups[0].tickets = tickets
+
+		return groups, nil
+	}
+
+	var repos repositorystore.Repositories
+	if err := repos.ReadAll(ctx, s.db); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoadTickets, err)
+	}
+
+	names := make(map[int]string)
+	for _, r := range repos {
+		names[r.ID] = r.Name
+	}
+
+	providerOf := make(map[string]string)
+
+	for _, b := range branches {
+		if _, ok := providerOf[b.TicketID]; !ok {
+			providerOf[b.TicketID] = names[b.RepositoryID]
+		}
+	}
+
+	byName := make(map[string]*group)
+
+	for _, t := range tickets {
+		name := providerOf[t.Key]
+		if s.providers[name] == nil {
+			groups[0].tickets = append(groups[0].tickets, t)
+			continue
+		}
+
+		g, ok := byName[name]
+		if !ok {
+			g = &group{provider: s.providers[name]}
+			byName[name] = g
+			groups = append(groups, g)
+		}
+
+		g.tickets = append(g.tickets, t)
+	}
+
+	return groups, nil
+}
+
+func (s *Syncer) syncBatch(
+	ctx context.Context,
+	provider ticketprovider.Provider,
+	tickets ticketstore.Tickets,
+	res *Result,
+) error {
+	keys := make([]string, 0, len(tickets))
+	for _, t := range tickets {
+		keys = append(keys, t.Key)
+	}
+
+	issues, err := provider.Issues(ctx, keys)
+	if errors.Is(err, jira.ErrAuthentication) || errors.Is(err, ticketprovider.ErrAuthentication) {
+		return err
+	}
+
+	found := make(map[string]*jira.Issue)
+	for _, i := range issues {
+		found[i.Key] = i
+	}
+
+	fetchedAt := time.Now()
+
+	for _, t := range tickets {
+		issue := found[t.Key]
+
+		ticketErr := err
+		if ticketErr == nil && issue == nil {
+			ticketErr = ErrTicketNotFound
+		}
+
+		if ticketErr != nil {
+			res.addError(t.Key, ticketErr)
+		}
+
+		if err := s.updateTicket(ctx, t, issue, ticketErr, fetchedAt); err != nil {
+			res.addError(t.Key, err)
+			continue
+		}
+
+		if issue != nil {
+			res.TicketsUpdated++
+		}
+	}
+
+	return nil
+}
+
+func (s *Syncer) updateTicket(
+	ctx context.Context,
+	t *ticketstore.Ticket,
+	issue *jira.Issue,
+	ticketErr error,
+	fetchedAt time.Time,
+) error {
+	t.FetchedAt = &fetchedAt
+	t.FetchError = ""
+
+	if ticketErr != nil {
+		t.FetchError = ticketErr.Error()
+	}
+
+	if issue != nil {
+		t.Summary = issue.Summary
+		t.Status = issue.Status
+		t.Category = issue.Category(s.categories)
+		t.Type = issue.Type
+		t.Parent = issue.Parent
+		t.Assignee = issue.Assignee
+		t.Priority = issue.Priority
+	}
+
+	if err := t.Update(ctx, s.db); err != nil {
+		return fmt.Errorf("failed to update ticket %s: %w", t.Key, err)
+	}
+
+	return nil
+}
+
+func (s *Syncer) start() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.running {
+		return ErrRunning
+	}
+
+	s.running = true
+
+	return nil
+}
+
+func (s *Syncer) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.running = false
+}
+
+func (s *Syncer) setLast(res *Result) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.last = res
+}
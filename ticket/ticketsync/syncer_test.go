@@ -0,0 +1,307 @@
+package ticketsync_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sirupsen/logrus"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketprovider"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+const (
+	testCluster = "test_ticketsync"
+)
+
+func setup(t *testing.T, name string) (*sqlx.DB, *jirafake.Server) {
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+
+	for _, repoName := range []string{"repo1", "repo2"} {
+		repo := &repositorystore.Repository{Name: repoName, URL: repoName + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	for _, b := range []*branchstore.Branch{
+		{Name: "master", RepositoryID: 1},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 1},
+		{Name: "feature/JIRA-1", TicketID: "JIRA-1", RepositoryID: 2},
+		{Name: "feature/JIRA-2", TicketID: "JIRA-2", RepositoryID: 1},
+		{Name: "feature/JIRA-3", TicketID: "JIRA-3", RepositoryID: 2},
+	} {
+		if err := b.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	if err := (&ticketstore.Ticket{Key: "JIRA-1", Summary: "outdated"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	server := jirafake.NewServer()
+	server.AddIssue(&jira.Issue{
+		Key:            "JIRA-1",
+		Summary:        "first",
+		Status:         "Done",
+		StatusCategory: "done",
+		Type:           "Story",
+		Parent:         "JIRA-9",
+		Assignee:       "Jane Doe",
+		Priority:       "High",
+	})
+	server.AddIssue(&jira.Issue{
+		Key:            "JIRA-3",
+		Summary:        "third",
+		Status:         "Code Review",
+		StatusCategory: "indeterminate",
+		Type:           "Bug",
+	})
+
+	return db, server
+}
+
+func TestSyncer_Sync(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server := setup(t, "sync")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	cfg := &config.Jira{BaseURL: &server.URL, StatusCategories: map[string]string{"Code Review": config.CategoryReview}}
+	syncer := ticketsync.New(db, jira.New(cfg)).WithBatchSize(2).WithStatusCategories(cfg)
+
+	if syncer.Last() != nil {
+		t.Error("expected no result before first synchronisation")
+	}
+
+	// 2. test
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.Tickets != 3 || res.TicketsUpdated != 2 || res.FinishedAt.Before(res.StartedAt) {
+		t.Errorf("expected 3 tickets and 2 updated tickets but got %#v", res)
+	}
+
+	if len(res.Errors) != 1 || res.Errors["JIRA-2"] != ticketsync.ErrTicketNotFound.Error() {
+		t.Errorf("expected ticket JIRA-2 not to be found but got %v", res.Errors)
+	}
+
+	if server.Requests() != 2 {
+		t.Errorf("expected tickets to be fetched with 2 requests but got %d", server.Requests())
+	}
+
+	if syncer.Last() != res {
+		t.Error("expected last result to be the one of the synchronisation")
+	}
+
+	ticket := testTicket(t, db, "JIRA-1")
+	if ticket.Summary != "first" || ticket.Status != "Done" || ticket.Type != "Story" || ticket.Parent != "JIRA-9" ||
+		ticket.Assignee != "Jane Doe" || ticket.Priority != "High" || ticket.FetchError != "" || ticket.FetchedAt == nil {
+		t.Errorf("expected fields of ticket to be updated but got %#v", ticket)
+	}
+
+	if ticket.Category != config.CategoryDone {
+		t.Errorf("expected category '%s' from JIRA but got '%s'", config.CategoryDone, ticket.Category)
+	}
+
+	ticket = testTicket(t, db, "JIRA-3")
+	if ticket.Category != config.CategoryReview {
+		t.Errorf("expected category '%s' from configuration but got '%s'", config.CategoryReview, ticket.Category)
+	}
+
+	ticket = testTicket(t, db, "JIRA-2")
+	if ticket.FetchError != ticketsync.ErrTicketNotFound.Error() {
+		t.Errorf("expected fetch error '%v' but got '%s'", ticketsync.ErrTicketNotFound, ticket.FetchError)
+	}
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadAll(context.Background(), db); err != nil || len(tickets) != 3 {
+		t.Errorf("expected one ticket per referenced key but got %d: %v", len(tickets), err)
+	}
+}
+
+func TestSyncer_Sync_Providers(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server := setup(t, "syncProviders")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	dir, err := ioutil.TempDir("", "ticketsync")
+	if err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	path := filepath.Join(dir, "tickets.yaml")
+	content := "tickets:\n  - key: JIRA-3\n    summary: third from file\n    status: Reviewing\n    category: review\n"
+
+	if err = ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	file, err := ticketprovider.NewFile(path)
+	if err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// tickets of repo2 come from the file, JIRA-1 belongs to its first branch in repo1 and stays with JIRA
+	syncer := ticketsync.New(db, jira.New(&config.Jira{BaseURL: &server.URL})).
+		WithProviders(map[string]ticketprovider.Provider{"repo2": file})
+
+	// 2. test
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.Tickets != 3 || res.TicketsUpdated != 2 || len(res.Errors) != 1 {
+		t.Errorf("expected 3 tickets, 2 updated tickets and 1 error but got %#v", res)
+	}
+
+	if server.Requests() != 1 {
+		t.Errorf("expected tickets of JIRA to be fetched with 1 request but got %d", server.Requests())
+	}
+
+	ticket := testTicket(t, db, "JIRA-3")
+	if ticket.Summary != "third from file" || ticket.Status != "Reviewing" || ticket.Category != config.CategoryReview {
+		t.Errorf("expected ticket to be updated from file but got %#v", ticket)
+	}
+
+	ticket = testTicket(t, db, "JIRA-1")
+	if ticket.Summary != "first" {
+		t.Errorf("expected ticket to be updated from JIRA but got %#v", ticket)
+	}
+}
+
+func TestSyncer_Sync_Errors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server := setup(t, "syncErrors")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	// 2. rate limit fails the batch only once retries are exhausted
+	client := jira.New(&config.Jira{BaseURL: &server.URL}).WithRetries(0, 0)
+	syncer := ticketsync.New(db, client).WithBatchSize(2)
+	server.RateLimit(1, time.Second)
+
+	res, err := syncer.Sync(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if res.TicketsUpdated != 1 || len(res.Errors) != 2 {
+		t.Errorf("expected first batch to fail but got %#v", res)
+	}
+
+	ticket := testTicket(t, db, "JIRA-1")
+	if ticket.Summary != "outdated" || ticket.FetchError == "" || ticket.FetchedAt == nil {
+		t.Errorf("expected ticket to record the error but got %#v", ticket)
+	}
+
+	// 3. authentication stops the synchronisation
+	server.SetCredentials("branma", "secret")
+
+	_, err = syncer.Sync(context.Background())
+	if !errors.Is(err, jira.ErrAuthentication) {
+		t.Errorf("expected error '%v' but got '%v'", jira.ErrAuthentication, err)
+	}
+}
+
+func TestSyncer_Schedule(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server := setup(t, "schedule")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	syncer := ticketsync.New(db, jira.New(&config.Jira{BaseURL: &server.URL}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		syncer.Schedule(ctx, 10*time.Millisecond, logrus.New())
+		close(done)
+	}()
+
+	// 2. test
+	deadline := time.Now().Add(5 * time.Second)
+	for syncer.Last() == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	<-done
+
+	if syncer.Last() == nil {
+		t.Error("expected scheduled synchronisation to run")
+	}
+}
+
+func testTicket(t *testing.T, db *sqlx.DB, key string) *ticketstore.Ticket {
+	t.Helper()
+
+	ticket := &ticketstore.Ticket{Key: key}
+	if err := ticket.ReadByKey(context.Background(), db); err != nil {
+		t.Fatalf("failed to load ticket %s: %v", key, err)
+	}
+
+	return ticket
+}
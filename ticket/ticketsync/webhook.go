@@ -0,0 +1,52 @@
+package ticketsync
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+)
+
+// ErrTicketDeleted is recorded for tickets which were deleted in JIRA
+var ErrTicketDeleted = errors.New("ticket was deleted in jira")
+
+// Apply updates the stored ticket immediately with the issue of an event JIRA sent to the webhook. Updated issues
+// overwrite the fields of the ticket, deleted ones are recorded as error. The issue is removed from the cache of the
+// client in any case, so reports don't serve the state before the event. It returns false if the event was ignored,
+// because it is not about issues or the ticket is unknown.
+func (s *Syncer) Apply(ctx context.Context, event *jira.WebhookEvent) (bool, error) {
+	if event == nil || event.Issue == nil {
+		return false, nil
+	}
+
+	if event.Type != jira.EventIssueUpdated && event.Type != jira.EventIssueDeleted {
+		return false, nil
+	}
+
+	s.client.Invalidate(event.Issue.Key)
+
+	t := &ticketstore.Ticket{Key: event.Issue.Key}
+
+	err := t.ReadByKey(ctx, s.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("%w: %v", ErrLoadTickets, err)
+	}
+
+	if event.Type == jira.EventIssueDeleted {
+		err = s.updateTicket(ctx, t, nil, ErrTicketDeleted, time.Now())
+	} else {
+		err = s.updateTicket(ctx, t, event.Issue, nil, time.Now())
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,95 @@
+package ticketsync_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/ticket/ticketsync"
+)
+
+func TestSyncer_Apply(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server := setup(t, "apply")
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	client := jira.New(&config.Jira{BaseURL: &server.URL}).WithCache(time.Hour)
+	syncer := ticketsync.New(db, client)
+
+	if _, err := client.Issue(context.Background(), "JIRA-1"); err != nil {
+		t.Fatalf("preparing cache failed: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name     string
+		event    *jira.WebhookEvent
+		expected bool
+	}{
+		{
+			name: "event nil",
+		},
+		{
+			name:  "other event",
+			event: &jira.WebhookEvent{Type: "jira:issue_created", Issue: &jira.Issue{Key: "JIRA-1"}},
+		},
+		{
+			name:  "unknown ticket",
+			event: &jira.WebhookEvent{Type: jira.EventIssueUpdated, Issue: &jira.Issue{Key: "JIRA-9"}},
+		},
+		{
+			name: "ticket updated",
+			event: &jira.WebhookEvent{
+				Type:  jira.EventIssueUpdated,
+				Issue: &jira.Issue{Key: "JIRA-1", Summary: "changed", Status: "Done", Type: "Bug", Assignee: "Jane Doe"},
+			},
+			expected: true,
+		},
+		{
+			name:     "ticket deleted",
+			event:    &jira.WebhookEvent{Type: jira.EventIssueDeleted, Issue: &jira.Issue{Key: "JIRA-1"}},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := syncer.Apply(context.Background(), testCase.event)
+			if err != nil {
+				t.Fatalf("expected no error but got: %v", err)
+			}
+
+			if actual != testCase.expected {
+				t.Errorf("expected event to be applied %t but got %t", testCase.expected, actual)
+			}
+		})
+	}
+
+	ticket := testTicket(t, db, "JIRA-1")
+	if ticket.Summary != "changed" || ticket.Status != "Done" || ticket.Type != "Bug" || ticket.Assignee != "Jane Doe" ||
+		ticket.FetchError != ticketsync.ErrTicketDeleted.Error() || ticket.FetchedAt == nil {
+		t.Errorf("expected ticket to be updated and marked as deleted but got %#v", ticket)
+	}
+
+	var tickets ticketstore.Tickets
+	if err := tickets.ReadAll(context.Background(), db); err != nil || len(tickets) != 1 {
+		t.Errorf("expected unknown tickets not to be stored but got %d tickets: %v", len(tickets), err)
+	}
+
+	if _, err := client.Issue(context.Background(), "JIRA-1"); err != nil || server.Requests() != 2 {
+		t.Errorf("expected issue of the event to be fetched again but got %d requests: %v", server.Requests(), err)
+	}
+}
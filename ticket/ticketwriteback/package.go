@@ -0,0 +1,3 @@
+// Package ticketwriteback writes the merges into release branches and the releases detected by the git
+// synchronisation back to the tickets in JIRA
+package ticketwriteback
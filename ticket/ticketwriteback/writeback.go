@@ -0,0 +1,164 @@
+package ticketwriteback
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/ticket/ticketstore"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	// EventMerged is the event of a branch merged into a release branch
+	EventMerged = "merged"
+
+	// EventReleased is the event of a version released
+	EventReleased = "released"
+
+	// ActionComment is the action of commenting on a ticket
+	ActionComment = "comment"
+
+	// ActionFixVersion is the action of adding the version to the fix versions of a ticket
+	ActionFixVersion = "fix_version"
+
+	// ActionTransition is the action of moving a ticket by a transition
+	ActionTransition = "transition"
+)
+
+// action is a change of a ticket in JIRA
+type action struct {
+	name string
+	do   func(ctx context.Context) error
+}
+
+// WriteBack writes changes back to JIRA for the repositories configured. Every action is recorded after it was done,
+// so it is done only once per ticket, version and event even if it is triggered again.
+type WriteBack struct {
+	db     *sqlx.DB
+	client jira.Writer
+	cfg    *config.Jira
+}
+
+// New returns a new write back
+func New(db *sqlx.DB, client jira.Writer, cfg *config.Jira) *WriteBack {
+	return &WriteBack{
+		db:     db,
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+// Merged writes back that the branch was merged into the release branch of the version. It returns the number of
+// actions done.
+func (w *WriteBack) Merged(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	b *branchstore.Branch,
+	version *versionstore.Version,
+) (int, error) {
+	cfg := w.cfg.GetWriteBack(repo.Name)
+	if !cfg.IsEnabled() || b.TicketID == "" {
+		return 0, nil
+	}
+
+	comment := fmt.Sprintf(
+		"Branch %s of repository %s was merged into the release branch of version %s.",
+		b.Name,
+		repo.Name,
+		version.Version,
+	)
+
+	return w.apply(ctx, b.TicketID, version, EventMerged, w.actions(cfg, b.TicketID, version, comment,
+		cfg.GetMergedTransition()))
+}
+
+// Released writes back that the version containing the ticket was released. It returns the number of actions done.
+func (w *WriteBack) Released(
+	ctx context.Context,
+	repo *repositorystore.Repository,
+	version *versionstore.Version,
+	ticketKey string,
+) (int, error) {
+	cfg := w.cfg.GetWriteBack(repo.Name)
+	if !cfg.IsEnabled() || ticketKey == "" {
+		return 0, nil
+	}
+
+	comment := fmt.Sprintf("Version %s was released.", version.Version)
+
+	return w.apply(ctx, ticketKey, version, EventReleased, w.actions(cfg, ticketKey, version, comment,
+		cfg.GetReleasedTransition()))
+}
+
+// actions returns the actions enabled by the configuration
+func (w *WriteBack) actions(
+	cfg *config.WriteBack,
+	key string,
+	version *versionstore.Version,
+	comment, transition string,
+) []action {
+	var actions []action
+
+	if cfg.GetComment() {
+		actions = append(actions, action{name: ActionComment, do: func(ctx context.Context) error {
+			return w.client.AddComment(ctx, key, comment)
+		}})
+	}
+
+	if cfg.GetFixVersion() {
+		actions = append(actions, action{name: ActionFixVersion, do: func(ctx context.Context) error {
+			return w.client.AddFixVersion(ctx, key, version.Version)
+		}})
+	}
+
+	if transition != "" {
+		actions = append(actions, action{name: ActionTransition, do: func(ctx context.Context) error {
+			return w.client.Transition(ctx, key, transition)
+		}})
+	}
+
+	return actions
+}
+
+// apply does the actions not done yet, it stops at the first failure so the remaining actions are tried again with
+// the next trigger
+func (w *WriteBack) apply(
+	ctx context.Context,
+	key string,
+	version *versionstore.Version,
+	event string,
+	actions []action,
+) (int, error) {
+	var done int
+
+	for _, a := range actions {
+		record := &ticketstore.Action{TicketKey: key, VersionID: version.ID, Event: event, Action: a.name}
+
+		err := record.ReadByEvent(ctx, w.db)
+		if err == nil {
+			continue
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return done, fmt.Errorf("failed to load %s action of ticket %s: %w", a.name, key, err)
+		}
+
+		if err = a.do(ctx); err != nil {
+			return done, fmt.Errorf("failed to write back %s to ticket %s: %w", a.name, key, err)
+		}
+
+		if err = record.Create(ctx, w.db); err != nil {
+			return done, fmt.Errorf("failed to record %s action of ticket %s: %w", a.name, key, err)
+		}
+
+		done++
+	}
+
+	return done, nil
+}
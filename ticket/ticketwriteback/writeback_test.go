@@ -0,0 +1,155 @@
+package ticketwriteback_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/jira"
+	"github.com/rebel-l/branma_be/jira/jirafake"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/ticket/ticketwriteback"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	testCluster = "test_ticketwriteback"
+)
+
+func setup(t *testing.T, name string) (*sqlx.DB, *jirafake.Server, *versionstore.Version) {
+	t.Helper()
+
+	db := test.Setup(t, testCluster, name)
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	server := jirafake.NewServer()
+	server.AddIssue(&jira.Issue{Key: "JIRA-1", Summary: "first", Status: "In Progress"})
+	server.SetTransitions(map[string]string{"Merge": "Merged", "Release": "Released"})
+
+	return db, server, version
+}
+
+func newConfig(server *jirafake.Server) *config.Jira {
+	url := server.URL
+	enabled := true
+	merged := "Merge"
+	released := "Released"
+
+	return &config.Jira{
+		BaseURL: &url,
+		WriteBack: map[string]*config.WriteBack{
+			"repo": {
+				Comment:            &enabled,
+				FixVersion:         &enabled,
+				MergedTransition:   &merged,
+				ReleasedTransition: &released,
+			},
+		},
+	}
+}
+
+func TestWriteBack_Merged(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server, version := setup(t, "writeBackMerged")
+
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	cfg := newConfig(server)
+	w := ticketwriteback.New(db, jira.New(cfg), cfg)
+	b := &branchstore.Branch{Name: "feature/JIRA-1", TicketID: "JIRA-1"}
+
+	// 2. test
+	done, err := w.Merged(context.Background(), &repositorystore.Repository{Name: "other"}, b, version)
+	if err != nil || done != 0 {
+		t.Fatalf("expected no actions for repository without write back but got %d: %v", done, err)
+	}
+
+	repo := &repositorystore.Repository{Name: "repo"}
+
+	done, err = w.Merged(context.Background(), repo, b, version)
+	if err != nil || done != 3 {
+		t.Fatalf("expected 3 actions but got %d: %v", done, err)
+	}
+
+	issue := server.Issue("JIRA-1")
+	if issue.Status != "Merged" || len(issue.FixVersions) != 1 || issue.FixVersions[0] != "1.0.0" {
+		t.Errorf("expected issue to be merged with fix version 1.0.0 but got %#v", issue)
+	}
+
+	expectedComment := "Branch feature/JIRA-1 of repository repo was merged into the release branch of version 1.0.0."
+	if comments := server.Comments("JIRA-1"); len(comments) != 1 || comments[0] != expectedComment {
+		t.Errorf("expected comment '%s' but got %v", expectedComment, comments)
+	}
+
+	done, err = w.Merged(context.Background(), repo, b, version)
+	if err != nil || done != 0 {
+		t.Errorf("expected no actions on second call but got %d: %v", done, err)
+	}
+
+	if comments := server.Comments("JIRA-1"); len(comments) != 1 {
+		t.Errorf("expected comment to be added only once but got %v", comments)
+	}
+}
+
+func TestWriteBack_Released(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db, server, version := setup(t, "writeBackReleased")
+
+	defer server.Close()
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	cfg := newConfig(server)
+	w := ticketwriteback.New(db, jira.New(cfg), cfg)
+	repo := &repositorystore.Repository{Name: "repo"}
+
+	// 2. test
+	done, err := w.Released(context.Background(), repo, version, "JIRA-2")
+	if !errors.Is(err, jira.ErrNotFound) || done != 0 {
+		t.Errorf("expected error '%v' for unknown ticket but got %d: %v", jira.ErrNotFound, done, err)
+	}
+
+	server.AddIssue(&jira.Issue{Key: "JIRA-2", Status: "Merged"})
+
+	done, err = w.Released(context.Background(), repo, version, "JIRA-2")
+	if err != nil || done != 3 {
+		t.Fatalf("expected failed actions to be retried but got %d: %v", done, err)
+	}
+
+	if issue := server.Issue("JIRA-2"); issue.Status != "Released" {
+		t.Errorf("expected issue to be released but got status '%s'", issue.Status)
+	}
+
+	expectedComment := "Version 1.0.0 was released."
+	if comments := server.Comments("JIRA-2"); len(comments) != 1 || comments[0] != expectedComment {
+		t.Errorf("expected comment '%s' but got %v", expectedComment, comments)
+	}
+}
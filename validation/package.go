@@ -0,0 +1,2 @@
+// Package validation provides the errors of invalid models listing every field which is wrong and why
+package validation
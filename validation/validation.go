@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// CodeRequired is the code of fields which are mandatory but not set
+	CodeRequired = "required"
+
+	// CodeTooLong is the code of fields exceeding their maximum length
+	CodeTooLong = "too_long"
+
+	// CodeInvalidFormat is the code of fields not matching the format expected
+	CodeInvalidFormat = "invalid_format"
+
+	// CodeNotFound is the code of fields referencing something which doesn't exist
+	CodeNotFound = "not_found"
+)
+
+// ErrInvalid is wrapped by every validation error, so it can be detected with errors.Is
+var ErrInvalid = errors.New("validation failed")
+
+// gitSchemes are the schemes of urls git can clone from
+var gitSchemes = map[string]bool{
+	"http":    true,
+	"https":   true,
+	"ssh":     true,
+	"git":     true,
+	"git+ssh": true,
+	"file":    true,
+}
+
+// scpLike matches the scp like syntax of git urls, e.g. git@github.com:rebel-l/branma_be.git
+var scpLike = regexp.MustCompile(`^([\w.+-]+@)?[\w.-]+:[^\s]+$`)
+
+// FieldError describes what is wrong with a field
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error lists the fields which are invalid
+type Error struct {
+	Errors []*FieldError
+}
+
+// Error returns the message of the error
+func (e *Error) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, f := range e.Errors {
+		messages = append(messages, fmt.Sprintf("%s %s", f.Field, f.Message))
+	}
+
+	return fmt.Sprintf("%v: %s", ErrInvalid, strings.Join(messages, ", "))
+}
+
+// Unwrap returns ErrInvalid
+func (e *Error) Unwrap() error {
+	return ErrInvalid
+}
+
+// Add adds an error of the field
+func (e *Error) Add(field, code, message string) {
+	e.Errors = append(e.Errors, &FieldError{Field: field, Code: code, Message: message})
+}
+
+// Required adds an error if the value of the field is empty
+func (e *Error) Required(field, value string) {
+	if value == "" {
+		e.Add(field, CodeRequired, "is required")
+	}
+}
+
+// MaxLength adds an error if the value of the field has more than max characters
+func (e *Error) MaxLength(field, value string, max int) {
+	if utf8.RuneCountInString(value) > max {
+		e.Add(field, CodeTooLong, fmt.Sprintf("must not be longer than %d characters", max))
+	}
+}
+
+// GitURL adds an error if the value of the field is set but not a url git can clone from. Supported are urls with
+// the schemes of git, the scp like syntax and absolute paths.
+func (e *Error) GitURL(field, value string) {
+	if value == "" || IsGitURL(value) {
+		return
+	}
+
+	e.Add(field, CodeInvalidFormat, "must be a git url like https://host/repo.git, git@host:repo.git or /path/to/repo")
+}
+
+// Err returns the error if any field is invalid, otherwise nil
+func (e *Error) Err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
+
+// IsGitURL returns true if git can clone from the url
+func IsGitURL(value string) bool {
+	if strings.HasPrefix(value, "/") {
+		return true
+	}
+
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil {
+			return false
+		}
+
+		return gitSchemes[strings.ToLower(u.Scheme)] && (u.Host != "" || u.Scheme == "file")
+	}
+
+	return scpLike.MatchString(value)
+}
@@ -0,0 +1,83 @@
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rebel-l/branma_be/validation"
+)
+
+func TestIsGitURL(t *testing.T) {
+	testCases := []struct {
+		url      string
+		expected bool
+	}{
+		{url: "https://github.com/rebel-l/branma_be.git", expected: true},
+		{url: "http://localhost:8080/repo", expected: true},
+		{url: "ssh://git@github.com:22/rebel-l/branma_be.git", expected: true},
+		{url: "git://github.com/rebel-l/branma_be.git", expected: true},
+		{url: "file:///srv/git/repo.git", expected: true},
+		{url: "git@github.com:rebel-l/branma_be.git", expected: true},
+		{url: "github.com:rebel-l/branma_be.git", expected: true},
+		{url: "/srv/git/repo.git", expected: true},
+		{url: "ftp://github.com/rebel-l/branma_be.git", expected: false},
+		{url: "https:///branma_be.git", expected: false},
+		{url: "no url", expected: false},
+		{url: "repo.git", expected: false},
+		{url: "", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.url, func(t *testing.T) {
+			if actual := validation.IsGitURL(testCase.url); testCase.expected != actual {
+				t.Errorf("expected %t but got %t", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	e := &validation.Error{}
+	if err := e.Err(); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	e.Required("name", "")
+	e.Required("other", "set")
+	e.MaxLength("name", strings.Repeat("ä", 4), 3)
+	e.MaxLength("other", strings.Repeat("ä", 3), 3)
+	e.GitURL("url", "no url")
+	e.GitURL("empty", "")
+
+	err := e.Err()
+	if !errors.Is(err, validation.ErrInvalid) {
+		t.Errorf("expected error '%v' but got '%v'", validation.ErrInvalid, err)
+	}
+
+	expected := []*validation.FieldError{
+		{Field: "name", Code: validation.CodeRequired, Message: "is required"},
+		{Field: "name", Code: validation.CodeTooLong, Message: "must not be longer than 3 characters"},
+		{
+			Field:   "url",
+			Code:    validation.CodeInvalidFormat,
+			Message: "must be a git url like https://host/repo.git, git@host:repo.git or /path/to/repo",
+		},
+	}
+
+	if len(expected) != len(e.Errors) {
+		t.Fatalf("expected %d field errors but got %d: %v", len(expected), len(e.Errors), err)
+	}
+
+	for i, fe := range expected {
+		if *fe != *e.Errors[i] {
+			t.Errorf("expected field error '%#v' but got '%#v'", fe, e.Errors[i])
+		}
+	}
+
+	msg := "validation failed: name is required, name must not be longer than 3 characters, url must be a git url " +
+		"like https://host/repo.git, git@host:repo.git or /path/to/repo"
+	if err.Error() != msg {
+		t.Errorf("expected message '%s' but got '%s'", msg, err.Error())
+	}
+}
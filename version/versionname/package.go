@@ -0,0 +1,2 @@
+// Package versionname provides the naming rules of versions in release branches and tags
+package versionname
@@ -0,0 +1,93 @@
+package versionname
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rebel-l/branma_be/config"
+)
+
+const (
+	// DefaultReleaseBranchPrefix is used if no prefix for release branches is configured
+	DefaultReleaseBranchPrefix = "release"
+
+	versionSeparators = "/-_"
+)
+
+// ReleaseBranchPrefix returns the configured prefix of release branches or the default one
+func ReleaseBranchPrefix(cfg *config.Git) string {
+	if prefix := cfg.GetReleaseBranchPrefix(); prefix != "" {
+		return prefix
+	}
+
+	return DefaultReleaseBranchPrefix
+}
+
+// FromBranch returns the version of a release branch or an empty string if the branch is not a release branch
+func FromBranch(prefix, branch string) string {
+	if !strings.HasPrefix(branch, prefix) {
+		return ""
+	}
+
+	version := strings.TrimPrefix(branch, prefix)
+	if version == "" || !strings.ContainsAny(version[:1], versionSeparators) {
+		return ""
+	}
+
+	return version[1:]
+}
+
+// FromTag returns the version of a release tag or an empty string if the tag doesn't match the pattern.
+// The first group of the pattern is the version, if the pattern has no group the whole tag is the version.
+func FromTag(pattern *regexp.Regexp, tag string) string {
+	matches := pattern.FindStringSubmatch(tag)
+	if len(matches) == 0 {
+		return ""
+	}
+
+	if len(matches) > 1 {
+		return matches[1]
+	}
+
+	return matches[0]
+}
+
+// Compare returns -1 if a is lower, 1 if a is greater and 0 if both versions are equal
+func Compare(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		if c := comparePart(partsA[i], partsB[i]); c != 0 {
+			return c
+		}
+	}
+
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePart(a, b string) int {
+	numA, errA := strconv.Atoi(a)
+	numB, errB := strconv.Atoi(b)
+
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	switch {
+	case numA < numB:
+		return -1
+	case numA > numB:
+		return 1
+	default:
+		return 0
+	}
+}
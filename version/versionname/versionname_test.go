@@ -0,0 +1,94 @@
+package versionname_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/rebel-l/branma_be/config"
+	"github.com/rebel-l/branma_be/version/versionname"
+)
+
+func TestReleaseBranchPrefix(t *testing.T) {
+	if versionname.ReleaseBranchPrefix(nil) != versionname.DefaultReleaseBranchPrefix {
+		t.Errorf("expected default prefix '%s' but got '%s'",
+			versionname.DefaultReleaseBranchPrefix, versionname.ReleaseBranchPrefix(nil))
+	}
+
+	prefix := "live"
+	if versionname.ReleaseBranchPrefix(&config.Git{ReleaseBranchPrefix: &prefix}) != prefix {
+		t.Errorf("expected configured prefix '%s'", prefix)
+	}
+}
+
+func TestFromBranch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prefix   string
+		branch   string
+		expected string
+	}{
+		{name: "release branch with slash", prefix: "release", branch: "release/2.4.0", expected: "2.4.0"},
+		{name: "release branch with dash", prefix: "release", branch: "release-2.4.0", expected: "2.4.0"},
+		{name: "release branch with underscore", prefix: "live", branch: "live_1.0", expected: "1.0"},
+		{name: "feature branch", prefix: "release", branch: "feature/JIRA-1", expected: ""},
+		{name: "prefix only", prefix: "release", branch: "release", expected: ""},
+		{name: "prefix without separator", prefix: "release", branch: "releases/1.0.0", expected: ""},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := versionname.FromBranch(testCase.prefix, testCase.branch)
+			if testCase.expected != actual {
+				t.Errorf("expected version '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		a        string
+		b        string
+		expected int
+	}{
+		{name: "equal", a: "2.4.0", b: "2.4.0", expected: 0},
+		{name: "lower patch", a: "2.4.0", b: "2.4.1", expected: -1},
+		{name: "numeric instead of lexical", a: "2.10.0", b: "2.9.0", expected: 1},
+		{name: "less parts", a: "2.4", b: "2.4.0", expected: -1},
+		{name: "more parts", a: "2.4.0.1", b: "2.4.0", expected: 1},
+		{name: "no numbers", a: "2.4.0-rc", b: "2.4.0-beta", expected: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := versionname.Compare(testCase.a, testCase.b)
+			if testCase.expected != actual {
+				t.Errorf("expected %d but got %d", testCase.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFromTag(t *testing.T) {
+	testCases := []struct {
+		name     string
+		pattern  string
+		tag      string
+		expected string
+	}{
+		{name: "default pattern", pattern: `^v(\d+(?:\.\d+)*)$`, tag: "v2.4.0", expected: "2.4.0"},
+		{name: "default pattern not matching", pattern: `^v(\d+(?:\.\d+)*)$`, tag: "v2.4.0-rc1", expected: ""},
+		{name: "pattern without group", pattern: `^\d+\.\d+$`, tag: "2.4", expected: "2.4"},
+		{name: "custom prefix", pattern: `^release-(.+)$`, tag: "release-2.4.0", expected: "2.4.0"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := versionname.FromTag(regexp.MustCompile(testCase.pattern), testCase.tag)
+			if testCase.expected != actual {
+				t.Errorf("expected version '%s' but got '%s'", testCase.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package versionstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// BranchVersion represents the assignment of a branch to a version in the database
+type BranchVersion struct {
+	ID         int       `db:"id"`
+	BranchID   int       `db:"branch_id"`
+	VersionID  int       `db:"version_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	ModifiedAt time.Time `db:"modified_at"`
+}
+
+// Create creates current branch version in the database
+func (b *BranchVersion) Create(ctx context.Context, db *sqlx.DB) error {
+	if !b.IsValid() {
+		return ErrDataMissing
+	}
+
+	if b.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`INSERT INTO branch_versions (branch_id, version_id) VALUES (?, ?)`)
+
+	res, err := db.ExecContext(ctx, q, b.BranchID, b.VersionID)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	b.ID = int(id)
+
+	return b.Read(ctx, db)
+}
+
+// Read sets the branch version from database by given ID
+func (b *BranchVersion) Read(ctx context.Context, db *sqlx.DB) error {
+	if b == nil || b.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM branch_versions WHERE id = ?`)
+
+	return db.GetContext(ctx, b, q, b.ID)
+}
+
+// Delete removes the current branch version from database by its ID
+func (b *BranchVersion) Delete(ctx context.Context, db *sqlx.DB) error {
+	if b == nil || b.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`DELETE FROM branch_versions WHERE id = ?`)
+
+	_, err := db.ExecContext(ctx, q, b.ID)
+
+	return err
+}
+
+// IsValid returns true if all mandatory fields are set
+func (b *BranchVersion) IsValid() bool {
+	if b == nil || b.BranchID == 0 || b.VersionID == 0 {
+		return false
+	}
+
+	return true
+}
+
+// BranchVersions represents a collection of BranchVersion
+type BranchVersions []*BranchVersion
+
+// ReadByBranch loads all versions the given branch is assigned to
+func (b *BranchVersions) ReadByBranch(ctx context.Context, db *sqlx.DB, branchID int) error {
+	if b == nil {
+		return ErrDataMissing
+	}
+
+	if branchID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM branch_versions WHERE branch_id = ? ORDER BY id`)
+
+	return db.SelectContext(ctx, b, q, branchID)
+}
+
+// ReadAll loads all assignments of branches to versions
+func (b *BranchVersions) ReadAll(ctx context.Context, db *sqlx.DB) error {
+	if b == nil {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM branch_versions ORDER BY id`)
+
+	return db.SelectContext(ctx, b, q)
+}
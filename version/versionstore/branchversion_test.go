@@ -0,0 +1,96 @@
+package versionstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestBranchVersion(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "branchVersion")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "testrepo", URL: "testrepo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	branch := &branchstore.Branch{Name: "feature/JIRA-1", RepositoryID: repo.ID}
+	if err := branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. create
+	var nilBranchVersion *versionstore.BranchVersion
+	test.CheckErrors(t, versionstore.ErrDataMissing, nilBranchVersion.Create(context.Background(), db))
+
+	bv := &versionstore.BranchVersion{BranchID: branch.ID}
+	test.CheckErrors(t, versionstore.ErrDataMissing, bv.Create(context.Background(), db))
+
+	bv = &versionstore.BranchVersion{ID: 1, BranchID: branch.ID, VersionID: version.ID}
+	test.CheckErrors(t, versionstore.ErrIDIsSet, bv.Create(context.Background(), db))
+
+	bv = &versionstore.BranchVersion{BranchID: branch.ID, VersionID: version.ID}
+	if err := bv.Create(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if bv.ID != 1 || bv.CreatedAt.IsZero() || bv.ModifiedAt.IsZero() {
+		t.Errorf("expected branch version to be loaded after creation but got %#v", bv)
+	}
+
+	duplicate := &versionstore.BranchVersion{BranchID: branch.ID, VersionID: version.ID}
+	test.CheckErrors(
+		t,
+		errors.New("UNIQUE constraint failed: branch_versions.branch_id, branch_versions.version_id"),
+		duplicate.Create(context.Background(), db),
+	)
+
+	// 3. read by branch
+	var assigned versionstore.BranchVersions
+	test.CheckErrors(t, versionstore.ErrIDMissing, assigned.ReadByBranch(context.Background(), db, 0))
+
+	if err := assigned.ReadByBranch(context.Background(), db, branch.ID); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(assigned) != 1 || assigned[0].VersionID != version.ID {
+		t.Errorf("expected branch to be assigned to version %d but got %#v", version.ID, assigned)
+	}
+
+	var all versionstore.BranchVersions
+	if err := all.ReadAll(context.Background(), db); err != nil || len(all) != 1 || all[0].ID != bv.ID {
+		t.Errorf("expected all branch versions to be loaded but got %#v: %v", all, err)
+	}
+
+	// 4. delete
+	if err := bv.Delete(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	err := (&versionstore.BranchVersion{ID: bv.ID}).Read(context.Background(), db)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected error '%v' after deletion but got '%v'", sql.ErrNoRows, err)
+	}
+}
@@ -0,0 +1,2 @@
+// Package versionstore contains the CRUD operations for the versions and their branches on the database
+package versionstore
@@ -0,0 +1,129 @@
+package versionstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Release represents the release of a version in a repository in the database
+type Release struct {
+	ID            int       `db:"id"`
+	VersionID     int       `db:"version_id"`
+	RepositoryID  int       `db:"repository_id"`
+	ReleasedAt    time.Time `db:"released_at"`
+	ReleaseCommit string    `db:"release_commit"`
+	CreatedAt     time.Time `db:"created_at"`
+	ModifiedAt    time.Time `db:"modified_at"`
+}
+
+// Create creates current release in the database
+func (r *Release) Create(ctx context.Context, db *sqlx.DB) error {
+	if !r.IsValid() {
+		return ErrDataMissing
+	}
+
+	if r.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`
+		INSERT INTO version_releases (
+			version_id,
+			repository_id,
+			released_at,
+			release_commit
+		) VALUES (?, ?, ?, ?)
+	`)
+
+	res, err := db.ExecContext(ctx, q, r.VersionID, r.RepositoryID, r.ReleasedAt, r.ReleaseCommit)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	r.ID = int(id)
+
+	return r.Read(ctx, db)
+}
+
+// Read sets the release from database by given ID
+func (r *Release) Read(ctx context.Context, db *sqlx.DB) error {
+	if r == nil || r.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_releases WHERE id = ?`)
+
+	return db.GetContext(ctx, r, q, r.ID)
+}
+
+// ReadByRepository sets the release from database by the given version and repository ID
+func (r *Release) ReadByRepository(ctx context.Context, db *sqlx.DB) error {
+	if r == nil || r.VersionID == 0 || r.RepositoryID == 0 {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_releases WHERE version_id = ? AND repository_id = ?`)
+
+	return db.GetContext(ctx, r, q, r.VersionID, r.RepositoryID)
+}
+
+// IsValid returns true if all mandatory fields are set
+func (r *Release) IsValid() bool {
+	if r == nil || r.VersionID == 0 || r.RepositoryID == 0 || r.ReleasedAt.IsZero() {
+		return false
+	}
+
+	return true
+}
+
+// ReleaseKey identifies the release of a version in a repository
+type ReleaseKey struct {
+	VersionID    int
+	RepositoryID int
+}
+
+// Releases represents a collection of Release
+type Releases []*Release
+
+// ReadAll loads all releases from the database ordered by ID
+func (r *Releases) ReadAll(ctx context.Context, db *sqlx.DB) error {
+	if r == nil {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_releases ORDER BY id`)
+
+	return db.SelectContext(ctx, r, q)
+}
+
+// ReadByRepository loads all releases of versions in the given repository ordered by ID
+func (r *Releases) ReadByRepository(ctx context.Context, db *sqlx.DB, repositoryID int) error {
+	if r == nil {
+		return ErrDataMissing
+	}
+
+	if repositoryID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_releases WHERE repository_id = ? ORDER BY id`)
+
+	return db.SelectContext(ctx, r, q, repositoryID)
+}
+
+// ByKey returns the releases of the collection mapped by version and repository
+func (r Releases) ByKey() map[ReleaseKey]*Release {
+	res := make(map[ReleaseKey]*Release, len(r))
+	for _, release := range r {
+		res[ReleaseKey{VersionID: release.VersionID, RepositoryID: release.RepositoryID}] = release
+	}
+
+	return res
+}
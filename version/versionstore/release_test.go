@@ -0,0 +1,101 @@
+package versionstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestRelease(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "release")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, name := range []string{"repo", "other"} {
+		repo := &repositorystore.Repository{Name: name, URL: name + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	releasedAt := time.Date(2020, 3, 8, 10, 0, 0, 0, time.UTC)
+
+	// 2. create
+	var nilRelease *versionstore.Release
+	test.CheckErrors(t, versionstore.ErrDataMissing, nilRelease.Create(context.Background(), db))
+
+	r := &versionstore.Release{VersionID: version.ID, RepositoryID: 1}
+	test.CheckErrors(t, versionstore.ErrDataMissing, r.Create(context.Background(), db))
+
+	r = &versionstore.Release{ID: 1, VersionID: version.ID, RepositoryID: 1, ReleasedAt: releasedAt}
+	test.CheckErrors(t, versionstore.ErrIDIsSet, r.Create(context.Background(), db))
+
+	r = &versionstore.Release{VersionID: version.ID, RepositoryID: 1, ReleasedAt: releasedAt, ReleaseCommit: "abc"}
+	if err := r.Create(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if r.ID == 0 || !r.ReleasedAt.Equal(releasedAt) || r.CreatedAt.IsZero() || r.ModifiedAt.IsZero() {
+		t.Errorf("expected release to be loaded after creation but got %#v", r)
+	}
+
+	duplicate := &versionstore.Release{VersionID: version.ID, RepositoryID: 1, ReleasedAt: releasedAt}
+	test.CheckErrors(
+		t,
+		errors.New("UNIQUE constraint failed: version_releases.version_id, version_releases.repository_id"),
+		duplicate.Create(context.Background(), db),
+	)
+
+	// 3. read by repository
+	missing := &versionstore.Release{VersionID: version.ID}
+	test.CheckErrors(t, versionstore.ErrDataMissing, missing.ReadByRepository(context.Background(), db))
+
+	actual := &versionstore.Release{VersionID: version.ID, RepositoryID: 1}
+	if err := actual.ReadByRepository(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if actual.ID != r.ID || actual.ReleaseCommit != "abc" {
+		t.Errorf("expected release %#v but got %#v", r, actual)
+	}
+
+	other := &versionstore.Release{VersionID: version.ID, RepositoryID: 2}
+	test.CheckErrors(t, sql.ErrNoRows, other.ReadByRepository(context.Background(), db))
+
+	// 4. read collections
+	var releases versionstore.Releases
+	if err := releases.ReadAll(context.Background(), db); err != nil || len(releases) != 1 {
+		t.Fatalf("expected one release but got %#v: %v", releases, err)
+	}
+
+	if _, ok := releases.ByKey()[versionstore.ReleaseKey{VersionID: version.ID, RepositoryID: 1}]; !ok {
+		t.Errorf("expected release of version %d in repository 1 but got %#v", version.ID, releases.ByKey())
+	}
+
+	releases = nil
+	test.CheckErrors(t, versionstore.ErrIDMissing, releases.ReadByRepository(context.Background(), db, 0))
+
+	if err := releases.ReadByRepository(context.Background(), db, 2); err != nil || len(releases) != 0 {
+		t.Errorf("expected no releases in repository 2 but got %#v: %v", releases, err)
+	}
+}
@@ -0,0 +1,168 @@
+package versionstore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	// ErrIDMissing will be thrown if an ID is expected but not set
+	ErrIDMissing = errors.New("id is mandatory for this operation")
+
+	// ErrIDIsSet will be thrown if no ID is expected but already set
+	ErrIDIsSet = errors.New("id should be not set for this operation, use update instead")
+
+	// ErrDataMissing will be thrown if mandatory data is not set
+	ErrDataMissing = errors.New("no data or mandatory data missing")
+)
+
+// Version represents the version in the database
+type Version struct {
+	ID            int        `db:"id"`
+	Version       string     `db:"version"`
+	BranchID      *int       `db:"branch_id"`
+	ReleasedAt    *time.Time `db:"released_at"`
+	ReleaseCommit string     `db:"release_commit"`
+	CreatedAt     time.Time  `db:"created_at"`
+	ModifiedAt    time.Time  `db:"modified_at"`
+}
+
+// Create creates current version in the database
+func (v *Version) Create(ctx context.Context, db *sqlx.DB) error {
+	if !v.IsValid() {
+		return ErrDataMissing
+	}
+
+	if v.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`
+		INSERT INTO versions (
+			version,
+			branch_id,
+			released_at,
+			release_commit
+		) VALUES (?, ?, ?, ?)
+	`)
+
+	res, err := db.ExecContext(ctx, q, v.getCreateArgs()...)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	v.ID = int(id)
+
+	return v.Read(ctx, db)
+}
+
+// Read sets the version from database by given ID
+func (v *Version) Read(ctx context.Context, db *sqlx.DB) error {
+	if v == nil || v.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM versions WHERE id = ?`)
+
+	return v.get(ctx, db, q, v.ID)
+}
+
+// ReadByVersion sets the version from database by the given version string
+func (v *Version) ReadByVersion(ctx context.Context, db *sqlx.DB) error {
+	if v == nil || v.Version == "" {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM versions WHERE version = ?`)
+
+	return v.get(ctx, db, q, v.Version)
+}
+
+// get scans into a new struct first, as the scan allocates the pointer fields even if no row was found
+func (v *Version) get(ctx context.Context, db *sqlx.DB, q string, args ...interface{}) error {
+	res := &Version{}
+	if err := db.GetContext(ctx, res, q, args...); err != nil {
+		return err
+	}
+
+	*v = *res
+
+	return nil
+}
+
+// Update changes the current version on the database by ID
+func (v *Version) Update(ctx context.Context, db *sqlx.DB) error {
+	if !v.IsValid() {
+		return ErrDataMissing
+	}
+
+	if v.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`
+		UPDATE versions
+		SET version = ?,
+			branch_id = ?,
+			released_at = ?,
+			release_commit = ?
+		WHERE id = ?
+	`)
+
+	if _, err := db.ExecContext(ctx, q, v.getUpdateArgs()...); err != nil {
+		return err
+	}
+
+	return v.Read(ctx, db)
+}
+
+// Delete removes the current version from database by its ID
+func (v *Version) Delete(ctx context.Context, db *sqlx.DB) error {
+	if v == nil || v.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`DELETE FROM versions WHERE id = ?`)
+
+	_, err := db.ExecContext(ctx, q, v.ID)
+
+	return err
+}
+
+// IsValid returns true if all mandatory fields are set
+func (v *Version) IsValid() bool {
+	if v == nil || v.Version == "" {
+		return false
+	}
+
+	return true
+}
+
+// IsReleased returns true if the version was released
+func (v *Version) IsReleased() bool {
+	return v != nil && v.ReleasedAt != nil
+}
+
+func (v *Version) getCreateArgs() []interface{} {
+	return []interface{}{
+		v.Version,
+		v.BranchID,
+		v.ReleasedAt,
+		v.ReleaseCommit,
+	}
+}
+
+func (v *Version) getUpdateArgs() []interface{} {
+	args := v.getCreateArgs()
+	args = append(args, v.ID)
+
+	return args
+}
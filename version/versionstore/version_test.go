@@ -0,0 +1,309 @@
+package versionstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/rebel-l/branma_be/branch/branchstore"
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+const (
+	testCluster = "test_version"
+)
+
+func TestVersion_Create(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeCreate")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "testrepo", URL: "testrepo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	branch := &branchstore.Branch{Name: "release/1.0.0", RepositoryID: repo.ID}
+	if err := branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *versionstore.Version
+		expected    *versionstore.Version
+		expectedErr error
+	}{
+		{
+			name:        "version is nil",
+			expectedErr: versionstore.ErrDataMissing,
+		},
+		{
+			name:        "version has no version",
+			actual:      &versionstore.Version{BranchID: &branch.ID},
+			expectedErr: versionstore.ErrDataMissing,
+		},
+		{
+			name:        "version has ID",
+			actual:      &versionstore.Version{ID: 1, Version: "1.0.0"},
+			expectedErr: versionstore.ErrIDIsSet,
+		},
+		{
+			name:     "success without branch",
+			actual:   &versionstore.Version{Version: "0.9.0"},
+			expected: &versionstore.Version{ID: 1, Version: "0.9.0"},
+		},
+		{
+			name:     "success with branch",
+			actual:   &versionstore.Version{Version: "1.0.0", BranchID: &branch.ID},
+			expected: &versionstore.Version{ID: 2, Version: "1.0.0", BranchID: &branch.ID},
+		},
+		{
+			name:        "duplicate",
+			actual:      &versionstore.Version{Version: "1.0.0"},
+			expectedErr: errors.New("UNIQUE constraint failed: versions.version"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Create(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+			testVersion(t, testCase.expected, testCase.actual)
+		})
+	}
+}
+
+func TestVersion_ReadByVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeReadByVersion")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	if err := (&versionstore.Version{Version: "2.4.0"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *versionstore.Version
+		expected    *versionstore.Version
+		expectedErr error
+	}{
+		{
+			name:        "version is nil",
+			expectedErr: versionstore.ErrDataMissing,
+		},
+		{
+			name:        "version not set",
+			actual:      &versionstore.Version{},
+			expectedErr: versionstore.ErrDataMissing,
+		},
+		{
+			name:        "version not existing",
+			actual:      &versionstore.Version{Version: "1.0.0"},
+			expectedErr: sql.ErrNoRows,
+		},
+		{
+			name:     "success",
+			actual:   &versionstore.Version{Version: "2.4.0"},
+			expected: &versionstore.Version{ID: 1, Version: "2.4.0"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.ReadByVersion(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+			testVersion(t, testCase.expected, testCase.actual)
+
+			if err != nil && testCase.actual.IsReleased() {
+				t.Error("expected version not to be released if it was not found")
+			}
+		})
+	}
+}
+
+func TestVersion_Update(t *testing.T) { // nolint:funlen
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeUpdate")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	repo := &repositorystore.Repository{Name: "testrepo", URL: "testrepo.url"}
+	if err := repo.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	branch := &branchstore.Branch{Name: "release/1.0.0", RepositoryID: repo.ID}
+	if err := branch.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	if err := (&versionstore.Version{Version: "1.0.0"}).Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	releasedAt := time.Date(2020, 1, 19, 10, 30, 0, 0, time.UTC)
+
+	// 2. test
+	testCases := []struct {
+		name        string
+		actual      *versionstore.Version
+		expected    *versionstore.Version
+		expectedErr error
+	}{
+		{
+			name:        "version is nil",
+			expectedErr: versionstore.ErrDataMissing,
+		},
+		{
+			name:        "version has no ID",
+			actual:      &versionstore.Version{Version: "1.0.0"},
+			expectedErr: versionstore.ErrIDMissing,
+		},
+		{
+			name:     "success",
+			actual:   &versionstore.Version{ID: 1, Version: "1.0.0", BranchID: &branch.ID},
+			expected: &versionstore.Version{ID: 1, Version: "1.0.0", BranchID: &branch.ID},
+		},
+		{
+			name: "release",
+			actual: &versionstore.Version{
+				ID:            1,
+				Version:       "1.0.0",
+				BranchID:      &branch.ID,
+				ReleasedAt:    &releasedAt,
+				ReleaseCommit: "a1b2c3",
+			},
+			expected: &versionstore.Version{
+				ID:            1,
+				Version:       "1.0.0",
+				BranchID:      &branch.ID,
+				ReleasedAt:    &releasedAt,
+				ReleaseCommit: "a1b2c3",
+			},
+		},
+		{
+			name:        "not existing version",
+			actual:      &versionstore.Version{ID: 2, Version: "2.0.0"},
+			expectedErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.actual.Update(context.Background(), db)
+			test.CheckErrors(t, testCase.expectedErr, err)
+			testVersion(t, testCase.expected, testCase.actual)
+		})
+	}
+}
+
+func TestVersion_Delete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "storeDelete")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	// 2. test
+	var nilVersion *versionstore.Version
+	test.CheckErrors(t, versionstore.ErrIDMissing, nilVersion.Delete(context.Background(), db))
+
+	if err := version.Delete(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	err := (&versionstore.Version{ID: version.ID}).Read(context.Background(), db)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected error '%v' after deletion but got '%v'", sql.ErrNoRows, err)
+	}
+}
+
+func testVersion(t *testing.T, expected, actual *versionstore.Version) {
+	t.Helper()
+
+	if expected == nil && actual == nil {
+		return
+	}
+
+	if expected != nil && actual == nil || expected == nil && actual != nil {
+		return
+	}
+
+	if expected.ID != actual.ID {
+		t.Errorf("expected ID %d but got %d", expected.ID, actual.ID)
+	}
+
+	if expected.Version != actual.Version {
+		t.Errorf("expected version '%s' but got '%s'", expected.Version, actual.Version)
+	}
+
+	if expected.BranchID == nil && actual.BranchID != nil ||
+		expected.BranchID != nil && (actual.BranchID == nil || *expected.BranchID != *actual.BranchID) {
+		t.Errorf("expected branch ID %v but got %v", expected.BranchID, actual.BranchID)
+	}
+
+	if expected.IsReleased() != actual.IsReleased() ||
+		expected.IsReleased() && !expected.ReleasedAt.Equal(*actual.ReleasedAt) {
+		t.Errorf("expected released at %v but got %v", expected.ReleasedAt, actual.ReleasedAt)
+	}
+
+	if expected.ReleaseCommit != actual.ReleaseCommit {
+		t.Errorf("expected release commit '%s' but got '%s'", expected.ReleaseCommit, actual.ReleaseCommit)
+	}
+
+	if actual.CreatedAt.IsZero() {
+		t.Error("created at should be greater than the zero date")
+	}
+
+	if actual.ModifiedAt.IsZero() {
+		t.Error("modified at should be greater than the zero date")
+	}
+}
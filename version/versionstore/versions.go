@@ -0,0 +1,31 @@
+package versionstore
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Versions represents a collection of Version
+type Versions []*Version
+
+// ReadAll loads all versions from the database ordered by ID
+func (v *Versions) ReadAll(ctx context.Context, db *sqlx.DB) error {
+	if v == nil {
+		return ErrDataMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM versions ORDER BY id`)
+
+	return db.SelectContext(ctx, v, q)
+}
+
+// ByID returns the versions of the collection mapped by their ID
+func (v Versions) ByID() map[int]*Version {
+	res := make(map[int]*Version, len(v))
+	for _, version := range v {
+		res[version.ID] = version
+	}
+
+	return res
+}
@@ -0,0 +1,48 @@
+package versionstore_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestVersions_ReadAll(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "versionsReadAll")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	for _, v := range []string{"1.0.0", "1.1.0"} {
+		if err := (&versionstore.Version{Version: v}).Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. test
+	var nilVersions *versionstore.Versions
+	test.CheckErrors(t, versionstore.ErrDataMissing, nilVersions.ReadAll(context.Background(), db))
+
+	var versions versionstore.Versions
+	if err := versions.ReadAll(context.Background(), db); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(versions) != 2 || versions[0].Version != "1.0.0" || versions[1].Version != "1.1.0" {
+		t.Fatalf("expected all versions ordered by ID but got %#v", versions)
+	}
+
+	byID := versions.ByID()
+	if byID[versions[0].ID] != versions[0] || byID[versions[1].ID] != versions[1] || len(byID) != 2 {
+		t.Errorf("unexpected mapping by ID: %#v", byID)
+	}
+}
@@ -0,0 +1,166 @@
+package versionstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// maxTicketsPerQuery limits the ticket IDs bound to one query, SQLite allows at most 999 variables per statement
+const maxTicketsPerQuery = 999
+
+// VersionTicket represents a ticket contained in the release of a version in a repository in the database
+type VersionTicket struct {
+	ID           int       `db:"id"`
+	VersionID    int       `db:"version_id"`
+	RepositoryID int       `db:"repository_id"`
+	TicketID     string    `db:"ticket_id"`
+	CreatedAt    time.Time `db:"created_at"`
+	ModifiedAt   time.Time `db:"modified_at"`
+}
+
+// Create creates current version ticket in the database
+func (v *VersionTicket) Create(ctx context.Context, db *sqlx.DB) error {
+	if !v.IsValid() {
+		return ErrDataMissing
+	}
+
+	if v.ID != 0 {
+		return ErrIDIsSet
+	}
+
+	q := db.Rebind(`INSERT INTO version_tickets (version_id, repository_id, ticket_id) VALUES (?, ?, ?)`)
+
+	res, err := db.ExecContext(ctx, q, v.VersionID, v.RepositoryID, v.TicketID)
+	if err != nil {
+		return err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	v.ID = int(id)
+
+	return v.Read(ctx, db)
+}
+
+// Read sets the version ticket from database by given ID
+func (v *VersionTicket) Read(ctx context.Context, db *sqlx.DB) error {
+	if v == nil || v.ID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_tickets WHERE id = ?`)
+
+	return db.GetContext(ctx, v, q, v.ID)
+}
+
+// IsValid returns true if all mandatory fields are set
+func (v *VersionTicket) IsValid() bool {
+	if v == nil || v.VersionID == 0 || v.RepositoryID == 0 || v.TicketID == "" {
+		return false
+	}
+
+	return true
+}
+
+// VersionTickets represents a collection of VersionTicket
+type VersionTickets []*VersionTicket
+
+// ReadByVersion loads all tickets contained in the given version in any repository
+func (v *VersionTickets) ReadByVersion(ctx context.Context, db *sqlx.DB, versionID int) error {
+	if v == nil {
+		return ErrDataMissing
+	}
+
+	if versionID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_tickets WHERE version_id = ? ORDER BY ticket_id`)
+
+	return db.SelectContext(ctx, v, q, versionID)
+}
+
+// ReadByRelease loads all tickets contained in the release of the given version in the given repository
+func (v *VersionTickets) ReadByRelease(ctx context.Context, db *sqlx.DB, versionID, repositoryID int) error {
+	if v == nil {
+		return ErrDataMissing
+	}
+
+	if versionID == 0 || repositoryID == 0 {
+		return ErrIDMissing
+	}
+
+	q := db.Rebind(`SELECT * FROM version_tickets WHERE version_id = ? AND repository_id = ? ORDER BY ticket_id`)
+
+	return db.SelectContext(ctx, v, q, versionID, repositoryID)
+}
+
+// ReadByTickets loads all versions containing the given tickets ordered by ticket and version. The tickets are queried
+// in chunks, so any number of tickets can be loaded.
+func (v *VersionTickets) ReadByTickets(ctx context.Context, db *sqlx.DB, ticketIDs []string) error {
+	if v == nil {
+		return ErrDataMissing
+	}
+
+	unique := uniqueTicketIDs(ticketIDs)
+
+	for start := 0; start < len(unique); start += maxTicketsPerQuery {
+		end := start + maxTicketsPerQuery
+		if end > len(unique) {
+			end = len(unique)
+		}
+
+		q, args, err := sqlx.In(
+			`SELECT * FROM version_tickets WHERE ticket_id IN (?) ORDER BY ticket_id, version_id`,
+			unique[start:end],
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = db.SelectContext(ctx, v, db.Rebind(q), args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueTicketIDs returns the ticket IDs sorted and without duplicates
+func uniqueTicketIDs(ticketIDs []string) []string {
+	found := make(map[string]bool, len(ticketIDs))
+	unique := make([]string, 0, len(ticketIDs))
+
+	for _, id := range ticketIDs {
+		if !found[id] {
+			found[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	sort.Strings(unique)
+
+	return unique
+}
+
+// TicketIDs returns the IDs of all tickets in the collection, tickets released in several repositories are returned
+// only once
+func (v VersionTickets) TicketIDs() []string {
+	ids := make([]string, 0, len(v))
+	found := make(map[string]bool, len(v))
+
+	for _, t := range v {
+		if !found[t.TicketID] {
+			found[t.TicketID] = true
+			ids = append(ids, t.TicketID)
+		}
+	}
+
+	return ids
+}
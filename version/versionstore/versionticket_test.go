@@ -0,0 +1,126 @@
+package versionstore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rebel-l/branma_be/repository/repositorystore"
+	"github.com/rebel-l/branma_be/test"
+	"github.com/rebel-l/branma_be/version/versionstore"
+)
+
+func TestVersionTicket(t *testing.T) {
+	if testing.Short() {
+		t.Skip("long running test")
+	}
+
+	// 1. setup
+	db := test.Setup(t, testCluster, "versionTicket")
+
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatalf("unable to close database connection: %v", err)
+		}
+	}()
+
+	version := &versionstore.Version{Version: "1.0.0"}
+	if err := version.Create(context.Background(), db); err != nil {
+		t.Fatalf("preparing data failed: %v", err)
+	}
+
+	for _, name := range []string{"repo", "other"} {
+		repo := &repositorystore.Repository{Name: name, URL: name + ".url"}
+		if err := repo.Create(context.Background(), db); err != nil {
+			t.Fatalf("preparing data failed: %v", err)
+		}
+	}
+
+	// 2. create
+	var nilTicket *versionstore.VersionTicket
+	test.CheckErrors(t, versionstore.ErrDataMissing, nilTicket.Create(context.Background(), db))
+
+	vt := &versionstore.VersionTicket{VersionID: version.ID, RepositoryID: 1}
+	test.CheckErrors(t, versionstore.ErrDataMissing, vt.Create(context.Background(), db))
+
+	vt = &versionstore.VersionTicket{VersionID: version.ID, TicketID: "JIRA-1"}
+	test.CheckErrors(t, versionstore.ErrDataMissing, vt.Create(context.Background(), db))
+
+	vt = &versionstore.VersionTicket{ID: 1, VersionID: version.ID, RepositoryID: 1, TicketID: "JIRA-1"}
+	test.CheckErrors(t, versionstore.ErrIDIsSet, vt.Create(context.Background(), db))
+
+	for _, vt = range []*versionstore.VersionTicket{
+		{VersionID: version.ID, RepositoryID: 1, TicketID: "JIRA-2"},
+		{VersionID: version.ID, RepositoryID: 1, TicketID: "JIRA-1"},
+		{VersionID: version.ID, RepositoryID: 2, TicketID: "JIRA-1"},
+	} {
+		if err := vt.Create(context.Background(), db); err != nil {
+			t.Fatalf("expected no error but got: %v", err)
+		}
+
+		if vt.ID == 0 || vt.CreatedAt.IsZero() || vt.ModifiedAt.IsZero() {
+			t.Errorf("expected version ticket to be loaded after creation but got %#v", vt)
+		}
+	}
+
+	duplicate := &versionstore.VersionTicket{VersionID: version.ID, RepositoryID: 1, TicketID: "JIRA-1"}
+	test.CheckErrors(
+		t,
+		errors.New(
+			"UNIQUE constraint failed: version_tickets.version_id, version_tickets.repository_id, version_tickets.ticket_id",
+		),
+		duplicate.Create(context.Background(), db),
+	)
+
+	// 3. read by version
+	var tickets versionstore.VersionTickets
+	test.CheckErrors(t, versionstore.ErrIDMissing, tickets.ReadByVersion(context.Background(), db, 0))
+
+	if err := tickets.ReadByVersion(context.Background(), db, version.ID); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	ids := tickets.TicketIDs()
+	if len(ids) != 2 || ids[0] != "JIRA-1" || ids[1] != "JIRA-2" {
+		t.Errorf("expected tickets [JIRA-1 JIRA-2] but got %v", ids)
+	}
+
+	// 4. read by release
+	tickets = nil
+	test.CheckErrors(t, versionstore.ErrIDMissing, tickets.ReadByRelease(context.Background(), db, version.ID, 0))
+
+	if err := tickets.ReadByRelease(context.Background(), db, version.ID, 2); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(tickets) != 1 || tickets[0].TicketID != "JIRA-1" || tickets[0].RepositoryID != 2 {
+		t.Errorf("expected only JIRA-1 of repository 2 to be loaded but got %#v", tickets)
+	}
+
+	// 5. read by tickets
+	tickets = nil
+	if err := tickets.ReadByTickets(context.Background(), db, []string{"JIRA-2", "JIRA-3"}); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(tickets) != 1 || tickets[0].TicketID != "JIRA-2" || tickets[0].VersionID != version.ID {
+		t.Errorf("expected only JIRA-2 to be loaded but got %#v", tickets)
+	}
+
+	// more tickets than variables allowed in one query
+	ticketIDs := []string{"JIRA-2", "JIRA-2"}
+	for i := 0; i < 1500; i++ {
+		ticketIDs = append(ticketIDs, fmt.Sprintf("AAA-%d", i), "JIRA-1")
+	}
+
+	tickets = nil
+	if err := tickets.ReadByTickets(context.Background(), db, ticketIDs); err != nil {
+		t.Fatalf("expected no error but got: %v", err)
+	}
+
+	if len(tickets) != 3 || tickets[0].TicketID != "JIRA-1" || tickets[1].TicketID != "JIRA-1" ||
+		tickets[2].TicketID != "JIRA-2" {
+		t.Errorf("expected both releases of JIRA-1 and JIRA-2 once but got %#v", tickets)
+	}
+}